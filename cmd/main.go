@@ -2,20 +2,27 @@ package main
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/DKhorkov/kfc/internal/app"
 	"github.com/DKhorkov/kfc/internal/config"
 	"github.com/DKhorkov/kfc/internal/contentbuilders"
 	controllers "github.com/DKhorkov/kfc/internal/controllers/http"
 	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/previews"
+	"github.com/DKhorkov/kfc/internal/realtime"
 	"github.com/DKhorkov/kfc/internal/repositories"
 	"github.com/DKhorkov/kfc/internal/services"
+	"github.com/DKhorkov/kfc/internal/storages"
 	"github.com/DKhorkov/kfc/internal/uow"
 	"github.com/DKhorkov/kfc/internal/usecases"
+	"github.com/DKhorkov/kfc/internal/workers"
+	"github.com/DKhorkov/libs/cache"
 	"github.com/DKhorkov/libs/db/postgresql"
 	"github.com/DKhorkov/libs/loadenv"
 	"github.com/DKhorkov/libs/logging"
 	"github.com/DKhorkov/libs/tracing"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -63,6 +70,86 @@ func main() {
 	}
 
 	unitOfWork := uow.New(pg)
+	hub := realtime.NewHub(cfg.Realtime.HistorySize)
+
+	var eventsPublisher interfaces.EventsPublisher
+
+	switch cfg.Realtime.Broker {
+	case config.RealtimeBackendMemory:
+		eventsPublisher = hub
+	case config.RealtimeBackendRedis:
+		var broker *realtime.RedisBroker
+		if broker, err = realtime.NewRedisBroker(cfg.Cache, hub, logger); err != nil {
+			panic(err)
+		}
+
+		defer func() {
+			if err = broker.Close(); err != nil {
+				logging.LogError(logger, "Error closing real-time broker", err)
+			}
+		}()
+
+		eventsPublisher = broker
+	default:
+		panic("unknown real-time broker: " + cfg.Realtime.Broker)
+	}
+
+	var presenceRepository interfaces.PresenceRepository
+
+	switch cfg.Realtime.PresenceStorage {
+	case config.RealtimeBackendMemory:
+		presenceRepository = repositories.NewMemoryPresenceRepository()
+	case config.RealtimeBackendRedis:
+		var cacheProvider *cache.CommonProvider
+
+		cacheProvider, err = cache.New(
+			cache.WithHost(cfg.Cache.Host),
+			cache.WithPort(cfg.Cache.Port),
+			cache.WithPassword(cfg.Cache.Password),
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		defer func() {
+			if err = cacheProvider.Close(); err != nil {
+				logging.LogError(logger, "Error closing cache provider", err)
+			}
+		}()
+
+		// Cache provider does not support batch reading of presences, so client is used for it:
+		redisClient := redis.NewClient(
+			&redis.Options{
+				Addr:     fmt.Sprintf("%s:%d", cfg.Cache.Host, cfg.Cache.Port),
+				Password: cfg.Cache.Password,
+			},
+		)
+
+		defer func() {
+			if err = redisClient.Close(); err != nil {
+				logging.LogError(logger, "Error closing Redis client", err)
+			}
+		}()
+
+		presenceRepository = repositories.NewPresenceRepository(cacheProvider, redisClient)
+	default:
+		panic("unknown presence storage: " + cfg.Realtime.PresenceStorage)
+	}
+
+	var blobStorage interfaces.BlobStorage
+
+	switch cfg.Attachments.Storage {
+	case config.AttachmentsStorageLocal:
+		if blobStorage, err = storages.NewLocalBlobStorage(cfg.Attachments.LocalDir); err != nil {
+			panic(err)
+		}
+	case config.AttachmentsStorageS3:
+		if blobStorage, err = storages.NewS3BlobStorage(cfg.Attachments.S3); err != nil {
+			panic(err)
+		}
+	default:
+		panic("unknown attachments storage: " + cfg.Attachments.Storage)
+	}
 
 	usersService := services.NewUsersService(
 		unitOfWork,
@@ -84,21 +171,114 @@ func main() {
 		},
 	)
 
-	usersUseCases := usecases.NewUsersUseCases(usersService, cfg.Security, cfg.Validation)
+	chatsService := services.NewChatsService(
+		unitOfWork,
+		func(tx postgresql.Transaction) interfaces.ChatsRepository {
+			return repositories.NewChatsRepository(tx)
+		},
+	)
+
+	presenceService := services.NewPresenceService(
+		func() interfaces.PresenceRepository {
+			return presenceRepository
+		},
+	)
+
+	messagesService := services.NewMessagesService(
+		unitOfWork,
+		func(tx postgresql.Transaction) interfaces.MessagesRepository {
+			return repositories.NewMessagesRepository(tx)
+		},
+		func(tx postgresql.Transaction) interfaces.ChatsRepository {
+			return repositories.NewChatsRepository(tx)
+		},
+		func(tx postgresql.Transaction) interfaces.AttachmentsRepository {
+			return repositories.NewAttachmentsRepository(tx)
+		},
+		func(tx postgresql.Transaction) interfaces.UsersRepository {
+			return repositories.NewUsersRepository(tx)
+		},
+	)
+
+	imagePreviewGenerator := previews.NewImagePreviewGenerator(cfg.Attachments.Previews)
+	attachmentsService := services.NewAttachmentsService(
+		unitOfWork,
+		func(tx postgresql.Transaction) interfaces.AttachmentsRepository {
+			return repositories.NewAttachmentsRepository(tx)
+		},
+		func() interfaces.BlobStorage {
+			return blobStorage
+		},
+		func() interfaces.ImagePreviewGenerator {
+			return imagePreviewGenerator
+		},
+	)
+
+	usersUseCases := usecases.NewUsersUseCases(
+		usersService,
+		presenceService,
+		cfg.Security,
+		cfg.Validation,
+	)
 	authUseCases := usecases.NewAuthUseCases(
 		authService,
 		usersService,
 		cfg.Security,
 		cfg.Validation,
 	)
+	chatsUseCases := usecases.NewChatsUseCases(
+		chatsService,
+		usersService,
+		eventsPublisher,
+		cfg.Security,
+		cfg.Chats,
+	)
+	linkPreviewFetcher, err := previews.NewLinkPreviewFetcher(cfg.Chats.LinkPreviews)
+	if err != nil {
+		panic(err)
+	}
+
+	messagesUseCases := usecases.NewMessagesUseCases(
+		messagesService,
+		chatsService,
+		presenceService,
+		attachmentsService,
+		eventsPublisher,
+		linkPreviewFetcher,
+		cfg.Security,
+		cfg.Validation,
+		cfg.Realtime,
+		cfg.Attachments,
+		cfg.Chats,
+	)
+	attachmentsUseCases := usecases.NewAttachmentsUseCases(
+		attachmentsService,
+		chatsService,
+		messagesService,
+		eventsPublisher,
+		cfg.Security,
+		cfg.Attachments,
+	)
+	presenceUseCases := usecases.NewPresenceUseCases(
+		presenceService,
+		chatsService,
+		eventsPublisher,
+		cfg.Realtime,
+	)
 
 	c, err := controllers.New(
 		cfg.HTTP,
+		cfg.Realtime,
 		cfg.CORS,
 		cfg.Docs,
 		cfg.Cookies,
 		usersUseCases,
 		authUseCases,
+		chatsUseCases,
+		messagesUseCases,
+		attachmentsUseCases,
+		presenceUseCases,
+		hub,
 		logger,
 		traceProvider,
 		cfg.Tracing.Spans.Root,
@@ -107,6 +287,30 @@ func main() {
 		panic(err)
 	}
 
-	application := app.New(c)
+	previewsWorker := workers.NewPreviewsWorker(
+		attachmentsUseCases,
+		cfg.Attachments.Previews.PollInterval,
+		logger,
+	)
+
+	scheduledMessagesWorker := workers.NewScheduledMessagesWorker(
+		messagesUseCases,
+		cfg.Chats.ScheduledMessages.PollInterval,
+		logger,
+	)
+
+	retentionWorker := workers.NewRetentionWorker(
+		messagesUseCases,
+		cfg.Chats.MessageRetention.PollInterval,
+		logger,
+	)
+
+	linkPreviewsWorker := workers.NewLinkPreviewsWorker(
+		messagesUseCases,
+		cfg.Chats.LinkPreviews.PollInterval,
+		logger,
+	)
+
+	application := app.New(c, previewsWorker, scheduledMessagesWorker, retentionWorker, linkPreviewsWorker)
 	application.Run()
 }
@@ -8,20 +8,26 @@ import (
 	"github.com/DKhorkov/kfc/internal/interfaces"
 )
 
-func New(controller interfaces.Controller) *App {
+func New(controller interfaces.Controller, workers ...interfaces.Worker) *App {
 	return &App{
 		controller: controller,
+		workers:    workers,
 	}
 }
 
 type App struct {
 	controller interfaces.Controller
+	workers    []interfaces.Worker
 }
 
 func (application *App) Run() {
 	// Launch asynchronous for graceful shutdown purpose:
 	go application.controller.Run()
 
+	for _, worker := range application.workers {
+		go worker.Run()
+	}
+
 	// Graceful shutdown. When system signal will be received, signal.Notify function will write it to channel.
 	// After this event, main goroutine will be unblocked (<-stopChannel blocks it) and application will be
 	// gracefully stopped:
@@ -29,4 +35,9 @@ func (application *App) Run() {
 	signal.Notify(stopChannel, syscall.SIGINT, syscall.SIGTERM)
 	<-stopChannel
 	application.controller.Stop()
+
+	// Workers are stopped after controller to finish background processing of already accepted requests:
+	for _, worker := range application.workers {
+		worker.Stop()
+	}
 }
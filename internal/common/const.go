@@ -19,6 +19,28 @@ const (
 
 	FeedbacksLimit = 5
 	FeedbacksTTL   = time.Hour * 24
+
+	MessageTextMaxLength = 4096
+	MessagesDefaultLimit = 50
+	MessagesMaxLimit     = 100
+	SearchQueryMaxLength = 256
+
+	MentionsPerMessageLimit = 50 // Further usernames in message are not resolved
+	ForwardedMessagesLimit  = 100
+
+	ChatTitleMaxLength = 255
+
+	ChatInviteMaxUsesLimit = 100000
+
+	PollQuestionMaxLength = 300
+	PollOptionMaxLength   = 100
+	PollOptionsMinCount   = 2
+	PollOptionsMaxCount   = 10
+
+	LinkURLMaxLength                = 2048 // Longer links in messages are not previewed
+	LinkPreviewTitleMaxLength       = 300
+	LinkPreviewDescriptionMaxLength = 1000
+	LinkPreviewSiteNameMaxLength    = 100
 )
 
 var Timezone *time.Location
@@ -5,4 +5,6 @@ const (
 
 	BackupsDir     = "postgres_backups"
 	BackupLogsPath = BackupsDir + "/backup.log"
+
+	AttachmentsDir = "attachments"
 )
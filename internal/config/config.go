@@ -33,6 +33,23 @@ func New() Config {
 				loadenv.GetEnvAsInt("HTTP_WRITE_TIMEOUT", 2),
 			),
 		},
+		Realtime: RealtimeConfig{
+			HistorySize: loadenv.GetEnvAsInt("REALTIME_HISTORY_SIZE", 1024),
+			HeartbeatInterval: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("REALTIME_HEARTBEAT_INTERVAL", 15),
+			),
+			WriteTimeout: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("REALTIME_WRITE_TIMEOUT", 10),
+			),
+			Broker: loadenv.GetEnv("REALTIME_BROKER", RealtimeBackendMemory),
+			TypingIndicatorTTL: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("REALTIME_TYPING_INDICATOR_TTL", 5),
+			),
+			PresenceStorage: loadenv.GetEnv("REALTIME_PRESENCE_STORAGE", RealtimeBackendMemory),
+			PresenceTTL: time.Second * time.Duration(
+				loadenv.GetEnvAsInt("REALTIME_PRESENCE_TTL", 90),
+			),
+		},
 		Database: postgresql.Config{
 			Host:         loadenv.GetEnv("POSTGRES_HOST", "0.0.0.0"),
 			Port:         loadenv.GetEnvAsInt("POSTGRES_PORT", 5432),
@@ -104,6 +121,107 @@ func New() Config {
 				},
 				";",
 			),
+			AllowedReactions: loadenv.GetEnvAsSlice(
+				"ALLOWED_REACTIONS",
+				[]string{"👍", "👎", "❤️", "🔥", "😂", "😮", "😢", "🙏"},
+				";",
+			),
+		},
+		Chats: ChatsConfig{
+			GroupMembersLimit: loadenv.GetEnvAsInt("CHATS_GROUP_MEMBERS_LIMIT", 200),
+			PinsLimit:         loadenv.GetEnvAsInt("CHATS_PINS_LIMIT", 50),
+			ChannelHandleRegExp: loadenv.GetEnv(
+				"CHATS_CHANNEL_HANDLE_REGEXP",
+				"^[a-z0-9_]{5,32}$",
+			),
+			ScheduledMessages: ScheduledMessagesConfig{
+				PerChatLimit: loadenv.GetEnvAsInt("CHATS_SCHEDULED_MESSAGES_PER_CHAT_LIMIT", 100),
+				MaxDelay: time.Hour * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_SCHEDULED_MESSAGES_MAX_DELAY", 24*365),
+				),
+				BatchSize: loadenv.GetEnvAsInt("CHATS_SCHEDULED_MESSAGES_BATCH_SIZE", 50),
+				PollInterval: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_SCHEDULED_MESSAGES_POLL_INTERVAL", 1000),
+				),
+			},
+			MessageRetention: MessageRetentionConfig{
+				BatchSize: loadenv.GetEnvAsInt("CHATS_MESSAGE_RETENTION_BATCH_SIZE", 100),
+				PollInterval: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_MESSAGE_RETENTION_POLL_INTERVAL", 5000),
+				),
+			},
+			LinkPreviews: LinkPreviewsConfig{
+				Timeout: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_TIMEOUT", 5),
+				),
+				MaxBodySize: int64(
+					loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_MAX_BODY_SIZE", 512*1024), // 512 KB
+				),
+				MaxRedirects: loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_MAX_REDIRECTS", 3),
+				UserAgent:    loadenv.GetEnv("CHATS_LINK_PREVIEWS_USER_AGENT", "kfc-link-preview/1.0"),
+				AllowedPrivateNetworks: loadenv.GetEnvAsSlice(
+					"CHATS_LINK_PREVIEWS_ALLOWED_PRIVATE_NETWORKS",
+					nil,
+					";",
+				),
+				BatchSize: loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_BATCH_SIZE", 10),
+				PollInterval: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_POLL_INTERVAL", 1000),
+				),
+				ClaimTimeout: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("CHATS_LINK_PREVIEWS_CLAIM_TIMEOUT", 60),
+				),
+			},
+		},
+		Attachments: AttachmentsConfig{
+			Storage:       loadenv.GetEnv("ATTACHMENTS_STORAGE", AttachmentsStorageLocal),
+			LocalDir:      loadenv.GetEnv("ATTACHMENTS_LOCAL_DIR", common.AttachmentsDir),
+			MaxSize:       int64(loadenv.GetEnvAsInt("ATTACHMENTS_MAX_SIZE", 20*1024*1024)), // 20 MB
+			MaxPerMessage: loadenv.GetEnvAsInt("ATTACHMENTS_MAX_PER_MESSAGE", 10),
+			AllowedMIMETypes: loadenv.GetEnvAsSlice(
+				"ATTACHMENTS_ALLOWED_MIME_TYPES",
+				[]string{
+					"image/jpeg",
+					"image/png",
+					"image/gif",
+					"image/webp",
+					"video/mp4",
+					"video/webm",
+					"audio/mpeg",
+					"application/ogg",
+					"application/pdf",
+					"application/zip",
+					"text/plain",
+				},
+				";",
+			),
+			S3: AttachmentsS3Config{
+				Endpoint:        loadenv.GetEnv("ATTACHMENTS_S3_ENDPOINT", "localhost:9000"),
+				Region:          loadenv.GetEnv("ATTACHMENTS_S3_REGION", "us-east-1"),
+				Bucket:          loadenv.GetEnv("ATTACHMENTS_S3_BUCKET", "attachments"),
+				AccessKeyID:     loadenv.GetEnv("ATTACHMENTS_S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: loadenv.GetEnv("ATTACHMENTS_S3_SECRET_ACCESS_KEY", ""),
+				UseSSL:          loadenv.GetEnvAsBool("ATTACHMENTS_S3_USE_SSL", false),
+				PartSize: uint64(
+					loadenv.GetEnvAsInt("ATTACHMENTS_S3_PART_SIZE", 5*1024*1024), // 5 MB
+				),
+				PresignedURLTTL: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("ATTACHMENTS_S3_PRESIGNED_URL_TTL", 900),
+				),
+			},
+			Previews: AttachmentsPreviewsConfig{
+				SmallThumbnailSize:  loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_SMALL_THUMBNAIL_SIZE", 160),
+				MediumThumbnailSize: loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_MEDIUM_THUMBNAIL_SIZE", 640),
+				JPEGQuality:         loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_JPEG_QUALITY", 80),
+				MaxPixels:           loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_MAX_PIXELS", 50_000_000),
+				BatchSize:           loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_BATCH_SIZE", 10),
+				PollInterval: time.Millisecond * time.Duration(
+					loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_POLL_INTERVAL", 1000),
+				),
+				ClaimTimeout: time.Second * time.Duration(
+					loadenv.GetEnvAsInt("ATTACHMENTS_PREVIEWS_CLAIM_TIMEOUT", 300),
+				),
+			},
 		},
 		CORS: CORSConfig{
 			AllowedOrigins:   loadenv.GetEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"*"}, ", "),
@@ -316,6 +434,25 @@ type HTTPConfig struct {
 	WriteTimeout time.Duration
 }
 
+const (
+	RealtimeBackendMemory = "memory" // State is kept by current instance only. Suitable for single-node runs.
+	RealtimeBackendRedis  = "redis"  // State is shared between all instances through Redis.
+)
+
+// RealtimeConfig configures long-lived real-time connections, which are not limited by HTTPConfig timeouts.
+type RealtimeConfig struct {
+	HistorySize        int // Number of last events, which are kept to be replayed after reconnection.
+	HeartbeatInterval  time.Duration
+	WriteTimeout       time.Duration // Timeout of single write to real-time connection.
+	Broker             string        // One of RealtimeBackend constants.
+	TypingIndicatorTTL time.Duration // Time, after which typing indicator is hidden, if it was not prolonged.
+	PresenceStorage    string        // One of RealtimeBackend constants.
+
+	// Time, after which User is considered offline, if his connection stopped prolonging presence
+	// (for example, when instance crashed).
+	PresenceTTL time.Duration
+}
+
 type EmailConfig struct {
 	SMTP              SMTPConfig
 	VerifyEmailURL    string
@@ -330,9 +467,10 @@ type SMTPConfig struct {
 }
 
 type ValidationConfig struct {
-	EmailRegExp     string
-	PasswordRegExps []string // Slice of rules to pass, because Go's regex doesn't support backtracking.
-	UsernameRegExps []string
+	EmailRegExp      string
+	PasswordRegExps  []string // Slice of rules to pass, because Go's regex doesn't support backtracking.
+	UsernameRegExps  []string
+	AllowedReactions []string // Emoji, which can be used as reactions on messages.
 }
 
 type CacheConfig struct {
@@ -341,6 +479,81 @@ type CacheConfig struct {
 	Password string
 }
 
+type ChatsConfig struct {
+	GroupMembersLimit int // Maximum number of members in group chat, including owner.
+	PinsLimit         int // Maximum number of pinned messages in chat.
+
+	// ChannelHandleRegExp is a rule for public handles of channels. Handles are stored in lower case.
+	ChannelHandleRegExp string
+
+	ScheduledMessages ScheduledMessagesConfig
+	MessageRetention  MessageRetentionConfig
+	LinkPreviews      LinkPreviewsConfig
+}
+
+type ScheduledMessagesConfig struct {
+	PerChatLimit int           // Maximum number of pending scheduled messages of User in one chat.
+	MaxDelay     time.Duration // Maximum time between scheduling of message and its sending.
+	BatchSize    int           // Number of due messages, which are taken by dispatcher at once.
+	PollInterval time.Duration
+}
+
+type MessageRetentionConfig struct {
+	BatchSize    int // Number of expired messages, which are deleted in one transaction.
+	PollInterval time.Duration
+}
+
+type LinkPreviewsConfig struct {
+	Timeout      time.Duration // Limits whole fetching of page, including redirects and reading of its content.
+	MaxBodySize  int64         // Only beginning of page of this size in bytes is read to find metadata.
+	MaxRedirects int
+	UserAgent    string
+
+	// AllowedPrivateNetworks lists private networks in CIDR notation, which pages are allowed to be fetched
+	// from. Any other private, loopback and link-local addresses are blocked to prevent SSRF.
+	AllowedPrivateNetworks []string
+
+	BatchSize    int // Number of links, which are taken by worker at once.
+	PollInterval time.Duration
+	ClaimTimeout time.Duration // Link, which is fetched longer, is returned to queue.
+}
+
+const (
+	AttachmentsStorageLocal = "local" // Attachments are stored in directory on filesystem of instance.
+	AttachmentsStorageS3    = "s3"    // Attachments are stored in S3-compatible object storage.
+)
+
+type AttachmentsConfig struct {
+	Storage          string // One of AttachmentsStorage constants.
+	LocalDir         string // Directory of local storage.
+	MaxSize          int64  // Maximum size of single attachment in bytes.
+	MaxPerMessage    int
+	AllowedMIMETypes []string // MIME types are detected by content of attachment, not by its name.
+	S3               AttachmentsS3Config
+	Previews         AttachmentsPreviewsConfig
+}
+
+type AttachmentsS3Config struct {
+	Endpoint        string // Host and port of storage without scheme.
+	Region          string
+	Bucket          string // Bucket is created on start, if it does not exist.
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	PartSize        uint64        // Size of part of multipart upload in bytes. Can not be less than 5 MB.
+	PresignedURLTTL time.Duration // Lifetime of URLs for direct downloads from storage.
+}
+
+type AttachmentsPreviewsConfig struct {
+	SmallThumbnailSize  int // Maximum width and height of small thumbnail in pixels.
+	MediumThumbnailSize int // Maximum width and height of medium thumbnail in pixels.
+	JPEGQuality         int
+	MaxPixels           int // Larger images are not decoded to protect memory.
+	BatchSize           int // Number of attachments, which are taken by worker at once.
+	PollInterval        time.Duration
+	ClaimTimeout        time.Duration // Attachment, which is processed longer, is returned to queue.
+}
+
 type CORSConfig struct {
 	AllowedOrigins   []string
 	AllowedMethods   []string
@@ -377,6 +590,7 @@ type CookiesConfig struct {
 
 type Config struct {
 	HTTP        HTTPConfig
+	Realtime    RealtimeConfig
 	Security    security.Config
 	Database    postgresql.Config
 	Logging     logging.Config
@@ -385,6 +599,8 @@ type Config struct {
 	Email       EmailConfig
 	Cache       CacheConfig
 	Validation  ValidationConfig
+	Chats       ChatsConfig
+	Attachments AttachmentsConfig
 	CORS        CORSConfig
 	Docs        DocsConfig
 	Cookies     CookiesConfig
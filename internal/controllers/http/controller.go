@@ -10,6 +10,7 @@ import (
 	"github.com/DKhorkov/kfc/internal/config"
 	"github.com/DKhorkov/kfc/internal/controllers/http/handlers"
 	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/realtime"
 	"github.com/DKhorkov/libs/logging"
 	middlewares "github.com/DKhorkov/libs/middlewares/http"
 	"github.com/DKhorkov/libs/tracing"
@@ -19,6 +20,7 @@ import (
 
 type Controller struct {
 	server *http.Server
+	hub    *realtime.Hub
 	logger logging.Logger
 	host   string
 	port   string
@@ -26,11 +28,17 @@ type Controller struct {
 
 func New(
 	httpConfig config.HTTPConfig,
+	realtimeConfig config.RealtimeConfig,
 	corsConfig config.CORSConfig,
 	docsConfig config.DocsConfig,
 	cookiesConfig config.CookiesConfig,
 	usersUseCases interfaces.UsersUseCases,
 	authUseCases interfaces.AuthUseCases,
+	chatsUseCases interfaces.ChatsUseCases,
+	messagesUseCases interfaces.MessagesUseCases,
+	attachmentsUseCases interfaces.AttachmentsUseCases,
+	presenceUseCases interfaces.PresenceUseCases,
+	hub *realtime.Hub,
 	logger logging.Logger,
 	traceProvider tracing.Provider,
 	spanConfig tracing.SpanConfig,
@@ -45,6 +53,23 @@ func New(
 		cookiesConfig,
 		usersUseCases,
 		authUseCases,
+		chatsUseCases,
+		messagesUseCases,
+		attachmentsUseCases,
+	)
+
+	// Real-time connections are served by separate router to bypass middlewares of rootMux.
+	// All other requests are passed to rootMux:
+	realtimeMux := mux.NewRouter()
+	realtimeMux.NotFoundHandler = rootMux
+	handlers.SetupRealtimeHandlers(
+		realtimeMux,
+		corsConfig,
+		realtimeConfig,
+		usersUseCases,
+		presenceUseCases,
+		hub,
+		logger,
 	)
 
 	httpHandler := cors.New(
@@ -55,7 +80,7 @@ func New(
 			MaxAge:           corsConfig.MaxAge,
 			AllowCredentials: corsConfig.AllowCredentials,
 		},
-	).Handler(rootMux)
+	).Handler(realtimeMux)
 
 	addr := net.JoinHostPort(httpConfig.Host, httpConfig.Port)
 	server := &http.Server{
@@ -68,6 +93,7 @@ func New(
 
 	return &Controller{
 		server: server,
+		hub:    hub,
 		logger: logger,
 		host:   httpConfig.Host,
 		port:   httpConfig.Port,
@@ -90,6 +116,9 @@ func (c *Controller) Run() {
 }
 
 func (c *Controller) Stop() {
+	// Closes real-time connections, because they are not tracked by server due to hijacking:
+	c.hub.Close()
+
 	// Stops accepting new requests and processes already received requests:
 	err := c.server.Shutdown(context.Background())
 	if err != nil {
@@ -0,0 +1,117 @@
+package attachments
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route GET /chats/{id}/attachments/{attachmentID} attachments DownloadAttachment
+//
+// DownloadAttachment
+//
+// Provides content of attachment of Chat with provided ID. If storage supports direct downloads, redirects
+// to temporary URL of attachment in storage instead.
+//
+// Produces:
+// - application/octet-stream
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: OK
+//	303: SeeOther
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// DownloadAttachmentHandler provides content of attachment as file.
+func DownloadAttachmentHandler(u interfaces.AttachmentsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		attachmentID, err := strconv.ParseUint(mux.Vars(r)[AttachmentIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		download, err := u.DownloadAttachment(
+			r.Context(),
+			accessTokenCookie.Value,
+			chatID,
+			attachmentID,
+		)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrAttachmentNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		writeDownload(w, r, download)
+	}
+}
+
+// writeDownload redirects to temporary URL of downloaded file or writes its content to response.
+func writeDownload(w http.ResponseWriter, r *http.Request, download *domains.AttachmentDownload) {
+	if download.URL != "" {
+		http.Redirect(w, r, download.URL, http.StatusSeeOther)
+
+		return
+	}
+
+	defer func() {
+		_ = download.Content.Close()
+	}()
+
+	// Content type is detected on upload, so browser should not guess it on its own:
+	w.Header().Set("Content-Type", download.MIMEType)
+	w.Header().Set("Content-Length", strconv.FormatUint(download.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set(
+		"Content-Disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": download.Filename}),
+	)
+	w.WriteHeader(http.StatusOK)
+
+	// Headers have already been sent, so error can not be reported to client:
+	_, _ = io.Copy(w, download.Content)
+}
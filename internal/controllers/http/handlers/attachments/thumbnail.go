@@ -0,0 +1,95 @@
+package attachments
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route GET /chats/{id}/attachments/{attachmentID}/thumbnails/{name} attachments DownloadAttachmentThumbnail
+//
+// DownloadAttachmentThumbnail
+//
+// Provides content of thumbnail of image attachment of Chat with provided ID. Thumbnail name is one of
+// "small" and "medium". If storage supports direct downloads, redirects to temporary URL of thumbnail in
+// storage instead.
+//
+// Produces:
+// - application/octet-stream
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: OK
+//	303: SeeOther
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// DownloadAttachmentThumbnailHandler provides content of thumbnail of image attachment as file.
+func DownloadAttachmentThumbnailHandler(u interfaces.AttachmentsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		attachmentID, err := strconv.ParseUint(mux.Vars(r)[AttachmentIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		download, err := u.DownloadAttachmentThumbnail(
+			r.Context(),
+			accessTokenCookie.Value,
+			chatID,
+			attachmentID,
+			domains.ThumbnailName(mux.Vars(r)[ThumbnailNameRouteKey]),
+		)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrAttachmentNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		writeDownload(w, r, download)
+	}
+}
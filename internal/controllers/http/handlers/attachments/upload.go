@@ -0,0 +1,136 @@
+package attachments
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	ChatIDRouteKey        = "id"
+	AttachmentIDRouteKey  = "attachmentID"
+	ThumbnailNameRouteKey = "name"
+
+	fileFormKey = "file"
+)
+
+// swagger:route POST /chats/{id}/attachments attachments UploadAttachment
+//
+// UploadAttachment
+//
+// Uploads file to Chat with provided ID. Request must be "multipart/form-data" with file in "file" field.
+// Uploaded attachment is visible only to current User, until it is sent with Message. In channels only owner and
+// admins, who can post, can upload files.
+//
+// Consumes:
+// - multipart/form-data
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: Attachment
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	413: RequestEntityTooLarge
+//	415: UnsupportedMediaType
+//	500: InternalServerError
+
+// UploadAttachmentHandler uploads file to Chat. File is streamed to storage without buffering it in memory.
+func UploadAttachmentHandler(u interfaces.AttachmentsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		// Other form fields are skipped until file is found:
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				http.Error(w, "file is required", http.StatusBadRequest)
+
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			if part.FormName() != fileFormKey {
+				continue
+			}
+
+			dto := domains.RawUploadAttachmentDTO{
+				AccessToken: accessTokenCookie.Value,
+				ChatID:      chatID,
+				Filename:    part.FileName(),
+				Content:     part,
+			}
+
+			attachment, err := u.UploadAttachment(r.Context(), dto)
+
+			switch {
+			case errors.Is(err, customerrors.ErrInvalidJWT):
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+
+				return
+			case errors.Is(err, customerrors.ErrNotChatMember),
+				errors.Is(err, customerrors.ErrChatPermissionDenied):
+				http.Error(w, err.Error(), http.StatusForbidden)
+
+				return
+			case errors.Is(err, customerrors.ErrAttachmentTooLarge):
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+
+				return
+			case errors.Is(err, customerrors.ErrAttachmentTypeNotAllowed):
+				http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+
+				return
+			case err != nil:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+
+			if err = json.NewEncoder(w).Encode(mappers.MapAttachment(*attachment)); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			return
+		}
+	}
+}
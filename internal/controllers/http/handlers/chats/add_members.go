@@ -0,0 +1,105 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route POST /chats/{id}/members chats AddChatMembers
+//
+// AddChatMembers
+//
+// Adds Users to group chat. Available for owner and admins of the chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	409: Conflict
+//	500: InternalServerError
+
+// AddChatMembersHandler adds Users to group chat.
+func AddChatMembersHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.AddChatMembersInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.AddChatMembers(r.Context(), accessTokenCookie.Value, chatID, input.Body.UserIDs)
+		if err != nil {
+			handleChatMembersError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleChatMembersError writes response with status code, which corresponds to error of chat members
+// management.
+func handleChatMembersError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrValidationFailed),
+		errors.Is(err, customerrors.ErrNotGroupChat):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, customerrors.ErrChatMemberNotFound),
+		errors.Is(err, customerrors.ErrChatNotFound),
+		errors.Is(err, customerrors.ErrUserNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, customerrors.ErrNotChatMember),
+		errors.Is(err, customerrors.ErrChatPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, customerrors.ErrChatMembersLimitExceeded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
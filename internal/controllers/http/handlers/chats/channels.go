@@ -0,0 +1,215 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/gorilla/mux"
+)
+
+const (
+	handleQueryKey = "handle"
+	limitQueryKey  = "limit"
+	offsetQueryKey = "offset"
+)
+
+// swagger:route POST /chats/channels chats CreateChannel
+//
+// CreateChannel
+//
+// Creates channel, where current User becomes owner. Only owner and admins can post to channel, while
+// subscribers can only read and react. Channel with handle is public and can be found by it.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	409: Conflict
+//	500: InternalServerError
+
+// CreateChannelHandler creates channel.
+func CreateChannelHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreateChannelInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.CreateChannel(
+			r.Context(),
+			domains.RawCreateChannelDTO{
+				AccessToken: accessTokenCookie.Value,
+				Title:       input.Body.Title,
+				Handle:      input.Body.Handle,
+			},
+		)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrChannelHandleTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// swagger:route GET /channels chats GetChannels
+//
+// GetChannels
+//
+// Provides list of public channels, which can be found by part of their handle.
+//
+// Responses:
+//	200: []Channel
+//	500: InternalServerError
+
+// GetChannelsHandler provides public channels with filtration and pagination.
+func GetChannelsHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var filters *domains.ChannelsFilters
+		if handle := r.URL.Query().Get(handleQueryKey); handle != "" {
+			filters = &domains.ChannelsFilters{
+				Handle: pointers.New(handle),
+			}
+		}
+
+		limitStr := r.URL.Query().Get(limitQueryKey)
+		limit, _ := strconv.Atoi(limitStr)
+
+		offsetStr := r.URL.Query().Get(offsetQueryKey)
+		offset, _ := strconv.Atoi(offsetStr)
+
+		var pagination *domains.Pagination
+		if offset != 0 && limit != 0 {
+			pagination = &domains.Pagination{
+				Offset: pointers.New(uint64(offset)),
+				Limit:  pointers.New(uint64(limit)),
+			}
+		}
+
+		channels, err := u.GetChannels(r.Context(), filters, pagination)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChannels(channels)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route POST /chats/{id}/subscribe chats SubscribeToChannel
+//
+// SubscribeToChannel
+//
+// Subscribes current User to public channel. Private channels can be joined only by invites. Subscription
+// is cancelled by leaving the channel.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// SubscribeToChannelHandler subscribes current User to public channel.
+func SubscribeToChannelHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.SubscribeToChannel(r.Context(), accessTokenCookie.Value, chatID)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrChatNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,84 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	IDRouteKey       = "id"
+	MemberIDRouteKey = "userID"
+)
+
+// swagger:route GET /chats/{id} chats GetChatByID
+//
+// GetChatByID
+//
+// Provides Chat with specified ID, if current User is a member of it.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// GetChatByIDHandler provides Chat with provided ID.
+func GetChatByIDHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.GetChatByID(r.Context(), accessTokenCookie.Value, chatID)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrChatNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
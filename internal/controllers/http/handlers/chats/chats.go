@@ -0,0 +1,59 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+// swagger:route GET /chats chats GetChats
+//
+// GetChats
+//
+// Provides list of Chats, which current User is a member of.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: []Chat
+//	401: Unauthorized
+//	500: InternalServerError
+
+// GetChatsHandler provides Chats of current User.
+func GetChatsHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chats, err := u.GetChats(r.Context(), accessTokenCookie.Value)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChats(chats)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
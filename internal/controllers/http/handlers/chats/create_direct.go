@@ -0,0 +1,86 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+// swagger:route POST /chats/direct chats CreateDirectChat
+//
+// CreateDirectChat
+//
+// Opens direct chat between current User and User with provided ID.
+// If such chat already exists, provides it.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// CreateDirectChatHandler opens direct chat with provided User.
+func CreateDirectChatHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreateDirectChatInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.CreateDirectChat(r.Context(), accessTokenCookie.Value, input.Body.UserID)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrUserNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,98 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+// swagger:route POST /chats/groups chats CreateGroupChat
+//
+// CreateGroupChat
+//
+// Creates group chat, where current User becomes owner and provided Users become members.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	409: Conflict
+//	500: InternalServerError
+
+// CreateGroupChatHandler creates group chat.
+func CreateGroupChatHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreateGroupChatInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.CreateGroupChat(
+			r.Context(),
+			domains.RawCreateGroupChatDTO{
+				AccessToken: accessTokenCookie.Value,
+				Title:       input.Body.Title,
+				MemberIDs:   input.Body.MemberIDs,
+			},
+		)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrUserNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case errors.Is(err, customerrors.ErrChatMembersLimitExceeded):
+			http.Error(w, err.Error(), http.StatusConflict)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package chats
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route POST /chats/{id}/members/{userID}/demote chats DemoteChatMember
+//
+// DemoteChatMember
+//
+// Makes admin of group chat a regular member. Available only for owner of the chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// DemoteChatMemberHandler makes admin of group chat a regular member.
+func DemoteChatMemberHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		memberID, err := strconv.ParseUint(mux.Vars(r)[MemberIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		chat, err := u.DemoteChatMember(r.Context(), accessTokenCookie.Value, chatID, memberID)
+		if err != nil {
+			handleChatMembersError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,199 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route PUT /chats/{id}/draft chats SaveChatDraft
+//
+// SaveChatDraft
+//
+// Saves unsent message of current User in Chat. Draft is synced with other sessions of the User.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: ChatDraft
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	500: InternalServerError
+
+// SaveChatDraftHandler saves draft of current User in Chat.
+func SaveChatDraftHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.SaveChatDraftInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawSaveChatDraftDTO{
+			AccessToken: accessTokenCookie.Value,
+			ChatID:      chatID,
+			Text:        input.Body.Text,
+		}
+
+		draft, err := u.SaveChatDraft(r.Context(), dto)
+		if err != nil {
+			handleChatDraftError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChatDraft(*draft)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route GET /chats/{id}/draft chats GetChatDraft
+//
+// GetChatDraft
+//
+// Provides unsent message of current User in Chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: ChatDraft
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// GetChatDraftHandler provides draft of current User in Chat.
+func GetChatDraftHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		draft, err := u.GetChatDraft(r.Context(), accessTokenCookie.Value, chatID)
+		if err != nil {
+			handleChatDraftError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChatDraft(*draft)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route DELETE /chats/{id}/draft chats DeleteChatDraft
+//
+// DeleteChatDraft
+//
+// Deletes unsent message of current User in Chat. Draft is removed from other sessions of the User.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// DeleteChatDraftHandler deletes draft of current User in Chat.
+func DeleteChatDraftHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err = u.DeleteChatDraft(r.Context(), accessTokenCookie.Value, chatID); err != nil {
+			handleChatDraftError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleChatDraftError writes response with status code, which corresponds to error of chat drafts
+// management.
+func handleChatDraftError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrValidationFailed):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, customerrors.ErrNotChatMember):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, customerrors.ErrChatDraftNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,268 @@
+package chats
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	InviteIDRouteKey    = "inviteID"
+	InviteTokenRouteKey = "token"
+)
+
+// swagger:route POST /chats/{id}/invites chats CreateChatInvite
+//
+// CreateChatInvite
+//
+// Creates invite link to group chat with optional expiration time and number of uses. Available for owner
+// and admins of the chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: ChatInvite
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// CreateChatInviteHandler creates invite link to group chat.
+func CreateChatInviteHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreateChatInviteInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		invite, err := u.CreateChatInvite(
+			r.Context(),
+			domains.RawCreateChatInviteDTO{
+				AccessToken: accessTokenCookie.Value,
+				ChatID:      chatID,
+				ExpiresAt:   input.Body.ExpiresAt,
+				MaxUses:     input.Body.MaxUses,
+			},
+		)
+		if err != nil {
+			handleChatInvitesError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChatInvite(*invite)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// swagger:route GET /chats/{id}/invites chats GetChatInvites
+//
+// GetChatInvites
+//
+// Returns all invite links to group chat, including expired and revoked ones, starting from the newest.
+// Available for owner and admins of the chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: []ChatInvite
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	500: InternalServerError
+
+// GetChatInvitesHandler returns invite links to group chat.
+func GetChatInvitesHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		invites, err := u.GetChatInvites(r.Context(), accessTokenCookie.Value, chatID)
+		if err != nil {
+			handleChatInvitesError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChatInvites(invites)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route DELETE /chats/{id}/invites/{inviteID} chats RevokeChatInvite
+//
+// RevokeChatInvite
+//
+// Revokes invite link to group chat, so nobody can join the chat by it anymore. Available for owner and
+// admins of the chat.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// RevokeChatInviteHandler revokes invite link to group chat.
+func RevokeChatInviteHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		inviteID, err := strconv.ParseUint(mux.Vars(r)[InviteIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err = u.RevokeChatInvite(r.Context(), accessTokenCookie.Value, chatID, inviteID); err != nil {
+			handleChatInvitesError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// swagger:route POST /invites/{token} chats JoinChatByInvite
+//
+// JoinChatByInvite
+//
+// Adds current User to group chat by invite link. If current User is already a member of the chat,
+// invite is not used.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Chat
+//	401: Unauthorized
+//	404: NotFound
+//	409: Conflict
+//	410: Gone
+//	500: InternalServerError
+
+// JoinChatByInviteHandler adds current User to group chat by invite link.
+func JoinChatByInviteHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chat, err := u.JoinChatByInvite(r.Context(), accessTokenCookie.Value, mux.Vars(r)[InviteTokenRouteKey])
+		if err != nil {
+			handleChatInvitesError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapChat(*chat)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleChatInvitesError writes response with status code, which corresponds to error of chat invites
+// management.
+func handleChatInvitesError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrValidationFailed),
+		errors.Is(err, customerrors.ErrNotGroupChat):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, customerrors.ErrChatNotFound),
+		errors.Is(err, customerrors.ErrChatInviteNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, customerrors.ErrNotChatMember),
+		errors.Is(err, customerrors.ErrChatPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, customerrors.ErrChatMembersLimitExceeded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, customerrors.ErrChatInviteExpired):
+		http.Error(w, err.Error(), http.StatusGone)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,55 @@
+package chats
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route POST /chats/{id}/leave chats LeaveChat
+//
+// LeaveChat
+//
+// Removes current User from group chat. If owner leaves, ownership is passed to the oldest admin or,
+// if there are no admins, to the oldest member.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// LeaveChatHandler removes current User from group chat.
+func LeaveChatHandler(u interfaces.ChatsUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[IDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err = u.LeaveChat(r.Context(), accessTokenCookie.Value, chatID); err != nil {
+			handleChatMembersError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
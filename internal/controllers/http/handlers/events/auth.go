@@ -0,0 +1,45 @@
+package events
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+// authenticate returns current User by access token cookie. If User can not be authenticated,
+// error response is written and false is returned.
+func authenticate(
+	w http.ResponseWriter,
+	r *http.Request,
+	u interfaces.UsersUseCases,
+) (*domains.User, bool) {
+	accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+
+		return nil, false
+	}
+
+	user, err := u.GetMe(r.Context(), accessTokenCookie.Value)
+
+	switch {
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+
+		return nil, false
+	case errors.Is(err, customerrors.ErrUserNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return nil, false
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	return &user.User, true
+}
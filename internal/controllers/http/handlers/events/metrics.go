@@ -0,0 +1,26 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	transportLabel     = "transport"
+	websocketTransport = "websocket"
+	sseTransport       = "sse"
+)
+
+// openConnections PROMQL => sum(realtime_open_connections) by (transport).
+var openConnections = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "realtime_open_connections",
+		Help: "Number of currently open real-time connections.",
+	},
+	[]string{
+		transportLabel,
+	},
+)
+
+func init() {
+	prometheus.MustRegister(openConnections)
+}
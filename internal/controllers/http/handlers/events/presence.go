@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/logging"
+)
+
+const (
+	// Number of presence prolongations during its TTL. Several prolongations tolerate delays of single ones:
+	presenceProlongationsPerTTL = 3
+)
+
+// trackPresence keeps User online, while his real-time connection is alive. Returned function stops
+// tracking and should be called, when connection is closed.
+func trackPresence(
+	ctx context.Context,
+	u interfaces.PresenceUseCases,
+	userID uint64,
+	presenceTTL time.Duration,
+	logger logging.Logger,
+) (stop func()) {
+	// Presence should be released even if request is already canceled:
+	ctx = context.WithoutCancel(ctx)
+
+	if err := u.ConnectUser(ctx, userID); err != nil {
+		logging.LogErrorContext(ctx, logger, "Failed to mark User online", err)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(presenceTTL / presenceProlongationsPerTTL)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := u.KeepUserOnline(ctx, userID); err != nil {
+					logging.LogErrorContext(ctx, logger, "Failed to prolong User presence", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+
+		if err := u.DisconnectUser(ctx, userID); err != nil {
+			logging.LogErrorContext(ctx, logger, "Failed to mark User offline", err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/realtime"
+	"github.com/DKhorkov/libs/logging"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+
+	// Time in milliseconds, after which client should reconnect to stream:
+	reconnectionDelay = 3000
+)
+
+// swagger:route GET /events events ServerSentEvents
+//
+// Server-Sent Events
+//
+// Streams real-time events of current User. Events, which were published after event from
+// Last-Event-ID header, are replayed first. User is online, while stream is open.
+//
+// Security:
+// - cookieAuth: []
+//
+// Produces:
+// - text/event-stream
+//
+// Responses:
+//	200: OK
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// ServerSentEventsHandler streams real-time events to current User as fallback for WebSocket.
+func ServerSentEventsHandler(
+	u interfaces.UsersUseCases,
+	presenceUseCases interfaces.PresenceUseCases,
+	hub *realtime.Hub,
+	heartbeatInterval time.Duration,
+	writeTimeout time.Duration,
+	presenceTTL time.Duration,
+	logger logging.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var lastEventID uint64
+		if rawLastEventID := r.Header.Get(lastEventIDHeader); rawLastEventID != "" {
+			var err error
+			if lastEventID, err = strconv.ParseUint(rawLastEventID, 10, 64); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		user, ok := authenticate(w, r, u)
+		if !ok {
+			return
+		}
+
+		// Server timeouts are designed for short requests, so deadlines are managed by handler itself:
+		responseController := http.NewResponseController(w)
+		if err := responseController.SetReadDeadline(time.Time{}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		subscription, err := hub.Subscribe(user.ID, lastEventID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		defer hub.Unsubscribe(subscription)
+
+		openConnections.WithLabelValues(sseTransport).Inc()
+		defer openConnections.WithLabelValues(sseTransport).Dec()
+
+		defer trackPresence(r.Context(), presenceUseCases, user.ID, presenceTTL, logger)()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no") // Disables buffering of proxies like nginx
+		w.WriteHeader(http.StatusOK)
+
+		write := func(data string) error {
+			if err = responseController.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+				return err
+			}
+
+			if _, err = fmt.Fprint(w, data); err != nil {
+				return err
+			}
+
+			return responseController.Flush()
+		}
+
+		if err = write(fmt.Sprintf("retry: %d\n\n", reconnectionDelay)); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event := <-subscription.Events():
+				data, err := formatServerSentEvent(event)
+				if err != nil {
+					logging.LogErrorContext(r.Context(), logger, "Failed to format Server-Sent Event", err)
+
+					continue
+				}
+
+				if err = write(data); err != nil {
+					return
+				}
+			case <-ticker.C:
+				// Comment line keeps connection alive for proxies and lets to detect disconnected clients:
+				if err = write(": heartbeat\n\n"); err != nil {
+					return
+				}
+			case <-subscription.Done():
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+// formatServerSentEvent formats event according to text/event-stream format. Ephemeral events are sent
+// without ID to keep Last-Event-ID of client pointing to last replayable event.
+func formatServerSentEvent(event domains.Event) (string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	if event.Ephemeral {
+		return fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data), nil
+	}
+
+	return fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data), nil
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/realtime"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Time allowed to read the next pong message from the client:
+	pongWait = 60 * time.Second
+
+	// Send pings to client with this period. Must be less than pongWait:
+	pingPeriod = pongWait * 9 / 10
+
+	// Maximum message size allowed from the client:
+	maxMessageSize = 4096
+)
+
+// swagger:route GET /ws events WebSocket
+//
+// WebSocket
+//
+// Upgrades connection to WebSocket and pushes real-time events of current User. User is online,
+// while connection is alive.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	101: SwitchingProtocols
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// WebSocketHandler upgrades connection to WebSocket and pushes real-time events to current User.
+func WebSocketHandler(
+	u interfaces.UsersUseCases,
+	presenceUseCases interfaces.PresenceUseCases,
+	hub *realtime.Hub,
+	upgrader *websocket.Upgrader,
+	writeTimeout time.Duration,
+	presenceTTL time.Duration,
+	logger logging.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticate(w, r, u)
+		if !ok {
+			return
+		}
+
+		subscription, err := hub.Subscribe(user.ID, 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		defer hub.Unsubscribe(subscription)
+
+		// Upgrader writes error response to client by itself:
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		openConnections.WithLabelValues(websocketTransport).Inc()
+		defer openConnections.WithLabelValues(websocketTransport).Dec()
+
+		defer func() {
+			if err = conn.Close(); err != nil {
+				logging.LogErrorContext(r.Context(), logger, "Failed to close WebSocket connection", err)
+			}
+		}()
+
+		defer trackPresence(r.Context(), presenceUseCases, user.ID, presenceTTL, logger)()
+
+		readerDone := make(chan struct{})
+		go readMessages(conn, readerDone)
+
+		writeEvents(conn, subscription, readerDone, writeTimeout)
+	}
+}
+
+// readMessages reads messages from client to process control frames and detect closed connection.
+func readMessages(conn *websocket.Conn, done chan<- struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvents pushes events to client until connection is closed by one of the sides.
+func writeEvents(
+	conn *websocket.Conn,
+	subscription *realtime.Subscription,
+	readerDone <-chan struct{},
+	writeTimeout time.Duration,
+) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-subscription.Events():
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-subscription.Done():
+			_ = conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(writeTimeout),
+			)
+
+			return
+		case <-readerDone:
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	ChatIDRouteKey    = "id"
+	MessageIDRouteKey = "messageID"
+)
+
+// swagger:route POST /chats/{id}/messages messages CreateMessage
+//
+// CreateMessage
+//
+// Sends new Message to Chat with provided ID.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: Message
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	500: InternalServerError
+
+// CreateMessageHandler sends new Message to Chat.
+func CreateMessageHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreateMessageInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawCreateMessageDTO{
+			AccessToken:      accessTokenCookie.Value,
+			ChatID:           chatID,
+			Text:             input.Body.Text,
+			ReplyToMessageID: input.Body.ReplyToMessageID,
+			AttachmentIDs:    input.Body.AttachmentIDs,
+		}
+
+		message, err := u.CreateMessage(r.Context(), dto)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed),
+			errors.Is(err, customerrors.ErrInvalidReply),
+			errors.Is(err, customerrors.ErrInvalidAttachment):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMessage(*message)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
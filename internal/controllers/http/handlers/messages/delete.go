@@ -0,0 +1,101 @@
+package messages
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	forEveryoneQueryKey = "forEveryone"
+)
+
+// swagger:route DELETE /chats/{id}/messages/{messageID} messages DeleteMessage
+//
+// DeleteMessage
+//
+// Deletes Message for current User or, if "forEveryone" is set, for all members of the Chat.
+// Only author or Chat admins can delete Message for everyone.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// DeleteMessageHandler deletes Message.
+func DeleteMessageHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		messageID, err := strconv.ParseUint(mux.Vars(r)[MessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var forEveryone bool
+		if value := r.URL.Query().Get(forEveryoneQueryKey); value != "" {
+			if forEveryone, err = strconv.ParseBool(value); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		dto := domains.RawDeleteMessageDTO{
+			AccessToken: accessTokenCookie.Value,
+			ChatID:      chatID,
+			MessageID:   messageID,
+			ForEveryone: forEveryone,
+		}
+
+		err = u.DeleteMessage(r.Context(), dto)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember),
+			errors.Is(err, customerrors.ErrMessagePermissionDenied):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrMessageNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
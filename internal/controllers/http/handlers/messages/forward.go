@@ -0,0 +1,109 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route POST /chats/{id}/messages/forward messages ForwardMessages
+//
+// ForwardMessages
+//
+// Forwards Messages of another Chat to Chat with provided ID. Copies keep attribution to original Messages.
+// Either all Messages are forwarded or none of them.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: []Message
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// ForwardMessagesHandler forwards Messages of another Chat to Chat.
+func ForwardMessagesHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.ForwardMessagesInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawForwardMessagesDTO{
+			AccessToken: accessTokenCookie.Value,
+			ChatID:      chatID,
+			FromChatID:  input.Body.FromChatID,
+			MessageIDs:  input.Body.MessageIDs,
+		}
+
+		messages, err := u.ForwardMessages(r.Context(), dto)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed),
+			errors.Is(err, customerrors.ErrInvalidForward):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrMessageNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMessages(messages)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
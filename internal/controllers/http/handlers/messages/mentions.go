@@ -0,0 +1,96 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/pointers"
+)
+
+// swagger:route GET /mentions messages GetMentions
+//
+// GetMentions
+//
+// Provides Messages, in which current User was mentioned, using keyset pagination by Mention ID.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: MentionsPage
+//	400: BadRequest
+//	401: Unauthorized
+//	500: InternalServerError
+
+// GetMentionsHandler provides mentions feed of current User with keyset pagination.
+func GetMentionsHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		cursor, err := parseMentionsCursor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		page, err := u.GetMentions(r.Context(), accessTokenCookie.Value, cursor)
+
+		switch {
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMentionsPage(*page)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func parseMentionsCursor(r *http.Request) (domains.MentionsCursor, error) {
+	var cursor domains.MentionsCursor
+
+	query := r.URL.Query()
+
+	if beforeStr := query.Get(beforeQueryKey); beforeStr != "" {
+		before, err := strconv.ParseUint(beforeStr, 10, 64)
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor.Before = pointers.New(before)
+	}
+
+	if limitStr := query.Get(limitQueryKey); limitStr != "" {
+		limit, err := strconv.ParseUint(limitStr, 10, 64)
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor.Limit = limit
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,128 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/gorilla/mux"
+)
+
+const (
+	beforeQueryKey = "before"
+	afterQueryKey  = "after"
+	limitQueryKey  = "limit"
+)
+
+// swagger:route GET /chats/{id}/messages messages GetChatMessages
+//
+// GetChatMessages
+//
+// Provides history of Chat with provided ID using keyset pagination by Message ID.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: MessagesPage
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	500: InternalServerError
+
+// GetChatMessagesHandler provides Messages of Chat with keyset pagination.
+func GetChatMessagesHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		cursor, err := parseMessagesCursor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		page, err := u.GetChatMessages(r.Context(), accessTokenCookie.Value, chatID, cursor)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMessagesPage(*page)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func parseMessagesCursor(r *http.Request) (domains.MessagesCursor, error) {
+	var cursor domains.MessagesCursor
+
+	query := r.URL.Query()
+
+	if beforeStr := query.Get(beforeQueryKey); beforeStr != "" {
+		before, err := strconv.ParseUint(beforeStr, 10, 64)
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor.Before = pointers.New(before)
+	}
+
+	if afterStr := query.Get(afterQueryKey); afterStr != "" {
+		after, err := strconv.ParseUint(afterStr, 10, 64)
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor.After = pointers.New(after)
+	}
+
+	if limitStr := query.Get(limitQueryKey); limitStr != "" {
+		limit, err := strconv.ParseUint(limitStr, 10, 64)
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor.Limit = limit
+	}
+
+	return cursor, nil
+}
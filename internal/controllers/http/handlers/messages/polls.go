@@ -0,0 +1,255 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+// swagger:route POST /chats/{id}/polls messages CreatePoll
+//
+// CreatePoll
+//
+// Sends Message with new Poll to group Chat with provided ID. Question of Poll is used as text of Message.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: Message
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// CreatePollHandler sends new Poll to group Chat.
+func CreatePollHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.CreatePollInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawCreatePollDTO{
+			AccessToken:    accessTokenCookie.Value,
+			ChatID:         chatID,
+			Question:       input.Body.Question,
+			Options:        input.Body.Options,
+			MultipleChoice: input.Body.MultipleChoice,
+			Anonymous:      input.Body.Anonymous,
+			ClosesAt:       input.Body.ClosesAt,
+		}
+
+		message, err := u.CreatePoll(r.Context(), dto)
+		if err != nil {
+			handlePollsError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMessage(*message)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// swagger:route POST /chats/{id}/messages/{messageID}/poll/votes messages VotePoll
+//
+// VotePoll
+//
+// Votes for options of Poll on behalf of current User. User can vote only once, so vote has to be
+// retracted to change it.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Poll
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	409: Conflict
+//	500: InternalServerError
+
+// VotePollHandler votes for options of Poll on behalf of current User.
+func VotePollHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		messageID, err := strconv.ParseUint(mux.Vars(r)[MessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.VotePollInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawPollVoteDTO{
+			AccessToken: accessTokenCookie.Value,
+			ChatID:      chatID,
+			MessageID:   messageID,
+			OptionIDs:   input.Body.OptionIDs,
+		}
+
+		poll, err := u.VotePoll(r.Context(), dto)
+		if err != nil {
+			handlePollsError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapPoll(poll)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route DELETE /chats/{id}/messages/{messageID}/poll/votes messages RetractPollVote
+//
+// RetractPollVote
+//
+// Retracts all votes of current User in Poll. Retracting of absent vote has no effect.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: Poll
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	409: Conflict
+//	500: InternalServerError
+
+// RetractPollVoteHandler retracts votes of current User in Poll.
+func RetractPollVoteHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		messageID, err := strconv.ParseUint(mux.Vars(r)[MessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		poll, err := u.RetractPollVote(r.Context(), accessTokenCookie.Value, chatID, messageID)
+		if err != nil {
+			handlePollsError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapPoll(poll)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handlePollsError writes response with status code, which corresponds to error of polls management.
+func handlePollsError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrValidationFailed),
+		errors.Is(err, customerrors.ErrNotGroupChat):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, customerrors.ErrNotChatMember),
+		errors.Is(err, customerrors.ErrChatPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, customerrors.ErrChatNotFound),
+		errors.Is(err, customerrors.ErrMessageNotFound),
+		errors.Is(err, customerrors.ErrPollNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, customerrors.ErrPollClosed),
+		errors.Is(err, customerrors.ErrPollAlreadyVoted):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
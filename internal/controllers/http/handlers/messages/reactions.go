@@ -0,0 +1,124 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const (
+	EmojiRouteKey = "emoji"
+)
+
+// swagger:route PUT /chats/{id}/messages/{messageID}/reactions/{emoji} messages AddMessageReaction
+//
+// AddMessageReaction
+//
+// Adds reaction of current User on Message. Adding of already left reaction has no effect.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// AddMessageReactionHandler adds reaction of current User on Message.
+func AddMessageReactionHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return messageReactionHandler(u.AddMessageReaction)
+}
+
+// swagger:route DELETE /chats/{id}/messages/{messageID}/reactions/{emoji} messages RemoveMessageReaction
+//
+// RemoveMessageReaction
+//
+// Removes reaction of current User from Message. Removing of absent reaction has no effect.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	500: InternalServerError
+
+// RemoveMessageReactionHandler removes reaction of current User from Message.
+func RemoveMessageReactionHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return messageReactionHandler(u.RemoveMessageReaction)
+}
+
+// messageReactionHandler parses reaction from request and changes it via provided use case.
+func messageReactionHandler(
+	changeReaction func(ctx context.Context, reactionData domains.RawMessageReactionDTO) error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		messageID, err := strconv.ParseUint(mux.Vars(r)[MessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawMessageReactionDTO{
+			AccessToken: accessTokenCookie.Value,
+			ChatID:      chatID,
+			MessageID:   messageID,
+			Emoji:       mux.Vars(r)[EmojiRouteKey],
+		}
+
+		err = changeReaction(r.Context(), dto)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case errors.Is(err, customerrors.ErrMessageNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
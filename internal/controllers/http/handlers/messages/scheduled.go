@@ -0,0 +1,295 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/gorilla/mux"
+)
+
+const ScheduledMessageIDRouteKey = "scheduledMessageID"
+
+// swagger:route POST /chats/{id}/scheduled-messages messages ScheduleMessage
+//
+// ScheduleMessage
+//
+// Schedules Message to be sent to Chat with provided ID at provided time. Permissions of author are checked
+// again, when Message is being sent.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	201: ScheduledMessage
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	404: NotFound
+//	409: Conflict
+//	500: InternalServerError
+
+// ScheduleMessageHandler schedules Message to be sent to Chat later.
+func ScheduleMessageHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.ScheduleMessageInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawScheduleMessageDTO{
+			AccessToken:      accessTokenCookie.Value,
+			ChatID:           chatID,
+			Text:             input.Body.Text,
+			ReplyToMessageID: input.Body.ReplyToMessageID,
+			SendAt:           input.Body.SendAt,
+		}
+
+		message, err := u.ScheduleMessage(r.Context(), dto)
+		if err != nil {
+			handleScheduledMessagesError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err = json.NewEncoder(w).Encode(mappers.MapScheduledMessage(*message)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// swagger:route GET /chats/{id}/scheduled-messages messages GetScheduledMessages
+//
+// GetScheduledMessages
+//
+// Provides Messages, which current User scheduled in Chat, in order of sending.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: []ScheduledMessage
+//	400: BadRequest
+//	401: Unauthorized
+//	500: InternalServerError
+
+// GetScheduledMessagesHandler provides scheduled Messages of current User in Chat.
+func GetScheduledMessagesHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		messages, err := u.GetScheduledMessages(r.Context(), accessTokenCookie.Value, chatID)
+		if err != nil {
+			handleScheduledMessagesError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapScheduledMessages(messages)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route PUT /chats/{id}/scheduled-messages/{scheduledMessageID} messages UpdateScheduledMessage
+//
+// UpdateScheduledMessage
+//
+// Edits text and sending time of scheduled Message. Message, which could not be sent, is scheduled again.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: ScheduledMessage
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// UpdateScheduledMessageHandler edits scheduled Message.
+func UpdateScheduledMessageHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		scheduledMessageID, err := strconv.ParseUint(mux.Vars(r)[ScheduledMessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		var input schemas.UpdateScheduledMessageInput
+		if err = json.Unmarshal(data, &input.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		dto := domains.RawUpdateScheduledMessageDTO{
+			AccessToken:        accessTokenCookie.Value,
+			ChatID:             chatID,
+			ScheduledMessageID: scheduledMessageID,
+			Text:               input.Body.Text,
+			SendAt:             input.Body.SendAt,
+		}
+
+		message, err := u.UpdateScheduledMessage(r.Context(), dto)
+		if err != nil {
+			handleScheduledMessagesError(w, err)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapScheduledMessage(*message)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// swagger:route DELETE /chats/{id}/scheduled-messages/{scheduledMessageID} messages CancelScheduledMessage
+//
+// CancelScheduledMessage
+//
+// Cancels sending of scheduled Message.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	204: NoContent
+//	400: BadRequest
+//	401: Unauthorized
+//	404: NotFound
+//	500: InternalServerError
+
+// CancelScheduledMessageHandler cancels sending of scheduled Message.
+func CancelScheduledMessageHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		chatID, err := strconv.ParseUint(mux.Vars(r)[ChatIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		scheduledMessageID, err := strconv.ParseUint(mux.Vars(r)[ScheduledMessageIDRouteKey], 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		err = u.CancelScheduledMessage(r.Context(), accessTokenCookie.Value, chatID, scheduledMessageID)
+		if err != nil {
+			handleScheduledMessagesError(w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleScheduledMessagesError writes response with status code, which corresponds to error of scheduled
+// messages management.
+func handleScheduledMessagesError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customerrors.ErrValidationFailed),
+		errors.Is(err, customerrors.ErrInvalidReply):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, customerrors.ErrInvalidJWT):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, customerrors.ErrNotChatMember),
+		errors.Is(err, customerrors.ErrChatPermissionDenied):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, customerrors.ErrChatNotFound),
+		errors.Is(err, customerrors.ErrScheduledMessageNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, customerrors.ErrScheduledMessagesLimitExceeded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
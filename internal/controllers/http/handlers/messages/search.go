@@ -0,0 +1,156 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/mappers"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/pointers"
+)
+
+const (
+	searchQueryKey   = "q"
+	chatIDQueryKey   = "chatId"
+	authorIDQueryKey = "authorId"
+	fromQueryKey     = "from"
+	toQueryKey       = "to"
+	offsetQueryKey   = "offset"
+)
+
+// swagger:route GET /search/messages messages SearchMessages
+//
+// SearchMessages
+//
+// Provides Messages from Chats of current User, which match full-text search query. Both russian and english
+// word forms are matched.
+//
+// Security:
+// - cookieAuth: []
+//
+// Responses:
+//	200: MessagesSearchPage
+//	400: BadRequest
+//	401: Unauthorized
+//	403: Forbidden
+//	500: InternalServerError
+
+// SearchMessagesHandler provides search results with offset pagination.
+func SearchMessagesHandler(u interfaces.MessagesUseCases) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessTokenCookie, err := r.Cookie(auth.AccessTokenCookieName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		searchData, err := parseSearchMessagesQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		searchData.AccessToken = accessTokenCookie.Value
+
+		page, err := u.SearchMessages(r.Context(), searchData)
+
+		switch {
+		case errors.Is(err, customerrors.ErrValidationFailed):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		case errors.Is(err, customerrors.ErrInvalidJWT):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		case errors.Is(err, customerrors.ErrNotChatMember):
+			http.Error(w, err.Error(), http.StatusForbidden)
+
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		if err = json.NewEncoder(w).Encode(mappers.MapMessagesSearchPage(*page)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func parseSearchMessagesQuery(r *http.Request) (domains.RawSearchMessagesDTO, error) {
+	query := r.URL.Query()
+
+	searchData := domains.RawSearchMessagesDTO{
+		Query: query.Get(searchQueryKey),
+	}
+
+	if chatIDStr := query.Get(chatIDQueryKey); chatIDStr != "" {
+		chatID, err := strconv.ParseUint(chatIDStr, 10, 64)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.ChatID = pointers.New(chatID)
+	}
+
+	if authorIDStr := query.Get(authorIDQueryKey); authorIDStr != "" {
+		authorID, err := strconv.ParseUint(authorIDStr, 10, 64)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.AuthorID = pointers.New(authorID)
+	}
+
+	if fromStr := query.Get(fromQueryKey); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.From = pointers.New(from)
+	}
+
+	if toStr := query.Get(toQueryKey); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.To = pointers.New(to)
+	}
+
+	if limitStr := query.Get(limitQueryKey); limitStr != "" {
+		limit, err := strconv.ParseUint(limitStr, 10, 64)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.Limit = limit
+	}
+
+	if offsetStr := query.Get(offsetQueryKey); offsetStr != "" {
+		offset, err := strconv.ParseUint(offsetStr, 10, 64)
+		if err != nil {
+			return searchData, err
+		}
+
+		searchData.Offset = offset
+	}
+
+	return searchData, nil
+}
@@ -3,14 +3,22 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/attachments"
 	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/auth"
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/chats"
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/events"
+	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/messages"
 	"github.com/DKhorkov/kfc/internal/controllers/http/handlers/users"
 	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/realtime"
+	"github.com/DKhorkov/libs/logging"
 	middlewares "github.com/DKhorkov/libs/middlewares/http"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -28,6 +36,49 @@ const (
 	forgetPasswordURL         = sendForgetPasswordURL + "/{%s}"
 	sendVerifyEmailMessageURL = usersURL + "/email/verify"
 	verifyEmailURL            = sendVerifyEmailMessageURL + "/{%s}"
+
+	chatsURL             = "/chats"
+	directChatsURL       = chatsURL + "/direct"
+	groupChatsURL        = chatsURL + "/groups"
+	createChannelURL     = chatsURL + "/channels"
+	getChatByIDURL       = chatsURL + "/{%s}"
+	chatMembersURL       = getChatByIDURL + "/members"
+	chatMemberURL        = chatMembersURL + "/{%s}"
+	promoteChatMemberURL = chatMemberURL + "/promote"
+	demoteChatMemberURL  = chatMemberURL + "/demote"
+	leaveChatURL         = getChatByIDURL + "/leave"
+	subscribeChannelURL  = getChatByIDURL + "/subscribe"
+	chatInvitesURL       = getChatByIDURL + "/invites"
+	chatInviteURL        = chatInvitesURL + "/{%s}"
+	readChatURL          = getChatByIDURL + "/read"
+	typingURL            = getChatByIDURL + "/typing"
+	attachmentsURL       = getChatByIDURL + "/attachments"
+	attachmentURL        = attachmentsURL + "/{%s}"
+	thumbnailURL         = attachmentURL + "/thumbnails/{%s}"
+	messagesURL          = getChatByIDURL + "/messages"
+	messageURL           = messagesURL + "/{%s}"
+	forwardMessagesURL   = messagesURL + "/forward"
+	messageReactionURL   = messageURL + "/reactions/{%s}"
+	threadURL            = messageURL + "/thread"
+	readThreadURL        = threadURL + "/read"
+	pinMessageURL        = messageURL + "/pin"
+	chatPinsURL          = getChatByIDURL + "/pins"
+	scheduledMessagesURL = getChatByIDURL + "/scheduled-messages"
+	scheduledMessageURL  = scheduledMessagesURL + "/{%s}"
+	chatMessageTTLURL    = getChatByIDURL + "/message-ttl"
+	chatDraftURL         = getChatByIDURL + "/draft"
+	pollsURL             = getChatByIDURL + "/polls"
+	pollVotesURL         = messageURL + "/poll/votes"
+
+	joinChatByInviteURL = "/invites/{%s}"
+
+	channelsURL = "/channels"
+
+	searchMessagesURL = "/search/messages"
+	mentionsURL       = "/mentions"
+
+	webSocketURL        = "/ws"
+	serverSentEventsURL = "/events"
 )
 
 func SetupHandlers(
@@ -36,6 +87,9 @@ func SetupHandlers(
 	cookiesConfig config.CookiesConfig,
 	usersUseCases interfaces.UsersUseCases,
 	authUseCases interfaces.AuthUseCases,
+	chatsUseCases interfaces.ChatsUseCases,
+	messagesUseCases interfaces.MessagesUseCases,
+	attachmentsUseCases interfaces.AttachmentsUseCases,
 ) {
 	rootMux.NotFoundHandler = http.HandlerFunc(DefaultHandler)
 	rootMux.MethodNotAllowedHandler = http.HandlerFunc(NotAllowedHandler)
@@ -48,6 +102,51 @@ func SetupHandlers(
 		fmt.Sprintf(getUserByIDURL, users.IDRouteKey),
 		users.GetUserByIDHandler(usersUseCases),
 	)
+	getMux.Handle(chatsURL, chats.GetChatsHandler(chatsUseCases))
+	getMux.Handle(channelsURL, chats.GetChannelsHandler(chatsUseCases))
+	getMux.Handle(
+		fmt.Sprintf(getChatByIDURL, chats.IDRouteKey),
+		chats.GetChatByIDHandler(chatsUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(chatInvitesURL, chats.IDRouteKey),
+		chats.GetChatInvitesHandler(chatsUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(chatDraftURL, chats.IDRouteKey),
+		chats.GetChatDraftHandler(chatsUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(messagesURL, messages.ChatIDRouteKey),
+		messages.GetChatMessagesHandler(messagesUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(threadURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.GetThreadMessagesHandler(messagesUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(chatPinsURL, messages.ChatIDRouteKey),
+		messages.GetChatPinsHandler(messagesUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(scheduledMessagesURL, messages.ChatIDRouteKey),
+		messages.GetScheduledMessagesHandler(messagesUseCases),
+	)
+	getMux.Handle(searchMessagesURL, messages.SearchMessagesHandler(messagesUseCases))
+	getMux.Handle(mentionsURL, messages.GetMentionsHandler(messagesUseCases))
+	getMux.Handle(
+		fmt.Sprintf(attachmentURL, attachments.ChatIDRouteKey, attachments.AttachmentIDRouteKey),
+		attachments.DownloadAttachmentHandler(attachmentsUseCases),
+	)
+	getMux.Handle(
+		fmt.Sprintf(
+			thumbnailURL,
+			attachments.ChatIDRouteKey,
+			attachments.AttachmentIDRouteKey,
+			attachments.ThumbnailNameRouteKey,
+		),
+		attachments.DownloadAttachmentThumbnailHandler(attachmentsUseCases),
+	)
 
 	swaggerURL := "/" + docsConfig.Filepath
 	opts := middleware.RedocOpts{
@@ -80,11 +179,199 @@ func SetupHandlers(
 		auth.ForgetPasswordHandler(authUseCases),
 	)
 	postMux.Handle(sendForgetPasswordURL, auth.SendForgetPasswordMessageHandler(authUseCases))
+	postMux.Handle(directChatsURL, chats.CreateDirectChatHandler(chatsUseCases))
+	postMux.Handle(groupChatsURL, chats.CreateGroupChatHandler(chatsUseCases))
+	postMux.Handle(createChannelURL, chats.CreateChannelHandler(chatsUseCases))
+	postMux.Handle(
+		fmt.Sprintf(chatMembersURL, chats.IDRouteKey),
+		chats.AddChatMembersHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(promoteChatMemberURL, chats.IDRouteKey, chats.MemberIDRouteKey),
+		chats.PromoteChatMemberHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(demoteChatMemberURL, chats.IDRouteKey, chats.MemberIDRouteKey),
+		chats.DemoteChatMemberHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(leaveChatURL, chats.IDRouteKey),
+		chats.LeaveChatHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(subscribeChannelURL, chats.IDRouteKey),
+		chats.SubscribeToChannelHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(chatInvitesURL, chats.IDRouteKey),
+		chats.CreateChatInviteHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(joinChatByInviteURL, chats.InviteTokenRouteKey),
+		chats.JoinChatByInviteHandler(chatsUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(messagesURL, messages.ChatIDRouteKey),
+		messages.CreateMessageHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(forwardMessagesURL, messages.ChatIDRouteKey),
+		messages.ForwardMessagesHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(pollsURL, messages.ChatIDRouteKey),
+		messages.CreatePollHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(pollVotesURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.VotePollHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(readChatURL, messages.ChatIDRouteKey),
+		messages.ReadChatMessagesHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(typingURL, messages.ChatIDRouteKey),
+		messages.SendTypingIndicatorHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(threadURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.CreateThreadMessageHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(readThreadURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.ReadThreadMessagesHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(pinMessageURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.PinMessageHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(scheduledMessagesURL, messages.ChatIDRouteKey),
+		messages.ScheduleMessageHandler(messagesUseCases),
+	)
+	postMux.Handle(
+		fmt.Sprintf(attachmentsURL, attachments.ChatIDRouteKey),
+		attachments.UploadAttachmentHandler(attachmentsUseCases),
+	)
 
 	putMux := rootMux.Methods(http.MethodPut).Subrouter()
 	putMux.Handle(meURL, users.UpdateCurrentUserHandler(usersUseCases))
 	putMux.Handle(sessionsURL, auth.RefreshTokensHandler(authUseCases, cookiesConfig))
+	putMux.Handle(
+		fmt.Sprintf(chatDraftURL, chats.IDRouteKey),
+		chats.SaveChatDraftHandler(chatsUseCases),
+	)
+	putMux.Handle(
+		fmt.Sprintf(messageURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.UpdateMessageHandler(messagesUseCases),
+	)
+	putMux.Handle(
+		fmt.Sprintf(scheduledMessageURL, messages.ChatIDRouteKey, messages.ScheduledMessageIDRouteKey),
+		messages.UpdateScheduledMessageHandler(messagesUseCases),
+	)
+	putMux.Handle(
+		fmt.Sprintf(chatMessageTTLURL, messages.ChatIDRouteKey),
+		messages.SetChatMessageTTLHandler(messagesUseCases),
+	)
+	putMux.Handle(
+		fmt.Sprintf(
+			messageReactionURL,
+			messages.ChatIDRouteKey,
+			messages.MessageIDRouteKey,
+			messages.EmojiRouteKey,
+		),
+		messages.AddMessageReactionHandler(messagesUseCases),
+	)
 
 	deleteMux := rootMux.Methods(http.MethodDelete).Subrouter()
 	deleteMux.Handle(sessionsURL, auth.LogoutHandler(authUseCases))
+	deleteMux.Handle(
+		fmt.Sprintf(chatMemberURL, chats.IDRouteKey, chats.MemberIDRouteKey),
+		chats.RemoveChatMemberHandler(chatsUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(chatInviteURL, chats.IDRouteKey, chats.InviteIDRouteKey),
+		chats.RevokeChatInviteHandler(chatsUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(chatDraftURL, chats.IDRouteKey),
+		chats.DeleteChatDraftHandler(chatsUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(messageURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.DeleteMessageHandler(messagesUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(
+			messageReactionURL,
+			messages.ChatIDRouteKey,
+			messages.MessageIDRouteKey,
+			messages.EmojiRouteKey,
+		),
+		messages.RemoveMessageReactionHandler(messagesUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(pinMessageURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.UnpinMessageHandler(messagesUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(scheduledMessageURL, messages.ChatIDRouteKey, messages.ScheduledMessageIDRouteKey),
+		messages.CancelScheduledMessageHandler(messagesUseCases),
+	)
+	deleteMux.Handle(
+		fmt.Sprintf(pollVotesURL, messages.ChatIDRouteKey, messages.MessageIDRouteKey),
+		messages.RetractPollVoteHandler(messagesUseCases),
+	)
+}
+
+// SetupRealtimeHandlers registers handlers of long-lived real-time connections. Such handlers must be
+// registered on router without middlewares, which wrap http.ResponseWriter, because wrapped writers
+// can not be hijacked or flushed.
+func SetupRealtimeHandlers(
+	router *mux.Router,
+	corsConfig config.CORSConfig,
+	realtimeConfig config.RealtimeConfig,
+	usersUseCases interfaces.UsersUseCases,
+	presenceUseCases interfaces.PresenceUseCases,
+	hub *realtime.Hub,
+	logger logging.Logger,
+) {
+	upgrader := &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+
+			return slices.Contains(corsConfig.AllowedOrigins, "*") ||
+				slices.Contains(corsConfig.AllowedOrigins, origin)
+		},
+	}
+
+	getMux := router.Methods(http.MethodGet).Subrouter()
+	getMux.Handle(
+		webSocketURL,
+		events.WebSocketHandler(
+			usersUseCases,
+			presenceUseCases,
+			hub,
+			upgrader,
+			realtimeConfig.WriteTimeout,
+			realtimeConfig.PresenceTTL,
+			logger,
+		),
+	)
+	getMux.Handle(
+		serverSentEventsURL,
+		events.ServerSentEventsHandler(
+			usersUseCases,
+			presenceUseCases,
+			hub,
+			realtimeConfig.HeartbeatInterval,
+			realtimeConfig.WriteTimeout,
+			realtimeConfig.PresenceTTL,
+			logger,
+		),
+	)
 }
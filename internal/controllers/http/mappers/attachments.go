@@ -0,0 +1,53 @@
+package mappers
+
+import (
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+func MapAttachment(attachment domains.Attachment) schemas.Attachment {
+	return schemas.Attachment{
+		ID:            attachment.ID,
+		ChatID:        attachment.ChatID,
+		UploaderID:    attachment.UploaderID,
+		MessageID:     attachment.MessageID,
+		Filename:      attachment.Filename,
+		MIMEType:      attachment.MIMEType,
+		Size:          attachment.Size,
+		Checksum:      attachment.Checksum,
+		PreviewStatus: string(attachment.PreviewStatus),
+		Width:         attachment.Width,
+		Height:        attachment.Height,
+		Blurhash:      attachment.Blurhash,
+		Thumbnails:    mapAttachmentThumbnails(attachment.Thumbnails),
+		CreatedAt:     attachment.CreatedAt,
+	}
+}
+
+func mapAttachmentThumbnails(thumbnails []domains.AttachmentThumbnail) []schemas.AttachmentThumbnail {
+	if len(thumbnails) == 0 {
+		return nil
+	}
+
+	result := make([]schemas.AttachmentThumbnail, len(thumbnails))
+	for i, thumbnail := range thumbnails {
+		result[i] = schemas.AttachmentThumbnail{
+			Name:     string(thumbnail.Name),
+			MIMEType: thumbnail.MIMEType,
+			Size:     thumbnail.Size,
+			Width:    thumbnail.Width,
+			Height:   thumbnail.Height,
+		}
+	}
+
+	return result
+}
+
+func MapAttachments(attachments []domains.Attachment) []schemas.Attachment {
+	result := make([]schemas.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		result[i] = MapAttachment(attachment)
+	}
+
+	return result
+}
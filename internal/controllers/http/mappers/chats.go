@@ -0,0 +1,129 @@
+package mappers
+
+import (
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+func MapChat(chat domains.Chat) schemas.Chat {
+	var members []schemas.ChatMember
+	if chat.Type != domains.ChatTypeChannel {
+		members = make([]schemas.ChatMember, len(chat.Members))
+		for i, member := range chat.Members {
+			members[i] = MapChatMember(member)
+		}
+	}
+
+	result := schemas.Chat{
+		ID:                 chat.ID,
+		Type:               string(chat.Type),
+		Title:              chat.Title,
+		Handle:             chat.Handle,
+		MessageTTL:         uint64(chat.MessageTTL),
+		Members:            members,
+		UnreadCount:        chat.UnreadCount,
+		UnreadThreadsCount: chat.UnreadThreadsCount,
+		CreatedAt:          chat.CreatedAt,
+		UpdatedAt:          chat.UpdatedAt,
+	}
+
+	if chat.Type == domains.ChatTypeChannel {
+		result.SubscribersCount = &chat.SubscribersCount
+	}
+
+	if chat.Draft != nil {
+		draft := MapChatDraft(*chat.Draft)
+		result.Draft = &draft
+	}
+
+	return result
+}
+
+func MapChats(chats []domains.Chat) []schemas.Chat {
+	result := make([]schemas.Chat, len(chats))
+	for i, chat := range chats {
+		result[i] = MapChat(chat)
+	}
+
+	return result
+}
+
+func MapChatMember(member domains.ChatMember) schemas.ChatMember {
+	return schemas.ChatMember{
+		UserID:            member.UserID,
+		Role:              string(member.Role),
+		LastReadMessageID: member.LastReadMessageID,
+		JoinedAt:          member.CreatedAt,
+	}
+}
+
+func MapChatReadState(member domains.ChatMember) schemas.ChatReadState {
+	state := schemas.ChatReadState{
+		ChatID:      member.ChatID,
+		UnreadCount: member.UnreadCount,
+	}
+
+	if member.LastReadMessageID != nil {
+		state.LastReadMessageID = *member.LastReadMessageID
+	}
+
+	return state
+}
+
+func MapChatInvite(invite domains.ChatInvite) schemas.ChatInvite {
+	return schemas.ChatInvite{
+		ID:        invite.ID,
+		ChatID:    invite.ChatID,
+		Token:     invite.Token,
+		CreatedBy: invite.CreatedBy,
+		ExpiresAt: invite.ExpiresAt,
+		MaxUses:   invite.MaxUses,
+		UsesCount: invite.UsesCount,
+		RevokedAt: invite.RevokedAt,
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+func MapChatInvites(invites []domains.ChatInvite) []schemas.ChatInvite {
+	result := make([]schemas.ChatInvite, len(invites))
+	for i, invite := range invites {
+		result[i] = MapChatInvite(invite)
+	}
+
+	return result
+}
+
+func MapChatDraft(draft domains.ChatDraft) schemas.ChatDraft {
+	return schemas.ChatDraft{
+		ChatID:    draft.ChatID,
+		Text:      draft.Text,
+		UpdatedAt: draft.UpdatedAt,
+	}
+}
+
+// MapChannel maps public channel, which always has title and handle.
+func MapChannel(channel domains.Chat) schemas.Channel {
+	result := schemas.Channel{
+		ID:               channel.ID,
+		SubscribersCount: channel.SubscribersCount,
+	}
+
+	if channel.Title != nil {
+		result.Title = *channel.Title
+	}
+
+	if channel.Handle != nil {
+		result.Handle = *channel.Handle
+	}
+
+	return result
+}
+
+func MapChannels(channels []domains.Chat) []schemas.Channel {
+	result := make([]schemas.Channel, len(channels))
+	for i, channel := range channels {
+		result[i] = MapChannel(channel)
+	}
+
+	return result
+}
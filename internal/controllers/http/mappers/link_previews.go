@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+func MapLinkPreview(preview *domains.LinkPreview) *schemas.LinkPreview {
+	if preview == nil {
+		return nil
+	}
+
+	mapped := &schemas.LinkPreview{
+		URL:         preview.URL,
+		Description: preview.Description,
+		SiteName:    preview.SiteName,
+		ImageURL:    preview.ImageURL,
+	}
+
+	// Title is always present in ready preview:
+	if preview.Title != nil {
+		mapped.Title = *preview.Title
+	}
+
+	return mapped
+}
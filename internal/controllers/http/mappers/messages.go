@@ -0,0 +1,203 @@
+package mappers
+
+import (
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+func MapMessage(message domains.Message) schemas.Message {
+	return schemas.Message{
+		ID:                message.ID,
+		ChatID:            message.ChatID,
+		AuthorID:          message.AuthorID,
+		Type:              string(message.Type),
+		SystemEvent:       MapSystemEvent(message.SystemEvent),
+		Text:              message.Text,
+		CreatedAt:         message.CreatedAt,
+		UpdatedAt:         message.UpdatedAt,
+		EditedAt:          message.EditedAt,
+		DeletedAt:         message.DeletedAt,
+		ReplyToMessageID:  message.ReplyToMessageID,
+		ThreadRootID:      message.ThreadRootID,
+		ThreadReplyCount:  message.ThreadReplyCount,
+		ThreadLastReplyAt: message.ThreadLastReplyAt,
+		ThreadUnreadCount: message.ThreadUnreadCount,
+		ExpiresAt:         message.ExpiresAt,
+		Reactions:         MapMessageReactions(message.Reactions),
+		Attachments:       MapAttachments(message.Attachments),
+		Poll:              MapPoll(message.Poll),
+		LinkPreview:       MapLinkPreview(message.LinkPreview),
+		ForwardedFrom:     MapMessageForward(message),
+	}
+}
+
+func MapMessageForward(message domains.Message) *schemas.MessageForward {
+	if !message.IsForwarded() {
+		return nil
+	}
+
+	return &schemas.MessageForward{
+		AuthorID:  message.ForwardedFromAuthorID,
+		ChatID:    message.ForwardedFromChatID,
+		MessageID: message.ForwardedFromMessageID,
+	}
+}
+
+func MapSystemEvent(event *domains.SystemEvent) *schemas.SystemEvent {
+	if event == nil {
+		return nil
+	}
+
+	result := &schemas.SystemEvent{
+		Type:      string(event.Type),
+		MessageID: event.MessageID,
+	}
+
+	if event.MessageTTL != nil {
+		messageTTL := uint64(*event.MessageTTL)
+		result.MessageTTL = &messageTTL
+	}
+
+	return result
+}
+
+func MapMessageReactions(reactions []domains.MessageReaction) []schemas.MessageReaction {
+	result := make([]schemas.MessageReaction, len(reactions))
+	for i, reaction := range reactions {
+		result[i] = schemas.MessageReaction{
+			Emoji:       reaction.Emoji,
+			Count:       reaction.Count,
+			ReactedByMe: reaction.ReactedByMe,
+		}
+	}
+
+	return result
+}
+
+func MapMessages(messages []domains.Message) []schemas.Message {
+	result := make([]schemas.Message, len(messages))
+	for i, message := range messages {
+		result[i] = MapMessage(message)
+	}
+
+	return result
+}
+
+func MapMessagesPage(page domains.MessagesPage) schemas.MessagesPage {
+	return schemas.MessagesPage{
+		Messages: MapMessages(page.Messages),
+		HasMore:  page.HasMore,
+	}
+}
+
+func MapMessagesSearchPage(page domains.MessagesSearchPage) schemas.MessagesSearchPage {
+	results := make([]schemas.MessageSearchResult, len(page.Results))
+	for i, result := range page.Results {
+		results[i] = schemas.MessageSearchResult{
+			Message: MapMessage(result.Message),
+			Snippet: result.Snippet,
+			Rank:    result.Rank,
+		}
+	}
+
+	return schemas.MessagesSearchPage{
+		Results: results,
+		HasMore: page.HasMore,
+	}
+}
+
+func MapMentionsPage(page domains.MentionsPage) schemas.MentionsPage {
+	mentions := make([]schemas.Mention, len(page.Mentions))
+	for i, mention := range page.Mentions {
+		mentions[i] = MapMention(mention)
+	}
+
+	return schemas.MentionsPage{
+		Mentions: mentions,
+		HasMore:  page.HasMore,
+	}
+}
+
+func MapMention(mention domains.Mention) schemas.Mention {
+	result := schemas.Mention{
+		ID:        mention.ID,
+		ChatID:    mention.ChatID,
+		Type:      string(mention.Type),
+		CreatedAt: mention.CreatedAt,
+	}
+
+	if mention.Message != nil {
+		result.Message = MapMessage(*mention.Message)
+	}
+
+	return result
+}
+
+func MapPin(pin domains.Pin) schemas.Pin {
+	result := schemas.Pin{
+		ID:        pin.ID,
+		ChatID:    pin.ChatID,
+		PinnedBy:  pin.PinnedBy,
+		CreatedAt: pin.CreatedAt,
+	}
+
+	if pin.Message != nil {
+		result.Message = MapMessage(*pin.Message)
+	}
+
+	return result
+}
+
+func MapPins(pins []domains.Pin) []schemas.Pin {
+	result := make([]schemas.Pin, len(pins))
+	for i, pin := range pins {
+		result[i] = MapPin(pin)
+	}
+
+	return result
+}
+
+func MapThreadPage(page domains.ThreadPage) schemas.ThreadPage {
+	return schemas.ThreadPage{
+		Root:     MapMessage(page.Root),
+		Messages: MapMessages(page.Messages),
+		HasMore:  page.HasMore,
+	}
+}
+
+func MapThreadReadState(participant domains.ThreadParticipant) schemas.ThreadReadState {
+	state := schemas.ThreadReadState{
+		ChatID:       participant.ChatID,
+		ThreadRootID: participant.ThreadRootID,
+		UnreadCount:  participant.UnreadCount,
+	}
+
+	if participant.LastReadMessageID != nil {
+		state.LastReadMessageID = *participant.LastReadMessageID
+	}
+
+	return state
+}
+
+func MapScheduledMessage(message domains.ScheduledMessage) schemas.ScheduledMessage {
+	return schemas.ScheduledMessage{
+		ID:               message.ID,
+		ChatID:           message.ChatID,
+		AuthorID:         message.AuthorID,
+		Text:             message.Text,
+		ReplyToMessageID: message.ReplyToMessageID,
+		SendAt:           message.SendAt,
+		FailedAt:         message.FailedAt,
+		CreatedAt:        message.CreatedAt,
+		UpdatedAt:        message.UpdatedAt,
+	}
+}
+
+func MapScheduledMessages(messages []domains.ScheduledMessage) []schemas.ScheduledMessage {
+	result := make([]schemas.ScheduledMessage, len(messages))
+	for i, message := range messages {
+		result[i] = MapScheduledMessage(message)
+	}
+
+	return result
+}
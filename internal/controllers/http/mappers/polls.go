@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"github.com/DKhorkov/kfc/internal/controllers/http/schemas"
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+func MapPoll(poll *domains.Poll) *schemas.Poll {
+	if poll == nil {
+		return nil
+	}
+
+	options := make([]schemas.PollOption, len(poll.Options))
+	for i, option := range poll.Options {
+		options[i] = MapPollOption(option)
+	}
+
+	return &schemas.Poll{
+		ID:             poll.ID,
+		MessageID:      poll.MessageID,
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		ClosesAt:       poll.ClosesAt,
+		VotersCount:    poll.VotersCount,
+		Options:        options,
+		CreatedAt:      poll.CreatedAt,
+	}
+}
+
+func MapPollOption(option domains.PollOption) schemas.PollOption {
+	return schemas.PollOption{
+		ID:         option.ID,
+		Text:       option.Text,
+		VotesCount: option.VotesCount,
+		VotedByMe:  option.VotedByMe,
+		VoterIDs:   option.VoterIDs,
+	}
+}
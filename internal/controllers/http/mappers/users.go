@@ -5,7 +5,7 @@ import (
 	"github.com/DKhorkov/kfc/internal/domains"
 )
 
-func MapUser(user domains.User) schemas.User {
+func MapUser(user domains.UserWithPresence) schemas.User {
 	return schemas.User{
 		ID:             user.ID,
 		Username:       user.Username,
@@ -13,10 +13,12 @@ func MapUser(user domains.User) schemas.User {
 		EmailConfirmed: user.EmailConfirmed,
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
+		Online:         user.Presence.Online,
+		LastSeenAt:     user.Presence.LastSeenAt,
 	}
 }
 
-func MapUsers(users []domains.User) []schemas.User {
+func MapUsers(users []domains.UserWithPresence) []schemas.User {
 	result := make([]schemas.User, len(users))
 	for i, user := range users {
 		result[i] = MapUser(user)
@@ -0,0 +1,169 @@
+package schemas
+
+import "time"
+
+// Attachment represents file, uploaded to chat.
+// swagger:model
+type Attachment struct {
+	// Unique identifier of the attachment.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which attachment was uploaded to.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Unique identifier of the user, who uploaded the attachment.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	UploaderID uint64 `json:"uploaderId"`
+
+	// Unique identifier of the message, which attachment was sent with. Absent until attachment is sent.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	MessageID *uint64 `json:"messageId,omitempty"`
+
+	// Name of the uploaded file.
+	// required: true
+	// nullable: false
+	// maxLength: 255
+	// example: report.pdf
+	Filename string `json:"filename"`
+
+	// MIME type of the file, detected by its content.
+	// required: true
+	// nullable: false
+	// example: application/pdf
+	MIMEType string `json:"mimeType"`
+
+	// Size of the file in bytes.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	Size uint64 `json:"size"`
+
+	// Hex encoded SHA-256 checksum of the file.
+	// required: true
+	// nullable: false
+	Checksum string `json:"checksum"`
+
+	// Status of preview generation. Preview is generated in background only for images.
+	// required: true
+	// nullable: false
+	// enum: none,pending,processing,ready,failed
+	PreviewStatus string `json:"previewStatus"`
+
+	// Width of the image in pixels. Present when preview is ready.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	Width *uint64 `json:"width,omitempty"`
+
+	// Height of the image in pixels. Present when preview is ready.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	Height *uint64 `json:"height,omitempty"`
+
+	// Compact placeholder of the image, which can be shown while image is loading. Present when preview is ready.
+	// required: false
+	// nullable: true
+	// example: LEHV6nWB2yk8pyo0adR*.7kCMdnj
+	Blurhash *string `json:"blurhash,omitempty"`
+
+	// Reduced copies of the image. Present when preview is ready.
+	// required: false
+	// nullable: false
+	Thumbnails []AttachmentThumbnail `json:"thumbnails,omitempty"`
+
+	// Represents datetime when attachment was uploaded.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AttachmentThumbnail represents reduced copy of image attachment.
+// swagger:model
+type AttachmentThumbnail struct {
+	// Name of the thumbnail, which is used for its download.
+	// required: true
+	// nullable: false
+	// enum: small,medium
+	Name string `json:"name"`
+
+	// MIME type of the thumbnail.
+	// required: true
+	// nullable: false
+	// example: image/jpeg
+	MIMEType string `json:"mimeType"`
+
+	// Size of the thumbnail in bytes.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	Size uint64 `json:"size"`
+
+	// Width of the thumbnail in pixels.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	Width uint64 `json:"width"`
+
+	// Height of the thumbnail in pixels.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	Height uint64 `json:"height"`
+}
+
+type AttachmentsInput struct {
+	// Unique identifiers of uploaded, but not sent attachments. Message with attachments may have no text
+	// required: false
+	// nullable: false
+	// in: body
+	AttachmentIDs []uint64 `json:"attachmentIds"`
+}
+
+// UploadAttachmentInput
+// swagger:parameters UploadAttachment
+type UploadAttachmentInput struct {
+	IDInput
+
+	// File to upload
+	// required: true
+	// in: formData
+	// swagger:file
+	File any `json:"file"`
+}
+
+// DownloadAttachmentInput
+// swagger:parameters DownloadAttachment
+type DownloadAttachmentInput struct {
+	IDInput
+
+	// Unique identifier of the attachment
+	// required: true
+	// nullable: false
+	// in: path
+	AttachmentID int `json:"attachmentID"`
+}
+
+// DownloadAttachmentThumbnailInput
+// swagger:parameters DownloadAttachmentThumbnail
+type DownloadAttachmentThumbnailInput struct {
+	DownloadAttachmentInput
+
+	// Name of the thumbnail
+	// required: true
+	// nullable: false
+	// enum: small,medium
+	// in: path
+	Name string `json:"name"`
+}
@@ -0,0 +1,468 @@
+package schemas
+
+import "time"
+
+// Chat represents a conversation between Users.
+// swagger:model
+type Chat struct {
+	// Unique identifier of the chat.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Type of the chat.
+	// required: true
+	// nullable: false
+	// enum: direct,group,channel
+	Type string `json:"type"`
+
+	// Title of the group chat or channel. Absent for direct chats.
+	// required: false
+	// nullable: true
+	// maxLength: 255
+	// example: Team
+	Title *string `json:"title,omitempty"`
+
+	// Public handle of the channel, by which it can be found. Absent for private channels and other chats.
+	// required: false
+	// nullable: true
+	// example: announcements
+	Handle *string `json:"handle,omitempty"`
+
+	// Number of subscribers of the channel. Absent for other chats.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	SubscribersCount *uint64 `json:"subscribersCount,omitempty"`
+
+	// Lifetime of messages in seconds, which are sent to the chat. Zero means that messages are not deleted
+	// automatically.
+	// required: true
+	// nullable: false
+	// enum: 0,86400,604800
+	MessageTTL uint64 `json:"messageTtl"`
+
+	// Members of the chat. Absent for channels, whose subscribers are not disclosed.
+	// required: false
+	// nullable: false
+	Members []ChatMember `json:"members,omitempty"`
+
+	// Number of messages, which were not read by current user.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	UnreadCount uint64 `json:"unreadCount"`
+
+	// Number of threads with unread replies, which current user joined.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	UnreadThreadsCount uint64 `json:"unreadThreadsCount"`
+
+	// Unsent message of current user in the chat. Absent, if user has no draft.
+	// required: false
+	// nullable: true
+	Draft *ChatDraft `json:"draft,omitempty"`
+
+	// Represents datetime when chat was created.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Represents datetime when chat was updated.
+	// required: true
+	// nullable: false
+	// format: date-time
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ChatMember represents a User, who participates in chat.
+// swagger:model
+type ChatMember struct {
+	// Unique identifier of the user.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	UserID uint64 `json:"userId"`
+
+	// Role of the user in the chat.
+	// required: true
+	// nullable: false
+	// enum: owner,admin,member
+	Role string `json:"role"`
+
+	// Unique identifier of the last message, which was read by the user.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	LastReadMessageID *uint64 `json:"lastReadMessageId,omitempty"`
+
+	// Represents datetime when user joined the chat.
+	// required: true
+	// nullable: false
+	// format: date-time
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// CreateDirectChatInput
+// swagger:parameters CreateDirectChat
+type CreateDirectChatInput struct {
+	// Information about User to open direct chat with
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifier of the user.
+		// required: true
+		// nullable: false
+		// minimum: 1
+		UserID uint64 `json:"userId"`
+	}
+}
+
+// GetChatByIDInput
+// swagger:parameters GetChatByID
+type GetChatByIDInput struct {
+	IDInput
+}
+
+// CreateGroupChatInput
+// swagger:parameters CreateGroupChat
+type CreateGroupChatInput struct {
+	// Information about group chat to create. Current User becomes its owner
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Title of the chat.
+		// required: true
+		// nullable: false
+		// maxLength: 255
+		// example: Team
+		Title string `json:"title"`
+
+		// Unique identifiers of users, who will be added to the chat as members.
+		// required: false
+		// nullable: false
+		MemberIDs []uint64 `json:"memberIds"`
+	}
+}
+
+// CreateChannelInput
+// swagger:parameters CreateChannel
+type CreateChannelInput struct {
+	// Information about channel to create. Current User becomes its owner
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Title of the channel.
+		// required: true
+		// nullable: false
+		// maxLength: 255
+		// example: Announcements
+		Title string `json:"title"`
+
+		// Public handle of the channel. Channel without handle is private and can be joined only by invites.
+		// required: false
+		// nullable: true
+		// pattern: ^[a-z0-9_]{5,32}$
+		// example: announcements
+		Handle *string `json:"handle,omitempty"`
+	}
+}
+
+// Channel represents public channel, found by search.
+// swagger:model
+type Channel struct {
+	// Unique identifier of the channel.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Title of the channel.
+	// required: true
+	// nullable: false
+	// maxLength: 255
+	// example: Announcements
+	Title string `json:"title"`
+
+	// Public handle of the channel.
+	// required: true
+	// nullable: false
+	// example: announcements
+	Handle string `json:"handle"`
+
+	// Number of subscribers of the channel.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	SubscribersCount uint64 `json:"subscribersCount"`
+}
+
+// GetChannelsInput
+// swagger:parameters GetChannels
+type GetChannelsInput struct {
+	Pagination
+
+	// Part of handle of the channel
+	// required: false
+	// nullable: false
+	// in: query
+	Handle string `json:"handle"`
+}
+
+// SubscribeToChannelInput
+// swagger:parameters SubscribeToChannel
+type SubscribeToChannelInput struct {
+	IDInput
+}
+
+// AddChatMembersInput
+// swagger:parameters AddChatMembers
+type AddChatMembersInput struct {
+	IDInput
+
+	// Information about Users to add to the chat
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifiers of users, who will be added to the chat as members.
+		// required: true
+		// nullable: false
+		// minItems: 1
+		UserIDs []uint64 `json:"userIds"`
+	}
+}
+
+// ChatMemberInput
+// swagger:parameters RemoveChatMember PromoteChatMember DemoteChatMember
+type ChatMemberInput struct {
+	IDInput
+
+	// Unique identifier of the chat member
+	// required: true
+	// nullable: false
+	// in: path
+	UserID int `json:"userID"`
+}
+
+// LeaveChatInput
+// swagger:parameters LeaveChat
+type LeaveChatInput struct {
+	IDInput
+}
+
+// ChatReadState represents read state of chat for current User.
+// swagger:model
+type ChatReadState struct {
+	// Unique identifier of the chat.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Unique identifier of the last message, which was read by current user.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	LastReadMessageID uint64 `json:"lastReadMessageId"`
+
+	// Number of messages, which were not read by current user.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	UnreadCount uint64 `json:"unreadCount"`
+}
+
+// ReadChatMessagesInput
+// swagger:parameters ReadChatMessages
+type ReadChatMessagesInput struct {
+	IDInput
+
+	// Information about last read Message
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifier of the last read message.
+		// required: true
+		// nullable: false
+		// minimum: 1
+		MessageID uint64 `json:"messageId"`
+	}
+}
+
+// SendTypingIndicatorInput
+// swagger:parameters SendTypingIndicator
+type SendTypingIndicatorInput struct {
+	IDInput
+}
+
+// ChatInvite represents a link, which lets Users join group chat.
+// swagger:model
+type ChatInvite struct {
+	// Unique identifier of the invite.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which invite leads to.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Secret token of the invite, which should be used to join the chat.
+	// required: true
+	// nullable: false
+	Token string `json:"token"`
+
+	// Unique identifier of the user, who created the invite.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	CreatedBy uint64 `json:"createdBy"`
+
+	// Represents datetime after which invite can not be used. Absent for invites without expiration.
+	// required: false
+	// nullable: true
+	// format: date-time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Number of times invite can be used. Absent for invites without such limit.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	MaxUses *uint64 `json:"maxUses,omitempty"`
+
+	// Number of users, who joined the chat by the invite.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	UsesCount uint64 `json:"usesCount"`
+
+	// Represents datetime when invite was revoked. Absent for invites, which were not revoked.
+	// required: false
+	// nullable: true
+	// format: date-time
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Represents datetime when invite was created.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateChatInviteInput
+// swagger:parameters CreateChatInvite
+type CreateChatInviteInput struct {
+	IDInput
+
+	// Limits of the invite
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Datetime after which invite can not be used. Invite does not expire, if absent.
+		// required: false
+		// nullable: true
+		// format: date-time
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+		// Number of times invite can be used. Number of uses is not limited, if absent.
+		// required: false
+		// nullable: true
+		// minimum: 1
+		// maximum: 100000
+		MaxUses *uint64 `json:"maxUses,omitempty"`
+	}
+}
+
+// GetChatInvitesInput
+// swagger:parameters GetChatInvites
+type GetChatInvitesInput struct {
+	IDInput
+}
+
+// RevokeChatInviteInput
+// swagger:parameters RevokeChatInvite
+type RevokeChatInviteInput struct {
+	IDInput
+
+	// Unique identifier of the invite
+	// required: true
+	// nullable: false
+	// in: path
+	InviteID int `json:"inviteID"`
+}
+
+// JoinChatByInviteInput
+// swagger:parameters JoinChatByInvite
+type JoinChatByInviteInput struct {
+	// Secret token of the invite
+	// required: true
+	// nullable: false
+	// in: path
+	Token string `json:"token"`
+}
+
+// ChatDraft represents unsent message of User in chat, which is synced between sessions of the User.
+// swagger:model
+type ChatDraft struct {
+	// Unique identifier of the chat.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Text of the draft.
+	// required: true
+	// nullable: false
+	// example: See you
+	Text string `json:"text"`
+
+	// Represents datetime when draft was updated.
+	// required: true
+	// nullable: false
+	// format: date-time
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SaveChatDraftInput
+// swagger:parameters SaveChatDraft
+type SaveChatDraftInput struct {
+	IDInput
+
+	// Draft data
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Text of the draft.
+		// required: true
+		// nullable: false
+		// minLength: 1
+		// maxLength: 4096
+		// example: See you
+		Text string `json:"text"`
+	}
+}
+
+// GetChatDraftInput
+// swagger:parameters GetChatDraft
+type GetChatDraftInput struct {
+	IDInput
+}
+
+// DeleteChatDraftInput
+// swagger:parameters DeleteChatDraft
+type DeleteChatDraftInput struct {
+	IDInput
+}
@@ -0,0 +1,38 @@
+package schemas
+
+// LinkPreview represents metadata of page, which link in the message leads to.
+// swagger:model
+type LinkPreview struct {
+	// Link, which is previewed.
+	// required: true
+	// nullable: false
+	// maxLength: 2048
+	// example: https://go.dev/blog/
+	URL string `json:"url"`
+
+	// Title of the page.
+	// required: true
+	// nullable: false
+	// maxLength: 300
+	// example: The Go Blog
+	Title string `json:"title"`
+
+	// Description of the page.
+	// required: false
+	// nullable: true
+	// maxLength: 1000
+	Description *string `json:"description,omitempty"`
+
+	// Name of the site, which page belongs to.
+	// required: false
+	// nullable: true
+	// maxLength: 100
+	// example: Go
+	SiteName *string `json:"siteName,omitempty"`
+
+	// Link to the image of the page. Image is not proxied and is loaded by client from its origin.
+	// required: false
+	// nullable: true
+	// maxLength: 2048
+	ImageURL *string `json:"imageUrl,omitempty"`
+}
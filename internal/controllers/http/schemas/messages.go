@@ -0,0 +1,778 @@
+package schemas
+
+import "time"
+
+// Message represents a message, sent to chat.
+// swagger:model
+type Message struct {
+	// Unique identifier of the message.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which message belongs to.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Unique identifier of the user, who sent the message.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	AuthorID uint64 `json:"authorId"`
+
+	// Type of the message. System messages describe changes of the chat, made by author of the message. Poll
+	// messages contain poll, which question is the text of the message.
+	// required: true
+	// nullable: false
+	// enum: regular,system,poll
+	Type string `json:"type"`
+
+	// Change of the chat, which system message describes. Present only for system messages.
+	// required: false
+	// nullable: true
+	SystemEvent *SystemEvent `json:"systemEvent,omitempty"`
+
+	// Text of the message.
+	// required: true
+	// nullable: false
+	// maxLength: 4096
+	// example: Hello!
+	Text string `json:"text"`
+
+	// Represents datetime when message was sent.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Represents datetime when message was updated.
+	// required: true
+	// nullable: false
+	// format: date-time
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Represents datetime when message text was edited last time.
+	// required: false
+	// nullable: true
+	// format: date-time
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+
+	// Represents datetime when message was deleted for everyone. Text of such message is erased.
+	// required: false
+	// nullable: true
+	// format: date-time
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Unique identifier of the message, which is quoted by this one.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	ReplyToMessageID *uint64 `json:"replyToMessageId,omitempty"`
+
+	// Unique identifier of the root message of the thread, which message is a reply in.
+	// Replies in threads are not shown in chat history.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	ThreadRootID *uint64 `json:"threadRootId,omitempty"`
+
+	// Number of replies in thread of the message.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	ThreadReplyCount uint64 `json:"threadReplyCount"`
+
+	// Represents datetime when the last reply was sent to thread of the message.
+	// required: false
+	// nullable: true
+	// format: date-time
+	ThreadLastReplyAt *time.Time `json:"threadLastReplyAt,omitempty"`
+
+	// Number of unread replies in thread of the message. Present only if current user joined the thread.
+	// required: false
+	// nullable: true
+	// minimum: 0
+	ThreadUnreadCount *uint64 `json:"threadUnreadCount,omitempty"`
+
+	// Represents datetime after which message is deleted automatically. Present only for messages, which were
+	// sent to chat with auto-delete timer.
+	// required: false
+	// nullable: true
+	// format: date-time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Reactions on the message, aggregated by emoji.
+	// required: true
+	// nullable: false
+	Reactions []MessageReaction `json:"reactions"`
+
+	// Files, sent with the message. Attachments of deleted for everyone message are not shown.
+	// required: true
+	// nullable: false
+	Attachments []Attachment `json:"attachments"`
+
+	// Poll of the message along with its results. Present only for poll messages, which were not deleted.
+	// required: false
+	// nullable: true
+	Poll *Poll `json:"poll,omitempty"`
+
+	// Preview of the first link in text of the message. Present only when metadata of the page is fetched,
+	// which happens in background after the message is sent.
+	// required: false
+	// nullable: true
+	LinkPreview *LinkPreview `json:"linkPreview,omitempty"`
+
+	// Original of the message, if message was forwarded.
+	// required: false
+	// nullable: true
+	ForwardedFrom *MessageForward `json:"forwardedFrom,omitempty"`
+}
+
+// MessageForward represents original of forwarded message.
+// swagger:model
+type MessageForward struct {
+	// Unique identifier of the user, who sent the original message.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	AuthorID *uint64 `json:"authorId,omitempty"`
+
+	// Unique identifier of the chat, which original message was sent to. Present only if current user is a
+	// member of the chat.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	ChatID *uint64 `json:"chatId,omitempty"`
+
+	// Unique identifier of the original message. Present only if current user is a member of its chat.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	MessageID *uint64 `json:"messageId,omitempty"`
+}
+
+// SystemEvent represents change of chat, which system message describes.
+// swagger:model
+type SystemEvent struct {
+	// Type of the change.
+	// required: true
+	// nullable: false
+	// enum: message.pinned,message.unpinned,chat.message_ttl_changed
+	Type string `json:"type"`
+
+	// Unique identifier of the message, which was changed.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	MessageID *uint64 `json:"messageId,omitempty"`
+
+	// New lifetime of chat messages in seconds. Zero means that messages are not deleted automatically.
+	// Present only for changes of auto-delete timer.
+	// required: false
+	// nullable: true
+	// enum: 0,86400,604800
+	MessageTTL *uint64 `json:"messageTtl,omitempty"`
+}
+
+// MessageReaction represents reactions with the same emoji on message.
+// swagger:model
+type MessageReaction struct {
+	// Emoji of the reaction.
+	// required: true
+	// nullable: false
+	// example: 👍
+	Emoji string `json:"emoji"`
+
+	// Number of users, who left the reaction.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	Count uint64 `json:"count"`
+
+	// Represents whether current user left the reaction.
+	// required: true
+	// nullable: false
+	ReactedByMe bool `json:"reactedByMe"`
+}
+
+// MessagesPage represents page of chat history.
+// swagger:model
+type MessagesPage struct {
+	// Messages of the page, ordered from older to newer.
+	// required: true
+	// nullable: false
+	Messages []Message `json:"messages"`
+
+	// Represents whether there are more messages in requested direction.
+	// required: true
+	// nullable: false
+	HasMore bool `json:"hasMore"`
+}
+
+// MessageSearchResult represents message, found by full-text search.
+// swagger:model
+type MessageSearchResult struct {
+	// Found message.
+	// required: true
+	// nullable: false
+	Message Message `json:"message"`
+
+	// HTML escaped fragment of message text with matched words wrapped into <mark> tags.
+	// required: true
+	// nullable: false
+	// example: Встречаемся <mark>завтра</mark> в офисе
+	Snippet string `json:"snippet"`
+
+	// Relevance of the message to the search query.
+	// required: true
+	// nullable: false
+	Rank float64 `json:"rank"`
+}
+
+// MessagesSearchPage represents page of search results.
+// swagger:model
+type MessagesSearchPage struct {
+	// Results of the page, ordered by relevance.
+	// required: true
+	// nullable: false
+	Results []MessageSearchResult `json:"results"`
+
+	// Represents whether there are more results after current page.
+	// required: true
+	// nullable: false
+	HasMore bool `json:"hasMore"`
+}
+
+// Mention represents mention of current user in message.
+// swagger:model
+type Mention struct {
+	// Unique identifier of the mention.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which message was sent to.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Way, in which user was mentioned: by username, with @all or with @here.
+	// required: true
+	// nullable: false
+	// enum: user,all,here
+	Type string `json:"type"`
+
+	// Message with the mention.
+	// required: true
+	// nullable: false
+	Message Message `json:"message"`
+
+	// Represents datetime when user was mentioned.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MentionsPage represents page of mentions feed.
+// swagger:model
+type MentionsPage struct {
+	// Mentions of the page, ordered from newer to older.
+	// required: true
+	// nullable: false
+	Mentions []Mention `json:"mentions"`
+
+	// Represents whether there are older mentions.
+	// required: true
+	// nullable: false
+	HasMore bool `json:"hasMore"`
+}
+
+// Pin represents message, pinned in chat.
+// swagger:model
+type Pin struct {
+	// Unique identifier of the pin.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which message is pinned in.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Pinned message.
+	// required: true
+	// nullable: false
+	Message Message `json:"message"`
+
+	// Unique identifier of the user, who pinned the message.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	PinnedBy uint64 `json:"pinnedBy"`
+
+	// Represents datetime when message was pinned.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ThreadPage represents page of thread replies.
+// swagger:model
+type ThreadPage struct {
+	// Root message of the thread.
+	// required: true
+	// nullable: false
+	Root Message `json:"root"`
+
+	// Replies of the page, ordered from older to newer.
+	// required: true
+	// nullable: false
+	Messages []Message `json:"messages"`
+
+	// Represents whether there are more replies in requested direction.
+	// required: true
+	// nullable: false
+	HasMore bool `json:"hasMore"`
+}
+
+// ThreadReadState represents read state of thread for current User.
+// swagger:model
+type ThreadReadState struct {
+	// Unique identifier of the chat.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Unique identifier of the root message of the thread.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ThreadRootID uint64 `json:"threadRootId"`
+
+	// Unique identifier of the last reply, which was read by current user.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	LastReadMessageID uint64 `json:"lastReadMessageId"`
+
+	// Number of replies, which were not read by current user.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	UnreadCount uint64 `json:"unreadCount"`
+}
+
+// ScheduledMessage represents message, which will be sent to chat on behalf of current user later.
+// swagger:model
+type ScheduledMessage struct {
+	// Unique identifier of the scheduled message.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the chat, which message will be sent to.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ChatID uint64 `json:"chatId"`
+
+	// Unique identifier of the author of the message.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	AuthorID uint64 `json:"authorId"`
+
+	// Text of the message.
+	// required: true
+	// nullable: false
+	Text string `json:"text"`
+
+	// Unique identifier of the message, which will be quoted.
+	// required: false
+	// nullable: true
+	// minimum: 1
+	ReplyToMessageID *uint64 `json:"replyToMessageId,omitempty"`
+
+	// Represents datetime when message will be sent.
+	// required: true
+	// nullable: false
+	// format: date-time
+	SendAt time.Time `json:"sendAt"`
+
+	// Represents datetime when message could not be sent, for example, because author left the chat.
+	// Failed message is not sent again until it is edited.
+	// required: false
+	// nullable: true
+	// format: date-time
+	FailedAt *time.Time `json:"failedAt,omitempty"`
+
+	// Represents datetime when message was scheduled.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Represents datetime when scheduled message was updated last time.
+	// required: true
+	// nullable: false
+	// format: date-time
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type MessageTextInput struct {
+	// Text of the message.
+	// required: true
+	// nullable: false
+	// maxLength: 4096
+	// example: Hello!
+	// in: body
+	Text string `json:"text"`
+}
+
+// CreateMessageInput
+// swagger:parameters CreateMessage
+type CreateMessageInput struct {
+	IDInput
+
+	// Information about Message to send
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		MessageTextInput
+		ReplyInput
+		AttachmentsInput
+	}
+}
+
+type ReplyInput struct {
+	// Unique identifier of the message to quote. Message must belong to the same history or thread
+	// required: false
+	// nullable: true
+	// minimum: 1
+	// in: body
+	ReplyToMessageID *uint64 `json:"replyToMessageId"`
+}
+
+// MessagesCursor
+// swagger:parameters MessagesCursor
+type MessagesCursor struct {
+	// Returns messages, which are older than message with provided ID
+	// required: false
+	// nullable: false
+	// in: query
+	Before uint64 `json:"before"`
+
+	// Returns messages, which are newer than message with provided ID. Can not be used with "before"
+	// required: false
+	// nullable: false
+	// in: query
+	After uint64 `json:"after"`
+
+	// Maximum amount of messages
+	// required: false
+	// nullable: false
+	// maximum: 100
+	// in: query
+	Limit uint64 `json:"limit"`
+}
+
+// GetChatMessagesInput
+// swagger:parameters GetChatMessages
+type GetChatMessagesInput struct {
+	IDInput
+	MessagesCursor
+}
+
+type MessageIDInput struct {
+	// Unique identifier of the message
+	// required: true
+	// nullable: false
+	// in: path
+	MessageID int `json:"messageID"`
+}
+
+// UpdateMessageInput
+// swagger:parameters UpdateMessage
+type UpdateMessageInput struct {
+	IDInput
+	MessageIDInput
+
+	// New text of the Message
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		MessageTextInput
+	}
+}
+
+// DeleteMessageInput
+// swagger:parameters DeleteMessage
+type DeleteMessageInput struct {
+	IDInput
+	MessageIDInput
+
+	// Deletes message for all members of the chat. Otherwise, message is deleted only for current user
+	// required: false
+	// nullable: false
+	// in: query
+	ForEveryone bool `json:"forEveryone"`
+}
+
+// MessageReactionInput
+// swagger:parameters AddMessageReaction RemoveMessageReaction
+type MessageReactionInput struct {
+	IDInput
+	MessageIDInput
+
+	// Emoji of the reaction. Must be one of allowed reactions
+	// required: true
+	// nullable: false
+	// in: path
+	Emoji string `json:"emoji"`
+}
+
+// GetThreadMessagesInput
+// swagger:parameters GetThreadMessages
+type GetThreadMessagesInput struct {
+	IDInput
+	MessageIDInput
+	MessagesCursor
+}
+
+// CreateThreadMessageInput
+// swagger:parameters CreateThreadMessage
+type CreateThreadMessageInput struct {
+	IDInput
+	MessageIDInput
+
+	// Information about reply to send
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		MessageTextInput
+		ReplyInput
+		AttachmentsInput
+	}
+}
+
+// ReadThreadMessagesInput
+// swagger:parameters ReadThreadMessages
+type ReadThreadMessagesInput struct {
+	IDInput
+	MessageIDInput
+
+	// Information about last read reply
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifier of the last read reply.
+		// required: true
+		// nullable: false
+		// minimum: 1
+		MessageID uint64 `json:"messageId"`
+	}
+}
+
+// SearchMessagesInput
+// swagger:parameters SearchMessages
+type SearchMessagesInput struct {
+	// Search query. Supports quoted phrases, "or" and "-" for exclusion of words
+	// required: true
+	// nullable: false
+	// maxLength: 256
+	// in: query
+	Q string `json:"q"`
+
+	// Searches only in chat with provided ID
+	// required: false
+	// nullable: false
+	// in: query
+	ChatID uint64 `json:"chatId"`
+
+	// Searches only messages of author with provided ID
+	// required: false
+	// nullable: false
+	// in: query
+	AuthorID uint64 `json:"authorId"`
+
+	// Searches only messages, which were sent at or after provided datetime
+	// required: false
+	// nullable: false
+	// format: date-time
+	// in: query
+	From time.Time `json:"from"`
+
+	// Searches only messages, which were sent before provided datetime
+	// required: false
+	// nullable: false
+	// format: date-time
+	// in: query
+	To time.Time `json:"to"`
+
+	// Maximum amount of results
+	// required: false
+	// nullable: false
+	// maximum: 100
+	// in: query
+	Limit uint64 `json:"limit"`
+
+	// Amount of results to skip
+	// required: false
+	// nullable: false
+	// in: query
+	Offset uint64 `json:"offset"`
+}
+
+// ForwardMessagesInput
+// swagger:parameters ForwardMessages
+type ForwardMessagesInput struct {
+	IDInput
+
+	// Information about Messages to forward
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifier of the chat, which messages are forwarded from.
+		// required: true
+		// nullable: false
+		// minimum: 1
+		FromChatID uint64 `json:"fromChatId"`
+
+		// Unique identifiers of the messages to forward.
+		// required: true
+		// nullable: false
+		// minItems: 1
+		// maxItems: 100
+		MessageIDs []uint64 `json:"messageIds"`
+	}
+}
+
+// GetMentionsInput
+// swagger:parameters GetMentions
+type GetMentionsInput struct {
+	// Returns mentions, which are older than mention with provided ID
+	// required: false
+	// nullable: false
+	// in: query
+	Before uint64 `json:"before"`
+
+	// Maximum amount of mentions
+	// required: false
+	// nullable: false
+	// maximum: 100
+	// in: query
+	Limit uint64 `json:"limit"`
+}
+
+// PinMessageInput
+// swagger:parameters PinMessage UnpinMessage
+type PinMessageInput struct {
+	IDInput
+	MessageIDInput
+}
+
+// GetChatPinsInput
+// swagger:parameters GetChatPins
+type GetChatPinsInput struct {
+	IDInput
+}
+
+type SendAtInput struct {
+	// Datetime when message will be sent. Must be in the future.
+	// required: true
+	// nullable: false
+	// format: date-time
+	// example: 2026-12-31T23:59:00Z
+	// in: body
+	SendAt time.Time `json:"sendAt"`
+}
+
+// ScheduleMessageInput
+// swagger:parameters ScheduleMessage
+type ScheduleMessageInput struct {
+	IDInput
+
+	// Information about Message to send later
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		MessageTextInput
+		ReplyInput
+		SendAtInput
+	}
+}
+
+// GetScheduledMessagesInput
+// swagger:parameters GetScheduledMessages
+type GetScheduledMessagesInput struct {
+	IDInput
+}
+
+type ScheduledMessageIDInput struct {
+	// Unique identifier of the scheduled message
+	// required: true
+	// nullable: false
+	// in: path
+	ScheduledMessageID int `json:"scheduledMessageID"`
+}
+
+// UpdateScheduledMessageInput
+// swagger:parameters UpdateScheduledMessage
+type UpdateScheduledMessageInput struct {
+	IDInput
+	ScheduledMessageIDInput
+
+	// New text and sending time of the scheduled Message
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		MessageTextInput
+		SendAtInput
+	}
+}
+
+// CancelScheduledMessageInput
+// swagger:parameters CancelScheduledMessage
+type CancelScheduledMessageInput struct {
+	IDInput
+	ScheduledMessageIDInput
+}
+
+// SetChatMessageTTLInput
+// swagger:parameters SetChatMessageTTL
+type SetChatMessageTTLInput struct {
+	IDInput
+
+	// Auto-delete timer of the chat
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Lifetime of messages in seconds: one day, one week or zero to turn timer off.
+		// required: true
+		// nullable: false
+		// enum: 0,86400,604800
+		// example: 86400
+		// in: body
+		TTL uint64 `json:"ttl"`
+	}
+}
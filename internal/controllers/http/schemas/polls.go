@@ -0,0 +1,162 @@
+package schemas
+
+import "time"
+
+// Poll represents question with options, which members of group chat vote for.
+// swagger:model
+type Poll struct {
+	// Unique identifier of the poll.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Unique identifier of the message, which contains the poll.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	MessageID uint64 `json:"messageId"`
+
+	// Question of the poll.
+	// required: true
+	// nullable: false
+	// maxLength: 300
+	// example: Where do we have lunch?
+	Question string `json:"question"`
+
+	// Represents whether several options can be chosen.
+	// required: true
+	// nullable: false
+	MultipleChoice bool `json:"multipleChoice"`
+
+	// Represents whether voters are hidden. Only numbers of votes are shown for anonymous polls.
+	// required: true
+	// nullable: false
+	Anonymous bool `json:"anonymous"`
+
+	// Represents datetime after which poll does not accept votes. Absent for polls without closing time.
+	// required: false
+	// nullable: true
+	// format: date-time
+	ClosesAt *time.Time `json:"closesAt,omitempty"`
+
+	// Number of users, who voted in the poll.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	VotersCount uint64 `json:"votersCount"`
+
+	// Options of the poll along with their results.
+	// required: true
+	// nullable: false
+	Options []PollOption `json:"options"`
+
+	// Represents datetime when poll was created.
+	// required: true
+	// nullable: false
+	// format: date-time
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PollOption represents answer of poll along with votes for it.
+// swagger:model
+type PollOption struct {
+	// Unique identifier of the option.
+	// required: true
+	// nullable: false
+	// minimum: 1
+	ID uint64 `json:"id"`
+
+	// Text of the option.
+	// required: true
+	// nullable: false
+	// maxLength: 100
+	// example: Pizza
+	Text string `json:"text"`
+
+	// Number of votes for the option.
+	// required: true
+	// nullable: false
+	// minimum: 0
+	VotesCount uint64 `json:"votesCount"`
+
+	// Represents whether current user voted for the option.
+	// required: true
+	// nullable: false
+	VotedByMe bool `json:"votedByMe"`
+
+	// Unique identifiers of users, who voted for the option. Present only for public polls.
+	// required: false
+	// nullable: true
+	VoterIDs []uint64 `json:"voterIds,omitempty"`
+}
+
+// CreatePollInput
+// swagger:parameters CreatePoll
+type CreatePollInput struct {
+	IDInput
+
+	// Information about Poll to send
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Question of the poll.
+		// required: true
+		// nullable: false
+		// minLength: 1
+		// maxLength: 300
+		// example: Where do we have lunch?
+		Question string `json:"question"`
+
+		// Options of the poll in order of showing. Options must be unique.
+		// required: true
+		// nullable: false
+		// minItems: 2
+		// maxItems: 10
+		// example: ["Pizza", "Sushi"]
+		Options []string `json:"options"`
+
+		// Whether several options can be chosen.
+		// required: false
+		// nullable: false
+		MultipleChoice bool `json:"multipleChoice"`
+
+		// Whether voters are hidden.
+		// required: false
+		// nullable: false
+		Anonymous bool `json:"anonymous"`
+
+		// Datetime after which poll does not accept votes. Poll is not closed automatically, if absent.
+		// required: false
+		// nullable: true
+		// format: date-time
+		ClosesAt *time.Time `json:"closesAt,omitempty"`
+	}
+}
+
+// VotePollInput
+// swagger:parameters VotePoll
+type VotePollInput struct {
+	IDInput
+	MessageIDInput
+
+	// Chosen options
+	// required: true
+	// nullable: false
+	// in: body
+	Body struct {
+		// Unique identifiers of chosen options. Only one option can be chosen in single choice poll.
+		// required: true
+		// nullable: false
+		// minItems: 1
+		OptionIDs []uint64 `json:"optionIds"`
+	}
+}
+
+// RetractPollVoteInput
+// swagger:parameters RetractPollVote
+type RetractPollVoteInput struct {
+	IDInput
+	MessageIDInput
+}
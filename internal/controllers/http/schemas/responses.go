@@ -60,6 +60,18 @@ type Conflict struct {
 	Error string
 }
 
+// Gone message returned when requested entity is no longer available
+// swagger:response Gone
+type Gone struct {
+	// HTTP Status Code
+	// in: header
+	StatusCode int
+
+	// Description of the situation
+	// in: body
+	Error string
+}
+
 // Unauthorized message returned when no information about current User
 // swagger:response Unauthorized
 type Unauthorized struct {
@@ -99,3 +111,27 @@ type NoContent struct {
 	// in: header
 	StatusCode int
 }
+
+// RequestEntityTooLarge message returned when Request Body exceeds allowed size
+// swagger:response RequestEntityTooLarge
+type RequestEntityTooLarge struct {
+	// HTTP Status Code
+	// in: header
+	StatusCode int
+
+	// Description of the situation
+	// in: body
+	Error string
+}
+
+// UnsupportedMediaType message returned when type of provided content is not supported
+// swagger:response UnsupportedMediaType
+type UnsupportedMediaType struct {
+	// HTTP Status Code
+	// in: header
+	StatusCode int
+
+	// Description of the situation
+	// in: body
+	Error string
+}
@@ -42,6 +42,17 @@ type User struct {
 	// nullable: false
 	// format: date-time
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Represents whether user holds real-time connection right now.
+	// required: true
+	// nullable: false
+	Online bool `json:"online"`
+
+	// Represents datetime when user was online last time. Absent, if user has never been online.
+	// required: false
+	// nullable: true
+	// format: date-time
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
 }
 
 // IDInput
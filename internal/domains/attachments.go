@@ -0,0 +1,133 @@
+package domains
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment represents file, uploaded to chat. Attachment is visible only to its uploader, until it is sent
+// with message.
+type Attachment struct {
+	ID            uint64                  `json:"id"`
+	ChatID        uint64                  `json:"chatId"`
+	UploaderID    uint64                  `json:"uploaderId"`
+	MessageID     *uint64                 `json:"messageId,omitempty"`
+	StorageKey    string                  `json:"-"` // Key of attachment content in blob storage
+	Filename      string                  `json:"filename"`
+	MIMEType      string                  `json:"mimeType"`
+	Size          uint64                  `json:"size"`
+	Checksum      string                  `json:"checksum"` // Hex encoded SHA-256 of attachment content
+	PreviewStatus AttachmentPreviewStatus `json:"previewStatus"`
+	Width         *uint64                 `json:"width,omitempty"`    // Set for images with ready preview
+	Height        *uint64                 `json:"height,omitempty"`   // Set for images with ready preview
+	Blurhash      *string                 `json:"blurhash,omitempty"` // Placeholder to show, while image is loaded
+	Thumbnails    []AttachmentThumbnail   `json:"thumbnails,omitempty"`
+	CreatedAt     time.Time               `json:"createdAt"`
+}
+
+// AttachmentPreviewStatus represents stage of preview generation. Previews are generated only for images.
+type AttachmentPreviewStatus string
+
+const (
+	AttachmentPreviewStatusNone       AttachmentPreviewStatus = "none" // Preview is not generated for attachment
+	AttachmentPreviewStatusPending    AttachmentPreviewStatus = "pending"
+	AttachmentPreviewStatusProcessing AttachmentPreviewStatus = "processing"
+	AttachmentPreviewStatusReady      AttachmentPreviewStatus = "ready"
+	AttachmentPreviewStatusFailed     AttachmentPreviewStatus = "failed" // Image could not be decoded
+)
+
+type ThumbnailName string
+
+const (
+	ThumbnailNameSmall  ThumbnailName = "small"
+	ThumbnailNameMedium ThumbnailName = "medium"
+)
+
+// ThumbnailNames lists all thumbnails, generated for image attachment.
+var ThumbnailNames = []ThumbnailName{ThumbnailNameSmall, ThumbnailNameMedium}
+
+// AttachmentThumbnail represents reduced copy of image attachment.
+type AttachmentThumbnail struct {
+	ID           uint64        `json:"id"`
+	AttachmentID uint64        `json:"attachmentId"`
+	Name         ThumbnailName `json:"name"`
+	StorageKey   string        `json:"-"`
+	MIMEType     string        `json:"mimeType"`
+	Size         uint64        `json:"size"`
+	Width        uint64        `json:"width"`
+	Height       uint64        `json:"height"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+// ImagePreview contains results of image processing, which are not saved yet.
+type ImagePreview struct {
+	Width      uint64           `json:"width"`
+	Height     uint64           `json:"height"`
+	Blurhash   string           `json:"blurhash"`
+	Thumbnails []ImageThumbnail `json:"thumbnails"`
+}
+
+type ImageThumbnail struct {
+	Name     ThumbnailName `json:"name"`
+	MIMEType string        `json:"mimeType"`
+	Width    uint64        `json:"width"`
+	Height   uint64        `json:"height"`
+	Content  []byte        `json:"-"`
+}
+
+type UpdateAttachmentPreviewDTO struct {
+	AttachmentID uint64                  `json:"attachmentId"`
+	Status       AttachmentPreviewStatus `json:"status"`
+	Width        *uint64                 `json:"width,omitempty"`
+	Height       *uint64                 `json:"height,omitempty"`
+	Blurhash     *string                 `json:"blurhash,omitempty"`
+}
+
+type CreateAttachmentThumbnailDTO struct {
+	AttachmentID uint64        `json:"attachmentId"`
+	Name         ThumbnailName `json:"name"`
+	StorageKey   string        `json:"storageKey"`
+	MIMEType     string        `json:"mimeType"`
+	Size         uint64        `json:"size"`
+	Width        uint64        `json:"width"`
+	Height       uint64        `json:"height"`
+}
+
+// AttachmentDownload contains either URL for direct download of attachment or its thumbnail from storage or
+// its content, which should be closed after reading.
+type AttachmentDownload struct {
+	Filename string        `json:"filename"`
+	MIMEType string        `json:"mimeType"`
+	Size     uint64        `json:"size"`
+	URL      string        `json:"url,omitempty"`
+	Content  io.ReadCloser `json:"-"`
+}
+
+type RawUploadAttachmentDTO struct {
+	AccessToken string    `json:"accessToken"`
+	ChatID      uint64    `json:"chatId"`
+	Filename    string    `json:"filename"`
+	Content     io.Reader `json:"-"`
+}
+
+// UploadAttachmentDTO contains data for uploading attachment. Content is read until its end, but no more than
+// MaxSize bytes.
+type UploadAttachmentDTO struct {
+	ChatID     uint64    `json:"chatId"`
+	UploaderID uint64    `json:"uploaderId"`
+	Filename   string    `json:"filename"`
+	MIMEType   string    `json:"mimeType"`
+	MaxSize    int64     `json:"maxSize"`
+	Content    io.Reader `json:"-"`
+}
+
+type CreateAttachmentDTO struct {
+	ChatID        uint64                  `json:"chatId"`
+	UploaderID    uint64                  `json:"uploaderId"`
+	StorageKey    string                  `json:"storageKey"`
+	Filename      string                  `json:"filename"`
+	MIMEType      string                  `json:"mimeType"`
+	Size          uint64                  `json:"size"`
+	Checksum      string                  `json:"checksum"`
+	PreviewStatus AttachmentPreviewStatus `json:"previewStatus"`
+}
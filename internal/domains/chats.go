@@ -0,0 +1,267 @@
+package domains
+
+import "time"
+
+type ChatType string
+
+const (
+	ChatTypeDirect  ChatType = "direct"
+	ChatTypeGroup   ChatType = "group"
+	ChatTypeChannel ChatType = "channel" // Only owner and admins can post, subscribers can read and react.
+)
+
+type ChatMemberRole string
+
+const (
+	ChatMemberRoleOwner  ChatMemberRole = "owner"
+	ChatMemberRoleAdmin  ChatMemberRole = "admin"
+	ChatMemberRoleMember ChatMemberRole = "member"
+)
+
+// MessageTTL is a lifetime of chat messages in seconds, after which they are deleted automatically.
+type MessageTTL uint64
+
+const (
+	MessageTTLOff  MessageTTL = 0 // Messages are never deleted automatically.
+	MessageTTLDay  MessageTTL = 24 * 60 * 60
+	MessageTTLWeek MessageTTL = 7 * MessageTTLDay
+)
+
+// IsValid reports whether chat can have such lifetime of messages.
+func (t MessageTTL) IsValid() bool {
+	return t == MessageTTLOff || t == MessageTTLDay || t == MessageTTLWeek
+}
+
+// CanManageMembers reports whether member with such role can add and remove other members.
+func (r ChatMemberRole) CanManageMembers() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanPostToChannel reports whether member with such role can post messages to channel.
+func (r ChatMemberRole) CanPostToChannel() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanManageInvites reports whether member with such role can create and revoke invite links of group chat.
+func (r ChatMemberRole) CanManageInvites() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanMentionEveryone reports whether member with such role can notify all members of group chat with
+// @all and @here mentions.
+func (r ChatMemberRole) CanMentionEveryone() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanPinMessages reports whether member with such role can pin and unpin messages of group chat. Any
+// member of direct chat can pin messages.
+func (r ChatMemberRole) CanPinMessages() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanChangeMessageTTL reports whether member with such role can change lifetime of messages of group chat or
+// channel. Any member of direct chat can change lifetime of its messages.
+func (r ChatMemberRole) CanChangeMessageTTL() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+// CanModerateMessages reports whether member with such role can delete messages of other members.
+func (r ChatMemberRole) CanModerateMessages() bool {
+	return r == ChatMemberRoleOwner || r == ChatMemberRoleAdmin
+}
+
+type Chat struct {
+	ID        uint64       `json:"id"`
+	Type      ChatType     `json:"type"`
+	Title     *string      `json:"title,omitempty"`
+	Handle    *string      `json:"handle,omitempty"` // Public handle of channel, by which it can be found.
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+	Members   []ChatMember `json:"members"` // Empty for channels, which provide SubscribersCount instead.
+
+	// MessageTTL is a lifetime of messages, which are sent to chat after it was set.
+	MessageTTL MessageTTL `json:"messageTtl"`
+
+	// UnreadCount is a number of unread messages of User, who requested chat.
+	UnreadCount uint64 `json:"unreadCount"`
+
+	// UnreadThreadsCount is a number of threads with unread replies, which User, who requested chat, joined.
+	UnreadThreadsCount uint64 `json:"unreadThreadsCount"`
+
+	// SubscribersCount is a number of members of channel. Always zero for other chats.
+	SubscribersCount uint64 `json:"subscribersCount"`
+
+	// Draft is unsent message of User, who requested chat.
+	Draft *ChatDraft `json:"draft,omitempty"`
+}
+
+// GetMember returns member of the chat by User ID or nil, if User is not a member of the chat.
+func (c *Chat) GetMember(userID uint64) *ChatMember {
+	for i := range c.Members {
+		if c.Members[i].UserID == userID {
+			return &c.Members[i]
+		}
+	}
+
+	return nil
+}
+
+// MessageExpiresAt returns time, when message of chat, which is sent at provided time, should be deleted, or
+// nil, if messages of chat are not deleted automatically.
+func (c *Chat) MessageExpiresAt(sentAt time.Time) *time.Time {
+	if c.MessageTTL == MessageTTLOff {
+		return nil
+	}
+
+	expiresAt := sentAt.Add(time.Duration(c.MessageTTL) * time.Second)
+
+	return &expiresAt
+}
+
+type ChatMember struct {
+	ID                uint64         `json:"id"`
+	ChatID            uint64         `json:"chatId"`
+	UserID            uint64         `json:"userId"`
+	Role              ChatMemberRole `json:"role"`
+	LastReadMessageID *uint64        `json:"lastReadMessageId,omitempty"`
+	UnreadCount       uint64         `json:"unreadCount"`
+	CreatedAt         time.Time      `json:"createdAt"`
+	UpdatedAt         time.Time      `json:"updatedAt"`
+}
+
+type RawCreateGroupChatDTO struct {
+	AccessToken string   `json:"accessToken"`
+	Title       string   `json:"title"`
+	MemberIDs   []uint64 `json:"memberIds"`
+}
+
+type CreateGroupChatDTO struct {
+	OwnerID   uint64   `json:"ownerId"`
+	Title     string   `json:"title"`
+	MemberIDs []uint64 `json:"memberIds"`
+}
+
+type RawCreateChannelDTO struct {
+	AccessToken string  `json:"accessToken"`
+	Title       string  `json:"title"`
+	Handle      *string `json:"handle,omitempty"`
+}
+
+type CreateChannelDTO struct {
+	OwnerID uint64  `json:"ownerId"`
+	Title   string  `json:"title"`
+	Handle  *string `json:"handle,omitempty"`
+}
+
+// ChannelsFilters filters public channels. Channels without handle are never found.
+type ChannelsFilters struct {
+	Handle *string `json:"handle,omitempty"`
+}
+
+// AddChatMembersDTO contains data for adding members to group chat on behalf of ActorID.
+type AddChatMembersDTO struct {
+	ChatID       uint64   `json:"chatId"`
+	ActorID      uint64   `json:"actorId"`
+	UserIDs      []uint64 `json:"userIds"`
+	MembersLimit int      `json:"membersLimit"`
+}
+
+// ChangeChatMemberDTO contains data for changing member UserID of group chat on behalf of ActorID.
+type ChangeChatMemberDTO struct {
+	ChatID  uint64 `json:"chatId"`
+	ActorID uint64 `json:"actorId"`
+	UserID  uint64 `json:"userId"`
+}
+
+// ChangeChatMemberRoleDTO contains data for changing role of member UserID on behalf of ActorID.
+type ChangeChatMemberRoleDTO struct {
+	ChangeChatMemberDTO
+	Role ChatMemberRole `json:"role"`
+}
+
+// ChatInvite lets Users join group chat by secret Token. Invite stops working after ExpiresAt, after MaxUses
+// joins or after revocation. Nil ExpiresAt and MaxUses mean no such limits.
+type ChatInvite struct {
+	ID        uint64     `json:"id"`
+	ChatID    uint64     `json:"chatId"`
+	Token     string     `json:"token"`
+	CreatedBy uint64     `json:"createdBy"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	MaxUses   *uint64    `json:"maxUses,omitempty"`
+	UsesCount uint64     `json:"usesCount"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type RawCreateChatInviteDTO struct {
+	AccessToken string     `json:"accessToken"`
+	ChatID      uint64     `json:"chatId"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	MaxUses     *uint64    `json:"maxUses,omitempty"`
+}
+
+// CreateChatInviteDTO contains data for creating invite link to group chat on behalf of ActorID.
+type CreateChatInviteDTO struct {
+	ChatID    uint64     `json:"chatId"`
+	ActorID   uint64     `json:"actorId"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	MaxUses   *uint64    `json:"maxUses,omitempty"`
+}
+
+// RevokeChatInviteDTO contains data for revoking invite link to group chat on behalf of ActorID.
+type RevokeChatInviteDTO struct {
+	ChatID   uint64 `json:"chatId"`
+	ActorID  uint64 `json:"actorId"`
+	InviteID uint64 `json:"inviteId"`
+}
+
+// JoinChatByInviteDTO contains data for joining group chat by invite Token.
+type JoinChatByInviteDTO struct {
+	Token        string `json:"token"`
+	UserID       uint64 `json:"userId"`
+	MembersLimit int    `json:"membersLimit"`
+}
+
+// ChatDraft is unsent text of message, which User typed in chat. User has at most one draft in each chat, which
+// is synchronized between his sessions.
+type ChatDraft struct {
+	ID        uint64    `json:"id"`
+	ChatID    uint64    `json:"chatId"`
+	UserID    uint64    `json:"userId"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type RawSaveChatDraftDTO struct {
+	AccessToken string `json:"accessToken"`
+	ChatID      uint64 `json:"chatId"`
+	Text        string `json:"text"`
+}
+
+// SaveChatDraftDTO contains data for saving draft of User in chat. Previous draft is replaced.
+type SaveChatDraftDTO struct {
+	ChatID uint64 `json:"chatId"`
+	UserID uint64 `json:"userId"`
+	Text   string `json:"text"`
+}
+
+// ChatDraftDeletion notifies other sessions of User, that his draft in chat was deleted.
+type ChatDraftDeletion struct {
+	ChatID uint64 `json:"chatId"`
+}
+
+// ReadReceipt notifies chat members, that User has read messages up to LastReadMessageID.
+type ReadReceipt struct {
+	ChatID            uint64 `json:"chatId"`
+	UserID            uint64 `json:"userId"`
+	LastReadMessageID uint64 `json:"lastReadMessageId"`
+}
+
+// TypingIndicator notifies chat members, that User is typing message. Indicator should be hidden after
+// ExpiresAt, unless it was prolonged by next one.
+type TypingIndicator struct {
+	ChatID    uint64    `json:"chatId"`
+	UserID    uint64    `json:"userId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,42 @@
+package domains
+
+type EventType string
+
+const (
+	EventTypeMessageCreated  EventType = "message.created"
+	EventTypeMessageUpdated  EventType = "message.updated"
+	EventTypeMessageDeleted  EventType = "message.deleted"
+	EventTypeMessagesRead    EventType = "messages.read"
+	EventTypeThreadRead      EventType = "thread.read"
+	EventTypeReactionAdded   EventType = "reaction.added"
+	EventTypeReactionRemoved EventType = "reaction.removed"
+	EventTypeTyping          EventType = "typing"
+	EventTypePresenceChanged EventType = "presence.changed"
+	EventTypeMentionCreated  EventType = "mention.created"
+	EventTypeDraftUpdated    EventType = "draft.updated"
+	EventTypeDraftDeleted    EventType = "draft.deleted"
+
+	// EventTypePollUpdated delivers results of poll to all members of chat without choice of recipient, while
+	// EventTypePollVoted delivers choice of voter to his sessions only, so anonymous votes are not disclosed.
+	EventTypePollUpdated EventType = "poll.updated"
+	EventTypePollVoted   EventType = "poll.voted"
+
+	// EventTypeAttachmentUpdated is published, when preview of attachment is generated or its generation fails.
+	EventTypeAttachmentUpdated EventType = "attachment.updated"
+
+	// EventTypeLinkPreviewReady is published, when metadata of link, which message contains, is fetched.
+	EventTypeLinkPreviewReady EventType = "link_preview.ready"
+)
+
+// Event represents real-time event, which is delivered to connected Users.
+type Event struct {
+	ID         uint64    `json:"id,omitempty"` // Absent for ephemeral events.
+	Type       EventType `json:"type"`
+	ChatID     uint64    `json:"chatId,omitempty"` // Absent for events, which are not related to chat.
+	Payload    any       `json:"payload"`
+	Recipients []uint64  `json:"-"`
+
+	// Ephemeral event makes sense only for Users, who are connected right now,
+	// so it is neither stored nor replayed after reconnection.
+	Ephemeral bool `json:"-"`
+}
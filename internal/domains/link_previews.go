@@ -0,0 +1,50 @@
+package domains
+
+import "time"
+
+// LinkPreviewStatus represents stage of fetching metadata of link.
+type LinkPreviewStatus string
+
+const (
+	LinkPreviewStatusPending    LinkPreviewStatus = "pending"
+	LinkPreviewStatusProcessing LinkPreviewStatus = "processing"
+	LinkPreviewStatusReady      LinkPreviewStatus = "ready"
+	LinkPreviewStatusFailed     LinkPreviewStatus = "failed" // Page could not be fetched or has no metadata
+)
+
+// LinkPreview is a cached OpenGraph or Twitter card metadata of page. Preview is fetched once for each URL
+// and is shared by all messages, which contain the link.
+type LinkPreview struct {
+	ID          uint64            `json:"id"`
+	URL         string            `json:"url"`
+	Status      LinkPreviewStatus `json:"status"`
+	Title       *string           `json:"title,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	SiteName    *string           `json:"siteName,omitempty"`
+	ImageURL    *string           `json:"imageUrl,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// MessageLinkPreview binds preview of link to message, which contains the link.
+type MessageLinkPreview struct {
+	MessageID   uint64      `json:"messageId"`
+	LinkPreview LinkPreview `json:"linkPreview"`
+}
+
+// LinkMetadata contains metadata of page, which is not saved yet.
+type LinkMetadata struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	SiteName    *string `json:"siteName,omitempty"`
+	ImageURL    *string `json:"imageUrl,omitempty"` // Absolute URL of image, resolved against URL of page
+}
+
+type UpdateLinkPreviewDTO struct {
+	LinkPreviewID uint64            `json:"linkPreviewId"`
+	Status        LinkPreviewStatus `json:"status"`
+	Title         *string           `json:"title,omitempty"`
+	Description   *string           `json:"description,omitempty"`
+	SiteName      *string           `json:"siteName,omitempty"`
+	ImageURL      *string           `json:"imageUrl,omitempty"`
+}
@@ -0,0 +1,392 @@
+package domains
+
+import "time"
+
+type MessageType string
+
+const (
+	MessageTypeRegular MessageType = "regular"
+	MessageTypeSystem  MessageType = "system" // Message describes change of chat, made by its author
+	MessageTypePoll    MessageType = "poll"   // Message contains poll, which question is the text of message
+)
+
+type SystemEventType string
+
+const (
+	SystemEventTypeMessagePinned     SystemEventType = "message.pinned"
+	SystemEventTypeMessageUnpinned   SystemEventType = "message.unpinned"
+	SystemEventTypeMessageTTLChanged SystemEventType = "chat.message_ttl_changed"
+)
+
+// SystemEvent describes change of chat, which system message was posted about.
+type SystemEvent struct {
+	Type       SystemEventType `json:"type"`
+	MessageID  *uint64         `json:"messageId,omitempty"`  // Message, which was changed
+	MessageTTL *MessageTTL     `json:"messageTtl,omitempty"` // New lifetime of chat messages
+}
+
+type Message struct {
+	ID                uint64            `json:"id"`
+	ChatID            uint64            `json:"chatId"`
+	AuthorID          uint64            `json:"authorId"`
+	Type              MessageType       `json:"type"`
+	SystemEvent       *SystemEvent      `json:"systemEvent,omitempty"`
+	Text              string            `json:"text"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+	EditedAt          *time.Time        `json:"editedAt,omitempty"`
+	DeletedAt         *time.Time        `json:"deletedAt,omitempty"`        // Message is a tombstone, if it was deleted for everyone
+	ReplyToMessageID  *uint64           `json:"replyToMessageId,omitempty"` // Message, which is quoted by this one
+	ThreadRootID      *uint64           `json:"threadRootId,omitempty"`     // Message is a reply in thread of root message
+	ThreadReplyCount  uint64            `json:"threadReplyCount"`
+	ThreadLastReplyAt *time.Time        `json:"threadLastReplyAt,omitempty"`
+	ExpiresAt         *time.Time        `json:"expiresAt,omitempty"` // Message is deleted automatically after this time
+	Reactions         []MessageReaction `json:"reactions,omitempty"`
+	Attachments       []Attachment      `json:"attachments,omitempty"`
+	Poll              *Poll             `json:"poll,omitempty"`
+	LinkPreview       *LinkPreview      `json:"linkPreview,omitempty"` // Set only when preview is ready
+
+	// Forwarded message keeps attribution to original message. Chat and message of original are provided only
+	// to Users, who are members of the original chat.
+	ForwardedFromMessageID *uint64 `json:"forwardedFromMessageId,omitempty"`
+	ForwardedFromChatID    *uint64 `json:"forwardedFromChatId,omitempty"`
+	ForwardedFromAuthorID  *uint64 `json:"forwardedFromAuthorId,omitempty"`
+
+	// ThreadUnreadCount is a number of unread replies in thread of the message. It is set only if User, who
+	// requested message, participates in the thread.
+	ThreadUnreadCount *uint64 `json:"threadUnreadCount,omitempty"`
+}
+
+type RawCreateMessageDTO struct {
+	AccessToken      string   `json:"accessToken"`
+	ChatID           uint64   `json:"chatId"`
+	Text             string   `json:"text"`
+	ReplyToMessageID *uint64  `json:"replyToMessageId,omitempty"`
+	ThreadRootID     *uint64  `json:"threadRootId,omitempty"`
+	AttachmentIDs    []uint64 `json:"attachmentIds,omitempty"`
+}
+
+// CreateMessageDTO contains data for sending message. If ThreadRootID is provided, message is sent as a
+// reply to thread of root message instead of chat history. AttachmentIDs are IDs of previously uploaded
+// attachments, which are sent together with message. Mentions of Users, who are not members of chat, are
+// ignored. ExpiresAt is set on sending according to lifetime of chat messages.
+type CreateMessageDTO struct {
+	ChatID             uint64         `json:"chatId"`
+	AuthorID           uint64         `json:"authorId"`
+	Type               MessageType    `json:"type"`
+	SystemEvent        *SystemEvent   `json:"systemEvent,omitempty"`
+	Text               string         `json:"text"`
+	ReplyToMessageID   *uint64        `json:"replyToMessageId,omitempty"`
+	ThreadRootID       *uint64        `json:"threadRootId,omitempty"`
+	AttachmentIDs      []uint64       `json:"attachmentIds,omitempty"`
+	MentionedUsernames []string       `json:"mentionedUsernames,omitempty"`
+	MentionAll         bool           `json:"mentionAll"`            // All members are mentioned with @all
+	HereUserIDs        []uint64       `json:"hereUserIds,omitempty"` // Online members, mentioned with @here
+	ExpiresAt          *time.Time     `json:"expiresAt,omitempty"`
+	Poll               *CreatePollDTO `json:"poll,omitempty"`    // Provided only for messages of poll type
+	LinkURL            *string        `json:"linkUrl,omitempty"` // Link, which preview is attached to message
+
+	ForwardedFromMessageID *uint64 `json:"forwardedFromMessageId,omitempty"`
+	ForwardedFromChatID    *uint64 `json:"forwardedFromChatId,omitempty"`
+	ForwardedFromAuthorID  *uint64 `json:"forwardedFromAuthorId,omitempty"`
+}
+
+// IsForwarded reports whether message is a forwarded copy of another message.
+func (m Message) IsForwarded() bool {
+	return m.ForwardedFromAuthorID != nil || m.ForwardedFromChatID != nil || m.ForwardedFromMessageID != nil
+}
+
+// HasMentions reports whether any Users are mentioned in message.
+func (dto CreateMessageDTO) HasMentions() bool {
+	return len(dto.MentionedUsernames) > 0 || dto.MentionAll || len(dto.HereUserIDs) > 0
+}
+
+type RawForwardMessagesDTO struct {
+	AccessToken string   `json:"accessToken"`
+	ChatID      uint64   `json:"chatId"`
+	FromChatID  uint64   `json:"fromChatId"`
+	MessageIDs  []uint64 `json:"messageIds"`
+}
+
+// ForwardMessagesDTO contains data for forwarding messages of FromChatID to ChatID on behalf of ActorID.
+type ForwardMessagesDTO struct {
+	ChatID     uint64   `json:"chatId"`
+	FromChatID uint64   `json:"fromChatId"`
+	MessageIDs []uint64 `json:"messageIds"`
+	ActorID    uint64   `json:"actorId"`
+}
+
+type RawUpdateMessageDTO struct {
+	AccessToken string `json:"accessToken"`
+	ChatID      uint64 `json:"chatId"`
+	MessageID   uint64 `json:"messageId"`
+	Text        string `json:"text"`
+}
+
+// UpdateMessageDTO contains data for editing message on behalf of ActorID. Preview of link replaces previous
+// one, if edited text contains another link.
+type UpdateMessageDTO struct {
+	ID      uint64  `json:"id"`
+	ChatID  uint64  `json:"chatId"`
+	ActorID uint64  `json:"actorId"`
+	Text    string  `json:"text"`
+	LinkURL *string `json:"linkUrl,omitempty"`
+}
+
+type RawDeleteMessageDTO struct {
+	AccessToken string `json:"accessToken"`
+	ChatID      uint64 `json:"chatId"`
+	MessageID   uint64 `json:"messageId"`
+	ForEveryone bool   `json:"forEveryone"`
+}
+
+// DeleteMessageDTO contains data for deleting message on behalf of ActorID. If ForEveryone is false,
+// message is hidden only for ActorID.
+type DeleteMessageDTO struct {
+	ID          uint64 `json:"id"`
+	ChatID      uint64 `json:"chatId"`
+	ActorID     uint64 `json:"actorId"`
+	ForEveryone bool   `json:"forEveryone"`
+}
+
+// MessageDeletion describes deleted message in real-time events.
+type MessageDeletion struct {
+	MessageID   uint64 `json:"messageId"`
+	ChatID      uint64 `json:"chatId"`
+	ForEveryone bool   `json:"forEveryone"`
+	Expired     bool   `json:"expired"` // Message is erased completely instead of turning into tombstone
+}
+
+// MessageReaction represents aggregated reactions with the same emoji on message.
+type MessageReaction struct {
+	MessageID   uint64 `json:"messageId"`
+	Emoji       string `json:"emoji"`
+	Count       uint64 `json:"count"`
+	ReactedByMe bool   `json:"reactedByMe"` // Whether User, who requested message, has left this reaction
+}
+
+type RawMessageReactionDTO struct {
+	AccessToken string `json:"accessToken"`
+	ChatID      uint64 `json:"chatId"`
+	MessageID   uint64 `json:"messageId"`
+	Emoji       string `json:"emoji"`
+}
+
+// MessageReactionDTO contains data for adding or removing reaction of UserID.
+type MessageReactionDTO struct {
+	ChatID    uint64 `json:"chatId"`
+	MessageID uint64 `json:"messageId"`
+	UserID    uint64 `json:"userId"`
+	Emoji     string `json:"emoji"`
+}
+
+// MessageReactionChange describes added or removed reaction in real-time events.
+type MessageReactionChange struct {
+	ChatID    uint64 `json:"chatId"`
+	MessageID uint64 `json:"messageId"`
+	UserID    uint64 `json:"userId"`
+	Emoji     string `json:"emoji"`
+}
+
+// MessagesCursor represents keyset pagination of chat history. Only one of Before and After can be provided.
+// If none of them provided, the latest messages will be returned.
+type MessagesCursor struct {
+	Before *uint64 `json:"before,omitempty"`
+	After  *uint64 `json:"after,omitempty"`
+	Limit  uint64  `json:"limit"`
+}
+
+// MessagesPage represents page of chat history. Messages are ordered from older to newer.
+type MessagesPage struct {
+	Messages []Message `json:"messages"`
+	HasMore  bool      `json:"hasMore"`
+}
+
+// ThreadPage represents page of thread replies along with root message of the thread.
+type ThreadPage struct {
+	Root     Message   `json:"root"`
+	Messages []Message `json:"messages"`
+	HasMore  bool      `json:"hasMore"`
+}
+
+// ThreadParticipant represents User, who joined the thread by writing root message or reply to it.
+type ThreadParticipant struct {
+	ID                uint64    `json:"id"`
+	ThreadRootID      uint64    `json:"threadRootId"`
+	ChatID            uint64    `json:"chatId"`
+	UserID            uint64    `json:"userId"`
+	LastReadMessageID *uint64   `json:"lastReadMessageId,omitempty"`
+	UnreadCount       uint64    `json:"unreadCount"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type RawSearchMessagesDTO struct {
+	AccessToken string     `json:"accessToken"`
+	Query       string     `json:"query"`
+	ChatID      *uint64    `json:"chatId,omitempty"`
+	AuthorID    *uint64    `json:"authorId,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	To          *time.Time `json:"to,omitempty"`
+	Limit       uint64     `json:"limit"`
+	Offset      uint64     `json:"offset"`
+}
+
+// SearchMessagesDTO contains full-text query and filters for search of messages in chats of UserID.
+// Messages are filtered by creation time in range [From, To).
+type SearchMessagesDTO struct {
+	UserID   uint64     `json:"userId"`
+	Query    string     `json:"query"`
+	ChatID   *uint64    `json:"chatId,omitempty"`
+	AuthorID *uint64    `json:"authorId,omitempty"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Limit    uint64     `json:"limit"`
+	Offset   uint64     `json:"offset"`
+}
+
+// MessageSearchResult represents message, found by full-text search. Snippet is HTML escaped fragment of
+// message text with matched words wrapped into <mark> tags.
+type MessageSearchResult struct {
+	Message Message `json:"message"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+// MessagesSearchPage represents page of search results. Results are ordered by relevance.
+type MessagesSearchPage struct {
+	Results []MessageSearchResult `json:"results"`
+	HasMore bool                  `json:"hasMore"`
+}
+
+type MentionType string
+
+const (
+	MentionTypeUser MentionType = "user" // User was mentioned by username
+	MentionTypeAll  MentionType = "all"  // User was mentioned as member of group chat with @all
+	MentionTypeHere MentionType = "here" // User was mentioned as online member of group chat with @here
+)
+
+// Mention represents mention of User in message. User is mentioned in message at most once, personal
+// mention takes precedence over @here and @all. Message is set only in mentions feed and real-time events.
+type Mention struct {
+	ID        uint64      `json:"id"`
+	MessageID uint64      `json:"messageId"`
+	ChatID    uint64      `json:"chatId"`
+	UserID    uint64      `json:"userId"`
+	Type      MentionType `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Message   *Message    `json:"message,omitempty"`
+}
+
+type CreateMentionDTO struct {
+	MessageID uint64      `json:"messageId"`
+	ChatID    uint64      `json:"chatId"`
+	UserID    uint64      `json:"userId"`
+	Type      MentionType `json:"type"`
+}
+
+// MentionsCursor represents keyset pagination of mentions feed. If Before is not provided, the latest
+// mentions will be returned.
+type MentionsCursor struct {
+	Before *uint64 `json:"before,omitempty"`
+	Limit  uint64  `json:"limit"`
+}
+
+// MentionsPage represents page of mentions feed. Mentions are ordered from newer to older.
+type MentionsPage struct {
+	Mentions []Mention `json:"mentions"`
+	HasMore  bool      `json:"hasMore"`
+}
+
+// Pin represents message, pinned in chat. Message is set, when pin is provided along with pinned message.
+type Pin struct {
+	ID        uint64    `json:"id"`
+	ChatID    uint64    `json:"chatId"`
+	MessageID uint64    `json:"messageId"`
+	PinnedBy  uint64    `json:"pinnedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	Message   *Message  `json:"message,omitempty"`
+}
+
+// PinMessageDTO contains data for pinning message on behalf of ActorID. Chat can not have more than
+// PinsLimit pinned messages.
+type PinMessageDTO struct {
+	ChatID    uint64 `json:"chatId"`
+	MessageID uint64 `json:"messageId"`
+	ActorID   uint64 `json:"actorId"`
+	PinsLimit int    `json:"pinsLimit"`
+}
+
+// UnpinMessageDTO contains data for unpinning message on behalf of ActorID.
+type UnpinMessageDTO struct {
+	ChatID    uint64 `json:"chatId"`
+	MessageID uint64 `json:"messageId"`
+	ActorID   uint64 `json:"actorId"`
+}
+
+// ScheduledMessage is message, which will be sent to chat history on behalf of author at SendAt. FailedAt is
+// set, if message could not be sent, for example, because author left the chat. Failed message is not sent
+// again until author edits it.
+type ScheduledMessage struct {
+	ID               uint64     `json:"id"`
+	ChatID           uint64     `json:"chatId"`
+	AuthorID         uint64     `json:"authorId"`
+	Text             string     `json:"text"`
+	ReplyToMessageID *uint64    `json:"replyToMessageId,omitempty"`
+	SendAt           time.Time  `json:"sendAt"`
+	FailedAt         *time.Time `json:"failedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+type RawScheduleMessageDTO struct {
+	AccessToken      string    `json:"accessToken"`
+	ChatID           uint64    `json:"chatId"`
+	Text             string    `json:"text"`
+	ReplyToMessageID *uint64   `json:"replyToMessageId,omitempty"`
+	SendAt           time.Time `json:"sendAt"`
+}
+
+// ScheduleMessageDTO contains data for scheduling message. Author can not have more than Limit pending
+// scheduled messages in one chat.
+type ScheduleMessageDTO struct {
+	ChatID           uint64    `json:"chatId"`
+	AuthorID         uint64    `json:"authorId"`
+	Text             string    `json:"text"`
+	ReplyToMessageID *uint64   `json:"replyToMessageId,omitempty"`
+	SendAt           time.Time `json:"sendAt"`
+	Limit            int       `json:"limit"`
+}
+
+type RawUpdateScheduledMessageDTO struct {
+	AccessToken        string    `json:"accessToken"`
+	ChatID             uint64    `json:"chatId"`
+	ScheduledMessageID uint64    `json:"scheduledMessageId"`
+	Text               string    `json:"text"`
+	SendAt             time.Time `json:"sendAt"`
+}
+
+// UpdateScheduledMessageDTO contains data for editing scheduled message on behalf of ActorID.
+type UpdateScheduledMessageDTO struct {
+	ID      uint64    `json:"id"`
+	ChatID  uint64    `json:"chatId"`
+	ActorID uint64    `json:"actorId"`
+	Text    string    `json:"text"`
+	SendAt  time.Time `json:"sendAt"`
+}
+
+// SetChatMessageTTLDTO contains data for changing lifetime of chat messages on behalf of ActorID.
+type SetChatMessageTTLDTO struct {
+	ChatID     uint64     `json:"chatId"`
+	ActorID    uint64     `json:"actorId"`
+	MessageTTL MessageTTL `json:"messageTtl"`
+}
+
+// MessagesPurge describes expired messages, which were deleted, together with replies in their threads.
+// StorageKeys are keys of attachments content, which is no longer referenced by any attachment and should be
+// deleted from blob storage.
+type MessagesPurge struct {
+	Messages    []Message `json:"messages"`
+	StorageKeys []string  `json:"storageKeys"`
+}
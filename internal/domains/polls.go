@@ -0,0 +1,119 @@
+package domains
+
+import "time"
+
+// Poll represents question of group chat member with options, which other members vote for. Poll is sent
+// as message of poll type. Votes of anonymous poll are counted, but voters are not disclosed.
+type Poll struct {
+	ID             uint64       `json:"id"`
+	MessageID      uint64       `json:"messageId"`
+	Question       string       `json:"question"`
+	MultipleChoice bool         `json:"multipleChoice"`
+	Anonymous      bool         `json:"anonymous"`
+	ClosesAt       *time.Time   `json:"closesAt,omitempty"` // Votes are not accepted after this time
+	CreatedAt      time.Time    `json:"createdAt"`
+	VotersCount    uint64       `json:"votersCount"`
+	Options        []PollOption `json:"options"`
+}
+
+// IsClosed reports, whether poll does not accept votes at the provided moment.
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosesAt != nil && !now.Before(*p.ClosesAt)
+}
+
+// GetOption returns option of poll by its ID or nil, if poll has no such option.
+func (p *Poll) GetOption(optionID uint64) *PollOption {
+	for i := range p.Options {
+		if p.Options[i].ID == optionID {
+			return &p.Options[i]
+		}
+	}
+
+	return nil
+}
+
+// VotedOptionIDs returns IDs of options, which User, who requested poll, voted for.
+func (p *Poll) VotedOptionIDs() []uint64 {
+	optionIDs := make([]uint64, 0, len(p.Options))
+	for _, option := range p.Options {
+		if option.VotedByMe {
+			optionIDs = append(optionIDs, option.ID)
+		}
+	}
+
+	return optionIDs
+}
+
+// Results returns copy of poll without choice of User, who requested poll, so it can be shown to anyone.
+func (p *Poll) Results() Poll {
+	results := *p
+	results.Options = make([]PollOption, len(p.Options))
+
+	for i, option := range p.Options {
+		option.VotedByMe = false
+		results.Options[i] = option
+	}
+
+	return results
+}
+
+// PollOption represents answer of poll along with aggregated votes for it.
+type PollOption struct {
+	ID         uint64   `json:"id"`
+	PollID     uint64   `json:"pollId"`
+	Text       string   `json:"text"`
+	VotesCount uint64   `json:"votesCount"`
+	VotedByMe  bool     `json:"votedByMe"`          // Whether User, who requested poll, has voted for this option
+	VoterIDs   []uint64 `json:"voterIds,omitempty"` // Provided only for public polls
+}
+
+// PollVote represents vote of User for option of poll.
+type PollVote struct {
+	PollID   uint64 `json:"pollId"`
+	OptionID uint64 `json:"optionId"`
+	UserID   uint64 `json:"userId"`
+}
+
+type RawCreatePollDTO struct {
+	AccessToken    string     `json:"accessToken"`
+	ChatID         uint64     `json:"chatId"`
+	Question       string     `json:"question"`
+	Options        []string   `json:"options"`
+	MultipleChoice bool       `json:"multipleChoice"`
+	Anonymous      bool       `json:"anonymous"`
+	ClosesAt       *time.Time `json:"closesAt,omitempty"`
+}
+
+// CreatePollDTO contains data of poll, which is created along with message of poll type.
+type CreatePollDTO struct {
+	Question       string     `json:"question"`
+	Options        []string   `json:"options"`
+	MultipleChoice bool       `json:"multipleChoice"`
+	Anonymous      bool       `json:"anonymous"`
+	ClosesAt       *time.Time `json:"closesAt,omitempty"`
+}
+
+type RawPollVoteDTO struct {
+	AccessToken string   `json:"accessToken"`
+	ChatID      uint64   `json:"chatId"`
+	MessageID   uint64   `json:"messageId"`
+	OptionIDs   []uint64 `json:"optionIds,omitempty"`
+}
+
+// PollVoteDTO contains data for voting of UserID in poll of message. OptionIDs are not provided, when vote
+// is retracted.
+type PollVoteDTO struct {
+	ChatID    uint64   `json:"chatId"`
+	MessageID uint64   `json:"messageId"`
+	UserID    uint64   `json:"userId"`
+	OptionIDs []uint64 `json:"optionIds,omitempty"`
+}
+
+// PollVoteChange describes choice of User in poll in real-time events, which are delivered only to sessions
+// of the User. OptionIDs are empty, if vote was retracted.
+type PollVoteChange struct {
+	ChatID    uint64   `json:"chatId"`
+	MessageID uint64   `json:"messageId"`
+	PollID    uint64   `json:"pollId"`
+	OptionIDs []uint64 `json:"optionIds"`
+}
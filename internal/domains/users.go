@@ -12,6 +12,21 @@ type User struct {
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
+// UserWithPresence is User with its online status. Presence is not stored in users table, so it is kept out of
+// User, which is scanned from users table rows.
+type UserWithPresence struct {
+	User
+	Presence Presence `json:"presence"`
+}
+
+// Presence represents online status of User. User is online, while he holds at least one real-time
+// connection.
+type Presence struct {
+	UserID     uint64     `json:"userId"`
+	Online     bool       `json:"online"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"` // Nil, if User has never been online.
+}
+
 type RawUpdateUserDTO struct {
 	AccessToken string `json:"accessToken"`
 	Username    string `json:"username"`
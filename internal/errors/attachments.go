@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrInvalidAttachment        = errors.New("attachment can not be sent with message")
+	ErrInvalidImage             = errors.New("image can not be processed")
+)
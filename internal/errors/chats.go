@@ -0,0 +1,16 @@
+package errors
+
+import "errors"
+
+var (
+	ErrChatNotFound             = errors.New("chat not found")
+	ErrChatMemberNotFound       = errors.New("chat member not found")
+	ErrNotChatMember            = errors.New("user is not a member of the chat")
+	ErrNotGroupChat             = errors.New("operation is allowed only in group chats and channels")
+	ErrChatPermissionDenied     = errors.New("user has not enough rights in the chat")
+	ErrChatMembersLimitExceeded = errors.New("chat members limit exceeded")
+	ErrChatInviteNotFound       = errors.New("chat invite not found")
+	ErrChatInviteExpired        = errors.New("chat invite is expired, revoked or used up")
+	ErrChannelHandleTaken       = errors.New("channel handle is already taken")
+	ErrChatDraftNotFound        = errors.New("chat draft not found")
+)
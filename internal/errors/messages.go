@@ -0,0 +1,24 @@
+package errors
+
+import "errors"
+
+var (
+	ErrMessageNotFound         = errors.New("message not found")
+	ErrMessagePermissionDenied = errors.New("user has not enough rights to change the message")
+	ErrInvalidReply            = errors.New("message can not be replied this way")
+	ErrInvalidForward          = errors.New("message can not be forwarded")
+	ErrNotThreadParticipant    = errors.New("user is not a participant of the thread")
+	ErrMessageAlreadyPinned    = errors.New("message is already pinned")
+	ErrMessageNotPinned        = errors.New("message is not pinned")
+	ErrPinsLimitExceeded       = errors.New("chat pins limit exceeded")
+
+	ErrPollNotFound     = errors.New("poll not found")
+	ErrPollClosed       = errors.New("poll is closed")
+	ErrPollAlreadyVoted = errors.New("user has already voted in the poll")
+
+	ErrLinkPreviewUnavailable = errors.New("link preview is unavailable")
+	ErrForbiddenLinkAddress   = errors.New("link leads to forbidden network address")
+
+	ErrScheduledMessageNotFound       = errors.New("scheduled message not found")
+	ErrScheduledMessagesLimitExceeded = errors.New("scheduled messages limit exceeded")
+)
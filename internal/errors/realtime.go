@@ -0,0 +1,5 @@
+package errors
+
+import "errors"
+
+var ErrRealtimeHubClosed = errors.New("real-time hub closed")
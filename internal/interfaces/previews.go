@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+//go:generate mockgen -source=previews.go -destination=../../mocks/previews/image_preview_generator.go -package=mockpreviews -exclude_interfaces=LinkPreviewFetcher
+type ImagePreviewGenerator interface {
+	// Supports reports, whether preview can be generated for content of provided MIME type.
+	Supports(mimeType string) bool
+
+	// Generate decodes image and prepares its thumbnails. Content, which can not be decoded as image,
+	// leads to ErrInvalidImage.
+	Generate(content io.Reader) (*domains.ImagePreview, error)
+}
+
+type LinkPreviewFetcher interface {
+	// Fetch loads page by provided URL and extracts its OpenGraph and Twitter card metadata. Page, which can
+	// not be loaded or has no metadata, leads to ErrLinkPreviewUnavailable. Page on private network address
+	// leads to ErrForbiddenLinkAddress.
+	Fetch(ctx context.Context, url string) (*domains.LinkMetadata, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+//go:generate mockgen -source=realtime.go -destination=../../mocks/realtime/events_publisher.go -package=mockrealtime -exclude_interfaces=
+type EventsPublisher interface {
+	// Publish delivers event to all connected recipients. Delivery is best-effort, so no error returned.
+	Publish(ctx context.Context, event domains.Event)
+}
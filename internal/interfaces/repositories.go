@@ -7,13 +7,13 @@ import (
 	"github.com/DKhorkov/kfc/internal/domains"
 )
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/emails_repository.go -package=mockrepositories -exclude_interfaces=AuthRepository,UsersRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/emails_repository.go -package=mockrepositories -exclude_interfaces=AuthRepository,UsersRepository,ChatsRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
 type EmailsRepository interface {
 	SendVerifyEmailMessage(ctx context.Context, user domains.User) error
 	SendForgetPasswordMessage(ctx context.Context, user domains.User) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/users_repository.go -package=mockrepositories -exclude_interfaces=AuthRepository,EmailsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/users_repository.go -package=mockrepositories -exclude_interfaces=AuthRepository,EmailsRepository,ChatsRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
 type UsersRepository interface {
 	GetUserByID(ctx context.Context, id uint64) (*domains.User, error)
 	GetUsers(
@@ -26,7 +26,7 @@ type UsersRepository interface {
 	UpdateUser(ctx context.Context, userProfileData domains.UpdateUserDTO) error
 }
 
-//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/auth_repository.go -package=mockrepositories -exclude_interfaces=UsersRepository,EmailsRepository
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/auth_repository.go -package=mockrepositories -exclude_interfaces=UsersRepository,EmailsRepository,ChatsRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
 type AuthRepository interface {
 	RegisterUser(ctx context.Context, userData domains.RegisterDTO) (userID uint64, err error)
 	CreateRefreshToken(
@@ -40,3 +40,207 @@ type AuthRepository interface {
 	VerifyEmail(ctx context.Context, userID uint64) error
 	ChangePassword(ctx context.Context, userID uint64, newPassword string) error
 }
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/chats_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
+type ChatsRepository interface {
+	GetChatByID(ctx context.Context, id uint64) (*domains.Chat, error)
+	GetDirectChat(ctx context.Context, firstUserID, secondUserID uint64) (*domains.Chat, error)
+	CreateDirectChat(ctx context.Context, firstUserID, secondUserID uint64) (chatID uint64, err error)
+	GetChatByIDForUpdate(ctx context.Context, id uint64) (*domains.Chat, error)
+	CreateGroupChat(ctx context.Context, title string) (chatID uint64, err error)
+	CreateChannel(ctx context.Context, title string, handle *string) (chatID uint64, err error)
+	GetChannels(
+		ctx context.Context,
+		filters *domains.ChannelsFilters,
+		pagination *domains.Pagination,
+	) ([]domains.Chat, error)
+	DeleteChat(ctx context.Context, id uint64) error
+	AddChatMembers(
+		ctx context.Context,
+		chatID uint64,
+		role domains.ChatMemberRole,
+		userIDs ...uint64,
+	) error
+	GetUserChats(ctx context.Context, userID uint64) ([]domains.Chat, error)
+	GetChatsMembers(ctx context.Context, chatIDs []uint64) ([]domains.ChatMember, error)
+	GetChatMembersIDs(ctx context.Context, chatID uint64) ([]uint64, error)
+	GetChatsMembersCounts(ctx context.Context, chatIDs []uint64) (map[uint64]uint64, error)
+	GetChatMember(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error)
+	GetChatMemberForUpdate(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error)
+	IncrementUnreadCounters(ctx context.Context, chatID, exceptUserID, delta uint64) error
+	UpdateLastReadMessage(ctx context.Context, chatID, userID, messageID uint64) error
+	RecountUnreadCounters(ctx context.Context, chatID, fromMessageID uint64) error
+	UpdateChatMemberRole(
+		ctx context.Context,
+		chatID, userID uint64,
+		role domains.ChatMemberRole,
+	) error
+	DeleteChatMember(ctx context.Context, chatID, userID uint64) error
+	TouchChat(ctx context.Context, chatID uint64) error
+	UpdateChatMessageTTL(ctx context.Context, chatID uint64, messageTTL domains.MessageTTL) error
+	GetUnreadThreadsCounts(ctx context.Context, userID uint64, chatIDs []uint64) (map[uint64]uint64, error)
+	GetChannelsUnreadCounts(ctx context.Context, userID uint64, chatIDs []uint64) (map[uint64]uint64, error)
+	DeleteThreadParticipants(ctx context.Context, chatID, userID uint64) error
+	CreateChatInvite(
+		ctx context.Context,
+		inviteData domains.CreateChatInviteDTO,
+		token string,
+	) (inviteID uint64, err error)
+	GetChatInviteByID(ctx context.Context, id uint64) (*domains.ChatInvite, error)
+	GetChatInviteByToken(ctx context.Context, token string) (*domains.ChatInvite, error)
+	GetChatInvites(ctx context.Context, chatID uint64) ([]domains.ChatInvite, error)
+	RevokeChatInvite(ctx context.Context, id uint64) error
+	IncrementChatInviteUses(ctx context.Context, id uint64) error
+	CreateChatInviteUse(ctx context.Context, inviteID, userID uint64) error
+	SaveChatDraft(ctx context.Context, draftData domains.SaveChatDraftDTO) (draftID uint64, err error)
+	GetChatDraft(ctx context.Context, chatID, userID uint64) (*domains.ChatDraft, error)
+	GetUserChatDrafts(ctx context.Context, userID uint64, chatIDs []uint64) ([]domains.ChatDraft, error)
+	DeleteChatDraft(ctx context.Context, chatID, userID uint64) (deleted bool, err error)
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/messages_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,PresenceRepository,AttachmentsRepository
+type MessagesRepository interface {
+	CreateMessage(ctx context.Context, messageData domains.CreateMessageDTO) (messageID uint64, err error)
+	GetMessageByID(ctx context.Context, id uint64) (*domains.Message, error)
+	GetMessageByIDForUpdate(ctx context.Context, id uint64) (*domains.Message, error)
+	GetChatMessagesByIDs(ctx context.Context, chatID, userID uint64, ids []uint64) ([]domains.Message, error)
+	GetChatMessages(
+		ctx context.Context,
+		chatID, userID uint64,
+		cursor domains.MessagesCursor,
+	) ([]domains.Message, error)
+	GetThreadMessages(
+		ctx context.Context,
+		threadRootID, userID uint64,
+		cursor domains.MessagesCursor,
+	) ([]domains.Message, error)
+	UpdateMessageText(ctx context.Context, id uint64, text string) error
+	DeleteMessage(ctx context.Context, id uint64) error
+	CreateMessageRevision(ctx context.Context, messageID uint64, text string) error
+	DeleteMessageRevisions(ctx context.Context, messageID uint64) error
+	HideMessage(ctx context.Context, messageID, userID uint64) error
+	AddMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (added bool, err error)
+	DeleteMessageReaction(
+		ctx context.Context,
+		reactionData domains.MessageReactionDTO,
+	) (deleted bool, err error)
+	DeleteMessageReactions(ctx context.Context, messageID uint64) error
+	GetMessagesReactions(
+		ctx context.Context,
+		messageIDs []uint64,
+		userID uint64,
+	) ([]domains.MessageReaction, error)
+	AddThreadReply(ctx context.Context, threadRootID uint64, repliedAt time.Time) error
+	AddThreadParticipants(ctx context.Context, chatID, threadRootID uint64, userIDs ...uint64) error
+	GetThreadParticipant(ctx context.Context, threadRootID, userID uint64) (*domains.ThreadParticipant, error)
+	GetThreadParticipantForUpdate(
+		ctx context.Context,
+		threadRootID, userID uint64,
+	) (*domains.ThreadParticipant, error)
+	GetUserThreadParticipants(
+		ctx context.Context,
+		threadRootIDs []uint64,
+		userID uint64,
+	) ([]domains.ThreadParticipant, error)
+	IncrementThreadUnreadCounters(ctx context.Context, threadRootID, exceptUserID uint64) error
+	UpdateThreadLastReadMessage(ctx context.Context, threadRootID, userID, messageID uint64) error
+	RecountThreadUnreadCounters(ctx context.Context, threadRootIDs []uint64) error
+	SearchMessages(
+		ctx context.Context,
+		searchData domains.SearchMessagesDTO,
+	) ([]domains.MessageSearchResult, error)
+	CreateMentions(ctx context.Context, mentions ...domains.CreateMentionDTO) error
+	GetMessageMentions(ctx context.Context, messageID uint64) ([]domains.Mention, error)
+	GetUserMentions(
+		ctx context.Context,
+		userID uint64,
+		cursor domains.MentionsCursor,
+	) ([]domains.Mention, error)
+	CreatePin(ctx context.Context, chatID, messageID, pinnedBy uint64) (added bool, err error)
+	GetMessagePin(ctx context.Context, messageID uint64) (*domains.Pin, error)
+	DeletePin(ctx context.Context, messageID uint64) (deleted bool, err error)
+	CountChatPins(ctx context.Context, chatID uint64) (int, error)
+	GetChatPins(ctx context.Context, chatID, userID uint64) ([]domains.Pin, error)
+	CreateScheduledMessage(ctx context.Context, messageData domains.ScheduleMessageDTO) (id uint64, err error)
+	GetScheduledMessageByID(ctx context.Context, id uint64) (*domains.ScheduledMessage, error)
+
+	// LockPendingScheduledMessage locks not failed scheduled message without waiting for other transactions.
+	// sql.ErrNoRows is returned, if message is locked by another transaction.
+	LockPendingScheduledMessage(ctx context.Context, id uint64) (*domains.ScheduledMessage, error)
+	GetUserScheduledMessages(ctx context.Context, chatID, authorID uint64) ([]domains.ScheduledMessage, error)
+	GetDueScheduledMessages(ctx context.Context, now time.Time, limit uint64) ([]domains.ScheduledMessage, error)
+	CountUserScheduledMessages(ctx context.Context, chatID, authorID uint64) (int, error)
+	UpdateScheduledMessage(ctx context.Context, id uint64, text string, sendAt time.Time) error
+
+	// FailScheduledMessage marks scheduled message as failed, if it was not updated after updatedAt.
+	FailScheduledMessage(ctx context.Context, id uint64, updatedAt time.Time) error
+	DeleteScheduledMessage(ctx context.Context, id uint64) error
+
+	// LockExpiredMessages locks batch of expired messages, skipping ones locked by other transactions, and
+	// returns them along with locked replies in their threads.
+	LockExpiredMessages(ctx context.Context, expiredBefore time.Time, limit uint64) ([]domains.Message, error)
+	DeleteMessages(ctx context.Context, ids []uint64) error
+	RecountThreadReplies(ctx context.Context, threadRootIDs []uint64) error
+	CreatePoll(ctx context.Context, messageID uint64, pollData domains.CreatePollDTO) (pollID uint64, err error)
+	GetMessagesPolls(ctx context.Context, messageIDs []uint64) ([]domains.Poll, error)
+	GetPollsOptions(ctx context.Context, pollIDs []uint64, userID uint64) ([]domains.PollOption, error)
+	GetPollsVotes(ctx context.Context, pollIDs []uint64) ([]domains.PollVote, error)
+	CreatePollVotes(ctx context.Context, pollID, userID uint64, optionIDs []uint64) error
+	DeletePollVotes(ctx context.Context, pollID, userID uint64) (deleted bool, err error)
+	DeleteMessagePoll(ctx context.Context, messageID uint64) error
+	SetMessageLinkPreview(ctx context.Context, messageID uint64, url string) error
+	CopyMessageLinkPreview(ctx context.Context, messageID, copyID uint64) error
+	DeleteMessageLinkPreview(ctx context.Context, messageID uint64) error
+	GetMessagesLinkPreviews(ctx context.Context, messageIDs []uint64) ([]domains.MessageLinkPreview, error)
+	GetLinkPreviewMessages(ctx context.Context, linkPreviewID uint64) ([]domains.Message, error)
+
+	// ClaimLinkPreviews takes pending previews for fetching of metadata. Previews, which processing was
+	// started before claimedBefore, are considered abandoned and are claimed again.
+	ClaimLinkPreviews(ctx context.Context, limit uint64, claimedBefore time.Time) ([]domains.LinkPreview, error)
+	UpdateLinkPreview(ctx context.Context, previewData domains.UpdateLinkPreviewDTO) (updated bool, err error)
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/attachments_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,MessagesRepository,PresenceRepository
+type AttachmentsRepository interface {
+	CreateAttachment(ctx context.Context, attachmentData domains.CreateAttachmentDTO) (attachmentID uint64, err error)
+	GetAttachmentByID(ctx context.Context, id uint64) (*domains.Attachment, error)
+	GetAttachmentsByIDsForUpdate(ctx context.Context, ids []uint64) ([]domains.Attachment, error)
+	SetAttachmentsMessage(ctx context.Context, messageID uint64, attachmentIDs []uint64) error
+	GetMessagesAttachments(ctx context.Context, messageIDs []uint64) ([]domains.Attachment, error)
+
+	// ClaimAttachmentsForPreview takes pending attachments for preview generation. Attachments, which
+	// processing was started before claimedBefore, are considered abandoned and are claimed again.
+	ClaimAttachmentsForPreview(
+		ctx context.Context,
+		limit uint64,
+		claimedBefore time.Time,
+	) ([]domains.Attachment, error)
+	UpdateAttachmentPreview(ctx context.Context, previewData domains.UpdateAttachmentPreviewDTO) error
+	CreateAttachmentThumbnails(ctx context.Context, thumbnails ...domains.CreateAttachmentThumbnailDTO) error
+	GetAttachmentsThumbnails(ctx context.Context, attachmentIDs []uint64) ([]domains.AttachmentThumbnail, error)
+
+	// CopyAttachment creates copy of attachment, which is sent with provided message of provided chat. Content
+	// of attachment is not copied, so copy refers to the same content in storage.
+	CopyAttachment(ctx context.Context, attachmentID, chatID, messageID uint64) (copyID uint64, err error)
+	CopyAttachmentThumbnails(ctx context.Context, attachmentID, copyID uint64) error
+
+	// GetUsedStorageKeys returns those of provided storage keys, which are referenced by attachments or their
+	// thumbnails.
+	GetUsedStorageKeys(ctx context.Context, storageKeys []string) ([]string, error)
+}
+
+//go:generate mockgen -source=repositories.go -destination=../../mocks/repositories/presence_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,MessagesRepository,AttachmentsRepository
+type PresenceRepository interface {
+	// AddConnection registers real-time connection of User and returns number of his connections.
+	// Connections are considered alive during ttl, unless they are prolonged.
+	AddConnection(ctx context.Context, userID uint64, ttl time.Duration) (connections int64, err error)
+	ProlongConnections(ctx context.Context, userID uint64, ttl time.Duration, lastSeenAt time.Time) error
+
+	// RemoveConnection unregisters real-time connection of User and returns number of his left connections.
+	// Last seen time of User is updated, when there are no connections left.
+	RemoveConnection(ctx context.Context, userID uint64, lastSeenAt time.Time) (connections int64, err error)
+	GetPresence(ctx context.Context, userID uint64) (*domains.Presence, error)
+
+	// GetPresences returns presences of provided Users in order of their IDs at once.
+	GetPresences(ctx context.Context, userIDs []uint64) ([]domains.Presence, error)
+}
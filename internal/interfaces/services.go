@@ -7,7 +7,7 @@ import (
 	"github.com/DKhorkov/kfc/internal/domains"
 )
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/users_service.go -package=mockservices -exclude_interfaces=AuthService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/users_service.go -package=mockservices -exclude_interfaces=AuthService,ChatsService,MessagesService,PresenceService,AttachmentsService
 type UsersService interface {
 	GetUserByID(ctx context.Context, id uint64) (*domains.User, error)
 	GetUsers(
@@ -20,7 +20,7 @@ type UsersService interface {
 	UpdateUser(ctx context.Context, userProfileData domains.UpdateUserDTO) (*domains.User, error)
 }
 
-//go:generate mockgen -source=services.go -destination=../../mocks/services/auth_service.go -package=mockservices -exclude_interfaces=UsersService
+//go:generate mockgen -source=services.go -destination=../../mocks/services/auth_service.go -package=mockservices -exclude_interfaces=UsersService,ChatsService,MessagesService,PresenceService,AttachmentsService
 type AuthService interface {
 	RegisterUser(ctx context.Context, userData domains.RegisterDTO) (*domains.User, error)
 	CreateRefreshToken(
@@ -37,3 +37,169 @@ type AuthService interface {
 	SendForgetPasswordMessage(ctx context.Context, email string) error
 	SendVerifyEmailMessage(ctx context.Context, email string) error
 }
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/chats_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,MessagesService,PresenceService,AttachmentsService
+type ChatsService interface {
+	GetOrCreateDirectChat(ctx context.Context, userID, interlocutorID uint64) (*domains.Chat, error)
+	CreateGroupChat(ctx context.Context, chatData domains.CreateGroupChatDTO) (*domains.Chat, error)
+	CreateChannel(ctx context.Context, channelData domains.CreateChannelDTO) (*domains.Chat, error)
+	GetChannels(
+		ctx context.Context,
+		filters *domains.ChannelsFilters,
+		pagination *domains.Pagination,
+	) ([]domains.Chat, error)
+	SubscribeToChannel(ctx context.Context, chatID, userID uint64) (*domains.Chat, error)
+	AddGroupChatMembers(
+		ctx context.Context,
+		membersData domains.AddChatMembersDTO,
+	) (*domains.Chat, error)
+	RemoveGroupChatMember(
+		ctx context.Context,
+		memberData domains.ChangeChatMemberDTO,
+	) (*domains.Chat, error)
+	ChangeGroupChatMemberRole(
+		ctx context.Context,
+		roleData domains.ChangeChatMemberRoleDTO,
+	) (*domains.Chat, error)
+	LeaveGroupChat(ctx context.Context, chatID, userID uint64) error
+	GetUserChats(ctx context.Context, userID uint64) ([]domains.Chat, error)
+	GetChatByID(ctx context.Context, id uint64) (*domains.Chat, error)
+	GetChatMember(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error)
+	GetChatMembersIDs(ctx context.Context, chatID uint64) ([]uint64, error)
+	GetUnreadThreadsCount(ctx context.Context, chatID, userID uint64) (uint64, error)
+	GetChannelUnreadCount(ctx context.Context, chatID, userID uint64) (uint64, error)
+	CreateChatInvite(ctx context.Context, inviteData domains.CreateChatInviteDTO) (*domains.ChatInvite, error)
+	GetChatInvites(ctx context.Context, chatID, actorID uint64) ([]domains.ChatInvite, error)
+	RevokeChatInvite(ctx context.Context, inviteData domains.RevokeChatInviteDTO) error
+	JoinChatByInvite(ctx context.Context, joinData domains.JoinChatByInviteDTO) (*domains.Chat, error)
+	SaveChatDraft(ctx context.Context, draftData domains.SaveChatDraftDTO) (*domains.ChatDraft, error)
+	GetChatDraft(ctx context.Context, chatID, userID uint64) (*domains.ChatDraft, error)
+	DeleteChatDraft(ctx context.Context, chatID, userID uint64) error
+}
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/messages_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,PresenceService,AttachmentsService
+type MessagesService interface {
+	CreateMessage(ctx context.Context, messageData domains.CreateMessageDTO) (*domains.Message, error)
+	GetMessageByID(ctx context.Context, id uint64) (*domains.Message, error)
+	GetChatMessageByID(ctx context.Context, chatID, userID, id uint64) (*domains.Message, error)
+	GetChatMessages(
+		ctx context.Context,
+		chatID, userID uint64,
+		cursor domains.MessagesCursor,
+	) (*domains.MessagesPage, error)
+	UpdateMessage(ctx context.Context, messageData domains.UpdateMessageDTO) (*domains.Message, error)
+	DeleteMessage(ctx context.Context, messageData domains.DeleteMessageDTO) (*domains.Message, error)
+	ReadChatMessages(ctx context.Context, chatID, userID, messageID uint64) (*domains.ChatMember, error)
+	AddMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (added bool, err error)
+	RemoveMessageReaction(
+		ctx context.Context,
+		reactionData domains.MessageReactionDTO,
+	) (removed bool, err error)
+	GetThreadMessages(
+		ctx context.Context,
+		chatID, threadRootID, userID uint64,
+		cursor domains.MessagesCursor,
+	) (*domains.ThreadPage, error)
+	ReadThreadMessages(
+		ctx context.Context,
+		chatID, threadRootID, userID, messageID uint64,
+	) (*domains.ThreadParticipant, error)
+	SearchMessages(
+		ctx context.Context,
+		searchData domains.SearchMessagesDTO,
+	) (*domains.MessagesSearchPage, error)
+	GetMessageMentions(ctx context.Context, messageID uint64) ([]domains.Mention, error)
+	GetUserMentions(
+		ctx context.Context,
+		userID uint64,
+		cursor domains.MentionsCursor,
+	) (*domains.MentionsPage, error)
+	ForwardMessages(ctx context.Context, forwardData domains.ForwardMessagesDTO) ([]domains.Message, error)
+	PinMessage(
+		ctx context.Context,
+		pinData domains.PinMessageDTO,
+	) (pin *domains.Pin, systemMessage *domains.Message, err error)
+	UnpinMessage(ctx context.Context, pinData domains.UnpinMessageDTO) (systemMessage *domains.Message, err error)
+	GetChatPins(ctx context.Context, chatID, userID uint64) ([]domains.Pin, error)
+	ScheduleMessage(
+		ctx context.Context,
+		messageData domains.ScheduleMessageDTO,
+	) (*domains.ScheduledMessage, error)
+	GetUserScheduledMessages(ctx context.Context, chatID, userID uint64) ([]domains.ScheduledMessage, error)
+	UpdateScheduledMessage(
+		ctx context.Context,
+		messageData domains.UpdateScheduledMessageDTO,
+	) (*domains.ScheduledMessage, error)
+	DeleteScheduledMessage(ctx context.Context, chatID, id, actorID uint64) error
+	GetDueScheduledMessages(ctx context.Context, now time.Time, limit uint64) ([]domains.ScheduledMessage, error)
+
+	// SendScheduledMessage sends scheduled message and removes it from schedule. ErrScheduledMessageNotFound
+	// is returned, if message is being sent by another dispatcher or was changed after it was provided.
+	SendScheduledMessage(
+		ctx context.Context,
+		scheduledMessage domains.ScheduledMessage,
+		messageData domains.CreateMessageDTO,
+	) (*domains.Message, error)
+	FailScheduledMessage(ctx context.Context, scheduledMessage domains.ScheduledMessage) error
+	SetChatMessageTTL(
+		ctx context.Context,
+		ttlData domains.SetChatMessageTTLDTO,
+	) (systemMessage *domains.Message, err error)
+
+	// PurgeExpiredMessages deletes batch of messages, which expired before provided time. Purge is nil, if
+	// there are no expired messages.
+	PurgeExpiredMessages(
+		ctx context.Context,
+		expiredBefore time.Time,
+		limit uint64,
+	) (*domains.MessagesPurge, error)
+	VotePoll(ctx context.Context, voteData domains.PollVoteDTO) (*domains.Poll, error)
+	RetractPollVote(ctx context.Context, voteData domains.PollVoteDTO) (poll *domains.Poll, retracted bool, err error)
+
+	// ClaimLinkPreviews takes batch of previews of links, which metadata is waiting for fetching.
+	ClaimLinkPreviews(ctx context.Context, limit uint64, claimTimeout time.Duration) ([]domains.LinkPreview, error)
+
+	// SaveLinkPreview saves result of fetching of link metadata and returns messages, which contain the link,
+	// with attached ready preview.
+	SaveLinkPreview(ctx context.Context, previewData domains.UpdateLinkPreviewDTO) ([]domains.Message, error)
+}
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/attachments_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,MessagesService,PresenceService
+type AttachmentsService interface {
+	UploadAttachment(ctx context.Context, attachmentData domains.UploadAttachmentDTO) (*domains.Attachment, error)
+	GetAttachmentByID(ctx context.Context, id uint64) (*domains.Attachment, error)
+
+	// DownloadAttachment provides temporary URL for direct download of attachment or its thumbnail from storage.
+	// If storage does not support direct downloads, content is provided, which should be closed after reading.
+	// Original of attachment is provided for empty thumbnail name.
+	DownloadAttachment(
+		ctx context.Context,
+		attachment domains.Attachment,
+		thumbnailName domains.ThumbnailName,
+	) (*domains.AttachmentDownload, error)
+
+	// ClaimAttachmentsForPreview takes batch of attachments, which are waiting for preview generation.
+	ClaimAttachmentsForPreview(
+		ctx context.Context,
+		limit uint64,
+		claimTimeout time.Duration,
+	) ([]domains.Attachment, error)
+	GenerateAttachmentPreview(ctx context.Context, attachment domains.Attachment) (*domains.Attachment, error)
+
+	// DeleteAttachmentsContent deletes content of deleted attachments from storage.
+	DeleteAttachmentsContent(ctx context.Context, storageKeys []string) error
+}
+
+//go:generate mockgen -source=services.go -destination=../../mocks/services/presence_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,MessagesService,AttachmentsService
+type PresenceService interface {
+	// AddConnection registers real-time connection of User and reports, whether User became online.
+	AddConnection(ctx context.Context, userID uint64, ttl time.Duration) (becameOnline bool, err error)
+	ProlongConnections(ctx context.Context, userID uint64, ttl time.Duration) error
+
+	// RemoveConnection unregisters real-time connection of User and reports, whether User became offline.
+	RemoveConnection(ctx context.Context, userID uint64) (becameOffline bool, err error)
+	GetPresence(ctx context.Context, userID uint64) (*domains.Presence, error)
+
+	// GetPresences returns presences of provided Users in order of their IDs at once.
+	GetPresences(ctx context.Context, userIDs []uint64) ([]domains.Presence, error)
+}
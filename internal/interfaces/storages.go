@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate mockgen -source=storages.go -destination=../../mocks/storages/blob_storage.go -package=mockstorages -exclude_interfaces=
+type BlobStorage interface {
+	// Put saves content under provided key. Content is streamed, so its size is not required to be known.
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes content by key. Absence of content is not considered as error.
+	Delete(ctx context.Context, key string) error
+
+	// GetDownloadURL provides temporary URL for downloading content directly from storage, bypassing
+	// application. Empty URL is returned, if storage does not support direct downloads.
+	GetDownloadURL(ctx context.Context, key, filename, contentType string) (string, error)
+}
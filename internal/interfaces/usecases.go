@@ -6,19 +6,19 @@ import (
 	"github.com/DKhorkov/kfc/internal/domains"
 )
 
-//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/users_usecases.go -package=mockusecases -exclude_interfaces=AuthUseCases
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/users_usecases.go -package=mockusecases -exclude_interfaces=AuthUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
 type UsersUseCases interface {
 	GetUsers(
 		ctx context.Context,
 		filters *domains.UsersFilters,
 		pagination *domains.Pagination,
-	) ([]domains.User, error)
-	GetUserByID(ctx context.Context, id uint64) (*domains.User, error)
-	GetMe(ctx context.Context, accessToken string) (*domains.User, error)
-	UpdateUser(ctx context.Context, userData domains.RawUpdateUserDTO) (*domains.User, error)
+	) ([]domains.UserWithPresence, error)
+	GetUserByID(ctx context.Context, id uint64) (*domains.UserWithPresence, error)
+	GetMe(ctx context.Context, accessToken string) (*domains.UserWithPresence, error)
+	UpdateUser(ctx context.Context, userData domains.RawUpdateUserDTO) (*domains.UserWithPresence, error)
 }
 
-//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/auth_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/auth_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
 type AuthUseCases interface {
 	RegisterUser(ctx context.Context, userData domains.RegisterDTO) (*domains.User, error)
 	LoginUser(ctx context.Context, userData domains.LoginDTO) (*domains.TokensDTO, error)
@@ -30,3 +30,157 @@ type AuthUseCases interface {
 	ChangePassword(ctx context.Context, accessToken, oldPassword, newPassword string) error
 	SendVerifyEmailMessage(ctx context.Context, email string) error
 }
+
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/chats_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
+type ChatsUseCases interface {
+	CreateDirectChat(
+		ctx context.Context,
+		accessToken string,
+		interlocutorID uint64,
+	) (*domains.Chat, error)
+	GetChats(ctx context.Context, accessToken string) ([]domains.Chat, error)
+	GetChatByID(ctx context.Context, accessToken string, chatID uint64) (*domains.Chat, error)
+	CreateGroupChat(ctx context.Context, chatData domains.RawCreateGroupChatDTO) (*domains.Chat, error)
+	CreateChannel(ctx context.Context, channelData domains.RawCreateChannelDTO) (*domains.Chat, error)
+	GetChannels(
+		ctx context.Context,
+		filters *domains.ChannelsFilters,
+		pagination *domains.Pagination,
+	) ([]domains.Chat, error)
+	SubscribeToChannel(ctx context.Context, accessToken string, chatID uint64) (*domains.Chat, error)
+	AddChatMembers(
+		ctx context.Context,
+		accessToken string,
+		chatID uint64,
+		userIDs []uint64,
+	) (*domains.Chat, error)
+	RemoveChatMember(
+		ctx context.Context,
+		accessToken string,
+		chatID, memberID uint64,
+	) (*domains.Chat, error)
+	PromoteChatMember(
+		ctx context.Context,
+		accessToken string,
+		chatID, memberID uint64,
+	) (*domains.Chat, error)
+	DemoteChatMember(
+		ctx context.Context,
+		accessToken string,
+		chatID, memberID uint64,
+	) (*domains.Chat, error)
+	LeaveChat(ctx context.Context, accessToken string, chatID uint64) error
+	CreateChatInvite(ctx context.Context, inviteData domains.RawCreateChatInviteDTO) (*domains.ChatInvite, error)
+	GetChatInvites(ctx context.Context, accessToken string, chatID uint64) ([]domains.ChatInvite, error)
+	RevokeChatInvite(ctx context.Context, accessToken string, chatID, inviteID uint64) error
+	JoinChatByInvite(ctx context.Context, accessToken, token string) (*domains.Chat, error)
+	SaveChatDraft(ctx context.Context, draftData domains.RawSaveChatDraftDTO) (*domains.ChatDraft, error)
+	GetChatDraft(ctx context.Context, accessToken string, chatID uint64) (*domains.ChatDraft, error)
+	DeleteChatDraft(ctx context.Context, accessToken string, chatID uint64) error
+}
+
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/messages_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,PresenceUseCases,AttachmentsUseCases
+type MessagesUseCases interface {
+	CreateMessage(ctx context.Context, messageData domains.RawCreateMessageDTO) (*domains.Message, error)
+	GetChatMessages(
+		ctx context.Context,
+		accessToken string,
+		chatID uint64,
+		cursor domains.MessagesCursor,
+	) (*domains.MessagesPage, error)
+	UpdateMessage(ctx context.Context, messageData domains.RawUpdateMessageDTO) (*domains.Message, error)
+	DeleteMessage(ctx context.Context, messageData domains.RawDeleteMessageDTO) error
+	ReadChatMessages(
+		ctx context.Context,
+		accessToken string,
+		chatID, messageID uint64,
+	) (*domains.ChatMember, error)
+	SendTypingIndicator(ctx context.Context, accessToken string, chatID uint64) error
+	AddMessageReaction(ctx context.Context, reactionData domains.RawMessageReactionDTO) error
+	RemoveMessageReaction(ctx context.Context, reactionData domains.RawMessageReactionDTO) error
+	GetThreadMessages(
+		ctx context.Context,
+		accessToken string,
+		chatID, threadRootID uint64,
+		cursor domains.MessagesCursor,
+	) (*domains.ThreadPage, error)
+	ReadThreadMessages(
+		ctx context.Context,
+		accessToken string,
+		chatID, threadRootID, messageID uint64,
+	) (*domains.ThreadParticipant, error)
+	SearchMessages(
+		ctx context.Context,
+		searchData domains.RawSearchMessagesDTO,
+	) (*domains.MessagesSearchPage, error)
+	GetMentions(
+		ctx context.Context,
+		accessToken string,
+		cursor domains.MentionsCursor,
+	) (*domains.MentionsPage, error)
+	ForwardMessages(ctx context.Context, forwardData domains.RawForwardMessagesDTO) ([]domains.Message, error)
+	PinMessage(ctx context.Context, accessToken string, chatID, messageID uint64) (*domains.Pin, error)
+	UnpinMessage(ctx context.Context, accessToken string, chatID, messageID uint64) error
+	GetChatPins(ctx context.Context, accessToken string, chatID uint64) ([]domains.Pin, error)
+	ScheduleMessage(
+		ctx context.Context,
+		messageData domains.RawScheduleMessageDTO,
+	) (*domains.ScheduledMessage, error)
+	GetScheduledMessages(
+		ctx context.Context,
+		accessToken string,
+		chatID uint64,
+	) ([]domains.ScheduledMessage, error)
+	UpdateScheduledMessage(
+		ctx context.Context,
+		messageData domains.RawUpdateScheduledMessageDTO,
+	) (*domains.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, accessToken string, chatID, scheduledMessageID uint64) error
+
+	// SendScheduledMessages sends batch of due scheduled messages and returns number of processed messages.
+	SendScheduledMessages(ctx context.Context) (int, error)
+	SetChatMessageTTL(ctx context.Context, accessToken string, chatID uint64, messageTTL domains.MessageTTL) error
+
+	// PurgeExpiredMessages deletes batch of expired messages and returns number of deleted messages.
+	PurgeExpiredMessages(ctx context.Context) (int, error)
+	CreatePoll(ctx context.Context, pollData domains.RawCreatePollDTO) (*domains.Message, error)
+	VotePoll(ctx context.Context, voteData domains.RawPollVoteDTO) (*domains.Poll, error)
+	RetractPollVote(ctx context.Context, accessToken string, chatID, messageID uint64) (*domains.Poll, error)
+
+	// ProcessLinkPreviews fetches metadata for batch of pending links and returns number of processed links.
+	ProcessLinkPreviews(ctx context.Context) (int, error)
+}
+
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/attachments_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases
+type AttachmentsUseCases interface {
+	UploadAttachment(
+		ctx context.Context,
+		attachmentData domains.RawUploadAttachmentDTO,
+	) (*domains.Attachment, error)
+
+	// DownloadAttachment provides URL for direct download of attachment from storage or, if storage does not
+	// support it, content of attachment.
+	DownloadAttachment(
+		ctx context.Context,
+		accessToken string,
+		chatID, attachmentID uint64,
+	) (*domains.AttachmentDownload, error)
+	DownloadAttachmentThumbnail(
+		ctx context.Context,
+		accessToken string,
+		chatID, attachmentID uint64,
+		thumbnailName domains.ThumbnailName,
+	) (*domains.AttachmentDownload, error)
+
+	// ProcessAttachmentsPreviews generates previews for batch of pending attachments and returns number of
+	// processed attachments.
+	ProcessAttachmentsPreviews(ctx context.Context) (int, error)
+}
+
+//go:generate mockgen -source=usecases.go -destination=../../mocks/usecases/presence_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,MessagesUseCases,AttachmentsUseCases
+type PresenceUseCases interface {
+	// ConnectUser marks already authenticated User online for the time of real-time connection.
+	ConnectUser(ctx context.Context, userID uint64) error
+	KeepUserOnline(ctx context.Context, userID uint64) error
+	DisconnectUser(ctx context.Context, userID uint64) error
+}
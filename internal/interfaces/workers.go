@@ -0,0 +1,7 @@
+package interfaces
+
+//go:generate mockgen -source=workers.go -destination=../../mocks/workers/worker.go -package=mockworkers -exclude_interfaces=
+type Worker interface {
+	Run()
+	Stop()
+}
@@ -0,0 +1,187 @@
+package previews
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/buckket/go-blurhash"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	jpegMIMEType = "image/jpeg"
+	pngMIMEType  = "image/png"
+	gifMIMEType  = "image/gif"
+	webpMIMEType = "image/webp"
+
+	// mimeSniffLength is a number of bytes, used by http.DetectContentType for detecting content type.
+	mimeSniffLength = 512
+
+	// Blurhash is calculated on small thumbnail, because its result does not depend on image size,
+	// while its calculation time does:
+	blurhashXComponents = 4
+	blurhashYComponents = 3
+)
+
+var decoders = map[string]struct {
+	decode       func(r io.Reader) (image.Image, error)
+	decodeConfig func(r io.Reader) (image.Config, error)
+}{
+	jpegMIMEType: {decode: jpeg.Decode, decodeConfig: jpeg.DecodeConfig},
+	pngMIMEType:  {decode: png.Decode, decodeConfig: png.DecodeConfig},
+	gifMIMEType:  {decode: gif.Decode, decodeConfig: gif.DecodeConfig}, // Only first frame is used
+	webpMIMEType: {decode: webp.Decode, decodeConfig: webp.DecodeConfig},
+}
+
+// ImagePreviewGenerator prepares small and medium thumbnails of JPEG, PNG, GIF and WebP images. Thumbnails of
+// JPEG images are encoded as JPEG and thumbnails of other images as PNG to keep transparency.
+type ImagePreviewGenerator struct {
+	config config.AttachmentsPreviewsConfig
+}
+
+func NewImagePreviewGenerator(previewsConfig config.AttachmentsPreviewsConfig) *ImagePreviewGenerator {
+	return &ImagePreviewGenerator{config: previewsConfig}
+}
+
+func (g *ImagePreviewGenerator) Supports(mimeType string) bool {
+	_, ok := decoders[mimeType]
+
+	return ok
+}
+
+func (g *ImagePreviewGenerator) Generate(content io.Reader) (*domains.ImagePreview, error) {
+	buffered := bufio.NewReader(content)
+
+	// Format is detected by content, the same way as on upload, to not trust stored MIME type:
+	head, err := buffered.Peek(mimeSniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	mimeType := http.DetectContentType(head)
+
+	decoder, ok := decoders[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported format", customerrors.ErrInvalidImage)
+	}
+
+	// Whole image is kept in memory, so its dimensions are checked before decoding:
+	var header bytes.Buffer
+
+	imageConfig, err := decoder.decodeConfig(io.TeeReader(buffered, &header))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImage, err)
+	}
+
+	if imageConfig.Width <= 0 || imageConfig.Height <= 0 ||
+		imageConfig.Width*imageConfig.Height > g.config.MaxPixels {
+		return nil, fmt.Errorf(
+			"%w: image of %dx%d pixels is too large",
+			customerrors.ErrInvalidImage,
+			imageConfig.Width,
+			imageConfig.Height,
+		)
+	}
+
+	img, err := decoder.decode(io.MultiReader(&header, buffered))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImage, err)
+	}
+
+	bounds := img.Bounds()
+	preview := &domains.ImagePreview{
+		Width:  uint64(bounds.Dx()),
+		Height: uint64(bounds.Dy()),
+	}
+
+	sizes := []struct {
+		name    domains.ThumbnailName
+		maxSide int
+	}{
+		{name: domains.ThumbnailNameSmall, maxSide: g.config.SmallThumbnailSize},
+		{name: domains.ThumbnailNameMedium, maxSide: g.config.MediumThumbnailSize},
+	}
+
+	var small image.Image
+
+	for _, size := range sizes {
+		resized := resize(img, size.maxSide)
+		if size.name == domains.ThumbnailNameSmall {
+			small = resized
+		}
+
+		thumbnail, err := g.encode(resized, mimeType)
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnail.Name = size.name
+		preview.Thumbnails = append(preview.Thumbnails, *thumbnail)
+	}
+
+	if preview.Blurhash, err = blurhash.Encode(blurhashXComponents, blurhashYComponents, small); err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrInvalidImage, err)
+	}
+
+	return preview, nil
+}
+
+func (g *ImagePreviewGenerator) encode(img image.Image, sourceMIMEType string) (*domains.ImageThumbnail, error) {
+	var (
+		buffer bytes.Buffer
+		err    error
+	)
+
+	thumbnail := &domains.ImageThumbnail{
+		Width:  uint64(img.Bounds().Dx()),
+		Height: uint64(img.Bounds().Dy()),
+	}
+
+	switch sourceMIMEType {
+	case jpegMIMEType:
+		thumbnail.MIMEType = jpegMIMEType
+		err = jpeg.Encode(&buffer, img, &jpeg.Options{Quality: g.config.JPEGQuality})
+	default:
+		thumbnail.MIMEType = pngMIMEType
+		err = png.Encode(&buffer, img)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail.Content = buffer.Bytes()
+
+	return thumbnail, nil
+}
+
+// resize scales image down to fit provided side, keeping its aspect ratio. Images are never scaled up.
+func resize(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			width, height = maxSide, max(1, height*maxSide/width)
+		} else {
+			width, height = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Src, nil)
+
+	return resized
+}
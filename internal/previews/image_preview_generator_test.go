@@ -0,0 +1,168 @@
+package previews_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/previews"
+	"github.com/stretchr/testify/require"
+)
+
+var previewsConfig = config.AttachmentsPreviewsConfig{
+	SmallThumbnailSize:  16,
+	MediumThumbnailSize: 64,
+	JPEGQuality:         80,
+	MaxPixels:           10_000,
+}
+
+func buildImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		for y := range height {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	return img
+}
+
+func encodeImage(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+
+	var (
+		buffer bytes.Buffer
+		err    error
+	)
+
+	img := buildImage(width, height)
+
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buffer, img, nil)
+	case "png":
+		err = png.Encode(&buffer, img)
+	case "gif":
+		err = gif.Encode(&buffer, img, nil)
+	}
+
+	require.NoError(t, err)
+
+	return buffer.Bytes()
+}
+
+func TestImagePreviewGenerator_Supports(t *testing.T) {
+	t.Parallel()
+
+	generator := previews.NewImagePreviewGenerator(previewsConfig)
+
+	require.True(t, generator.Supports("image/jpeg"))
+	require.True(t, generator.Supports("image/png"))
+	require.True(t, generator.Supports("image/gif"))
+	require.True(t, generator.Supports("image/webp"))
+	require.False(t, generator.Supports("image/svg+xml"))
+	require.False(t, generator.Supports("application/pdf"))
+}
+
+func TestImagePreviewGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		content            []byte
+		expectedWidth      uint64
+		expectedHeight     uint64
+		expectedMIMEType   string
+		expectedThumbnails map[domains.ThumbnailName][2]uint64
+		errorExpected      bool
+	}{
+		{
+			name:             "landscape jpeg",
+			content:          encodeImage(t, "jpeg", 100, 50),
+			expectedWidth:    100,
+			expectedHeight:   50,
+			expectedMIMEType: "image/jpeg",
+			expectedThumbnails: map[domains.ThumbnailName][2]uint64{
+				domains.ThumbnailNameSmall:  {16, 8},
+				domains.ThumbnailNameMedium: {64, 32},
+			},
+		},
+		{
+			name:             "portrait png",
+			content:          encodeImage(t, "png", 40, 80),
+			expectedWidth:    40,
+			expectedHeight:   80,
+			expectedMIMEType: "image/png",
+			expectedThumbnails: map[domains.ThumbnailName][2]uint64{
+				domains.ThumbnailNameSmall:  {8, 16},
+				domains.ThumbnailNameMedium: {32, 64},
+			},
+		},
+		{
+			name:             "small gif is not scaled up",
+			content:          encodeImage(t, "gif", 10, 10),
+			expectedWidth:    10,
+			expectedHeight:   10,
+			expectedMIMEType: "image/png",
+			expectedThumbnails: map[domains.ThumbnailName][2]uint64{
+				domains.ThumbnailNameSmall:  {10, 10},
+				domains.ThumbnailNameMedium: {10, 10},
+			},
+		},
+		{
+			name:          "not an image",
+			content:       []byte("%PDF-1.4 definitely not an image"),
+			errorExpected: true,
+		},
+		{
+			name:          "truncated image",
+			content:       encodeImage(t, "png", 50, 50)[:100],
+			errorExpected: true,
+		},
+		{
+			name:          "too many pixels",
+			content:       encodeImage(t, "png", 200, 100),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			generator := previews.NewImagePreviewGenerator(previewsConfig)
+
+			preview, err := generator.Generate(bytes.NewReader(tc.content))
+			if tc.errorExpected {
+				require.ErrorIs(t, err, customerrors.ErrInvalidImage)
+				require.Nil(t, preview)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedWidth, preview.Width)
+			require.Equal(t, tc.expectedHeight, preview.Height)
+			require.NotEmpty(t, preview.Blurhash)
+			require.Len(t, preview.Thumbnails, len(tc.expectedThumbnails))
+
+			for _, thumbnail := range preview.Thumbnails {
+				require.Equal(t, tc.expectedMIMEType, thumbnail.MIMEType)
+				require.Equal(t, tc.expectedThumbnails[thumbnail.Name], [2]uint64{thumbnail.Width, thumbnail.Height})
+
+				// Thumbnail should be a valid image of declared size:
+				imageConfig, _, err := image.DecodeConfig(bytes.NewReader(thumbnail.Content))
+				require.NoError(t, err)
+				require.Equal(t, int(thumbnail.Width), imageConfig.Width)
+				require.Equal(t, int(thumbnail.Height), imageConfig.Height)
+			}
+		})
+	}
+}
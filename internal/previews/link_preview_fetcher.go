@@ -0,0 +1,376 @@
+package previews
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DKhorkov/kfc/internal/common"
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	httpScheme  = "http"
+	httpsScheme = "https"
+
+	htmlMIMEType  = "text/html"
+	xhtmlMIMEType = "application/xhtml+xml"
+
+	// Headers are not limited by body size, so they are limited separately:
+	maxResponseHeaderBytes = 64 * 1024
+)
+
+// reservedNetworks lists special-purpose networks, which are not covered by netip.Addr methods, but must not
+// be reachable by fetcher:
+var reservedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, which translates to IPv4 addresses, including private
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // Local-use NAT64
+	netip.MustParsePrefix("2001::/32"),       // Teredo tunnels
+	netip.MustParsePrefix("2001:db8::/32"),   // Documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4 tunnels, which embed IPv4 addresses, including private
+}
+
+// LinkPreviewFetcher loads beginning of HTML pages and extracts their OpenGraph and Twitter card metadata.
+// Address of each connection, including connections of redirects, is checked after name resolution, so
+// neither redirects nor DNS records can lead fetcher into private networks.
+type LinkPreviewFetcher struct {
+	config                 config.LinkPreviewsConfig
+	allowedPrivateNetworks []netip.Prefix
+	client                 *http.Client
+}
+
+func NewLinkPreviewFetcher(linkPreviewsConfig config.LinkPreviewsConfig) (*LinkPreviewFetcher, error) {
+	fetcher := &LinkPreviewFetcher{
+		config:                 linkPreviewsConfig,
+		allowedPrivateNetworks: make([]netip.Prefix, len(linkPreviewsConfig.AllowedPrivateNetworks)),
+	}
+
+	for i, network := range linkPreviewsConfig.AllowedPrivateNetworks {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(network))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed private network: %w", err)
+		}
+
+		fetcher.allowedPrivateNetworks[i] = prefix.Masked()
+	}
+
+	dialer := &net.Dialer{
+		Timeout: linkPreviewsConfig.Timeout,
+		Control: fetcher.checkConnectionAddress,
+	}
+
+	fetcher.client = &http.Client{
+		Transport: &http.Transport{
+			// Proxy is not used, because it would connect to page on behalf of fetcher bypassing address check:
+			Proxy:                  nil,
+			DialContext:            dialer.DialContext,
+			TLSHandshakeTimeout:    linkPreviewsConfig.Timeout,
+			ResponseHeaderTimeout:  linkPreviewsConfig.Timeout,
+			MaxResponseHeaderBytes: maxResponseHeaderBytes,
+			DisableKeepAlives:      true,
+		},
+		CheckRedirect: fetcher.checkRedirect,
+		Timeout:       linkPreviewsConfig.Timeout,
+	}
+
+	return fetcher, nil
+}
+
+func (f *LinkPreviewFetcher) Fetch(ctx context.Context, rawURL string) (*domains.LinkMetadata, error) {
+	linkURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrLinkPreviewUnavailable, err)
+	}
+
+	if err = checkLinkURL(linkURL); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, linkURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrLinkPreviewUnavailable, err)
+	}
+
+	request.Header.Set("User-Agent", f.config.UserAgent)
+	request.Header.Set("Accept", htmlMIMEType+", "+xhtmlMIMEType)
+
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrLinkPreviewUnavailable, err)
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf(
+			"%w: unexpected status %d",
+			customerrors.ErrLinkPreviewUnavailable,
+			response.StatusCode,
+		)
+	}
+
+	contentType := response.Header.Get("Content-Type")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != htmlMIMEType && mediaType != xhtmlMIMEType) {
+		return nil, fmt.Errorf(
+			"%w: unsupported content type %q",
+			customerrors.ErrLinkPreviewUnavailable,
+			contentType,
+		)
+	}
+
+	// Metadata is located in head of page, so the rest of large page is not read:
+	content, err := charset.NewReader(io.LimitReader(response.Body, f.config.MaxBodySize), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrLinkPreviewUnavailable, err)
+	}
+
+	// Relative URL of image is resolved against URL of page, which was reached after redirects:
+	metadata := parseLinkMetadata(content, response.Request.URL)
+	if metadata.Title == "" {
+		return nil, fmt.Errorf("%w: page has no metadata", customerrors.ErrLinkPreviewUnavailable)
+	}
+
+	return &metadata, nil
+}
+
+// checkConnectionAddress is called by dialer with resolved address of each connection before connecting.
+func (f *LinkPreviewFetcher) checkConnectionAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !f.isAllowedAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", customerrors.ErrForbiddenLinkAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// isAllowedAddress reports, whether pages can be fetched from provided address. Only public unicast
+// addresses and addresses of explicitly allowed private networks are allowed.
+func (f *LinkPreviewFetcher) isAllowedAddress(addr netip.Addr) bool {
+	// IPv4-mapped IPv6 addresses are checked as IPv4 ones:
+	addr = addr.Unmap()
+
+	for _, network := range f.allowedPrivateNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+
+	// Loopback, link-local, multicast and unspecified addresses are not global unicast ones:
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, network := range reservedNetworks {
+		if network.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *LinkPreviewFetcher) checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) > f.config.MaxRedirects {
+		return fmt.Errorf("%w: too many redirects", customerrors.ErrLinkPreviewUnavailable)
+	}
+
+	return checkLinkURL(request.URL)
+}
+
+// checkLinkURL allows only absolute HTTP and HTTPS URLs.
+func checkLinkURL(linkURL *url.URL) error {
+	if (linkURL.Scheme != httpScheme && linkURL.Scheme != httpsScheme) || linkURL.Hostname() == "" {
+		return fmt.Errorf("%w: unsupported link %q", customerrors.ErrLinkPreviewUnavailable, linkURL.Redacted())
+	}
+
+	return nil
+}
+
+// parseLinkMetadata extracts metadata from head of HTML page. OpenGraph properties take precedence over
+// Twitter card ones, while title and description of document are used as fallback.
+func parseLinkMetadata(content io.Reader, pageURL *url.URL) domains.LinkMetadata {
+	properties, documentTitle := readHead(html.NewTokenizer(content))
+
+	title := firstProperty(properties, "og:title", "twitter:title")
+	if title == "" {
+		title = documentTitle
+	}
+
+	metadata := domains.LinkMetadata{
+		Title: normalizeText(title, common.LinkPreviewTitleMaxLength),
+		Description: optionalText(
+			normalizeText(
+				firstProperty(properties, "og:description", "twitter:description", "description"),
+				common.LinkPreviewDescriptionMaxLength,
+			),
+		),
+		SiteName: optionalText(
+			normalizeText(firstProperty(properties, "og:site_name"), common.LinkPreviewSiteNameMaxLength),
+		),
+	}
+
+	imageURL := firstProperty(
+		properties,
+		"og:image",
+		"og:image:url",
+		"og:image:secure_url",
+		"twitter:image",
+		"twitter:image:src",
+	)
+	if imageURL != "" {
+		metadata.ImageURL = resolveImageURL(imageURL, pageURL)
+	}
+
+	return metadata
+}
+
+// readHead collects properties of meta tags and title of document until body of page starts. Only first
+// occurrence of each property is kept.
+func readHead(tokenizer *html.Tokenizer) (map[string]string, string) {
+	var (
+		properties    = make(map[string]string)
+		documentTitle string
+	)
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// Page ended or its read limit is reached:
+			return properties, documentTitle
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); atom.Lookup(name) == atom.Head {
+				return properties, documentTitle
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttributes := tokenizer.TagName()
+
+			switch atom.Lookup(name) {
+			case atom.Body:
+				return properties, documentTitle
+			case atom.Title:
+				if tokenizer.Next() == html.TextToken && documentTitle == "" {
+					documentTitle = string(tokenizer.Text())
+				}
+			case atom.Meta:
+				if !hasAttributes {
+					continue
+				}
+
+				key, value := readMetaProperty(tokenizer)
+				if _, ok := properties[key]; key != "" && !ok {
+					properties[key] = value
+				}
+			}
+		}
+	}
+}
+
+// readMetaProperty reads name of property from "property" or "name" attribute of meta tag and its value
+// from "content" attribute.
+func readMetaProperty(tokenizer *html.Tokenizer) (key, value string) {
+	for {
+		name, attributeValue, more := tokenizer.TagAttr()
+
+		switch string(name) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(string(attributeValue)))
+			}
+		case "content":
+			value = string(attributeValue)
+		}
+
+		if !more {
+			return key, value
+		}
+	}
+}
+
+func firstProperty(properties map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(properties[key]); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// normalizeText collapses whitespaces, removes control and invalid characters and limits length of text.
+func normalizeText(text string, maxLength int) string {
+	text = strings.Join(strings.Fields(strings.ToValidUTF8(text, "")), " ")
+	text = strings.Map(
+		func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+
+			return r
+		},
+		text,
+	)
+
+	if utf8.RuneCountInString(text) > maxLength {
+		text = strings.TrimSpace(string([]rune(text)[:maxLength]))
+	}
+
+	return text
+}
+
+func optionalText(text string) *string {
+	if text == "" {
+		return nil
+	}
+
+	return &text
+}
+
+// resolveImageURL provides absolute HTTP or HTTPS URL of image or nil, if image URL is not valid.
+func resolveImageURL(rawURL string, pageURL *url.URL) *string {
+	imageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	imageURL = pageURL.ResolveReference(imageURL)
+	if checkLinkURL(imageURL) != nil {
+		return nil
+	}
+
+	resolved := imageURL.String()
+	if len(resolved) > common.LinkURLMaxLength {
+		return nil
+	}
+
+	return &resolved
+}
@@ -0,0 +1,267 @@
+package previews_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/previews"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// linkPreviewsConfig allows loopback network, where test server is listening:
+var linkPreviewsConfig = config.LinkPreviewsConfig{
+	Timeout:                time.Second,
+	MaxBodySize:            4096,
+	MaxRedirects:           2,
+	UserAgent:              "test-agent",
+	AllowedPrivateNetworks: []string{"127.0.0.0/8"},
+}
+
+func newLinkPreviewsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	writeHTML := func(w http.ResponseWriter, contentType, page string) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = fmt.Fprint(w, page)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/opengraph", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != linkPreviewsConfig.UserAgent {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		writeHTML(w, "text/html; charset=utf-8", `<!DOCTYPE html>
+<html>
+<head>
+	<title>Document title</title>
+	<meta property="og:title" content="  Open   Graph &amp; title ">
+	<meta property="og:title" content="Second title">
+	<meta property="og:description" content="Description of page">
+	<meta property="og:site_name" content="Example">
+	<meta property="og:image" content="/images/cover.png">
+	<meta name="twitter:title" content="Twitter title">
+</head>
+<body><meta property="og:description" content="Ignored"></body>
+</html>`)
+	})
+	mux.HandleFunc("/twitter", func(w http.ResponseWriter, _ *http.Request) {
+		writeHTML(w, "text/html", `<html><head>
+	<meta name="twitter:title" content="Twitter title">
+	<meta name="description" content="Document description">
+	<meta name="twitter:image" content="https://cdn.example.com/card.jpg">
+</head></html>`)
+	})
+	mux.HandleFunc("/document", func(w http.ResponseWriter, _ *http.Request) {
+		page, _ := charmap.Windows1251.NewEncoder().String("<html><head><title>Привет, мир</title></head></html>")
+		writeHTML(w, "text/html; charset=windows-1251", page)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		writeHTML(w, "text/html", "<html><head></head><body><title>Not in head</title></body></html>")
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, _ *http.Request) {
+		writeHTML(
+			w,
+			"text/html",
+			"<html><head><!-- "+strings.Repeat("a", 2*int(linkPreviewsConfig.MaxBodySize))+
+				" --><title>Too far</title></head></html>",
+		)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, _ *http.Request) {
+		writeHTML(w, "application/json", `{"title": "Not a page"}`)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(3 * linkPreviewsConfig.Timeout):
+		}
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/opengraph", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect-loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/redirect-loop", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect-private", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect-file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLinkPreviewFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	server := newLinkPreviewsServer(t)
+
+	testCases := []struct {
+		name          string
+		url           string
+		expected      *domains.LinkMetadata
+		expectedError error
+	}{
+		{
+			name: "OpenGraph metadata",
+			url:  server.URL + "/opengraph",
+			expected: &domains.LinkMetadata{
+				Title:       "Open Graph & title",
+				Description: pointers.New("Description of page"),
+				SiteName:    pointers.New("Example"),
+				ImageURL:    pointers.New(server.URL + "/images/cover.png"),
+			},
+		},
+		{
+			name: "Twitter card metadata",
+			url:  server.URL + "/twitter",
+			expected: &domains.LinkMetadata{
+				Title:       "Twitter title",
+				Description: pointers.New("Document description"),
+				ImageURL:    pointers.New("https://cdn.example.com/card.jpg"),
+			},
+		},
+		{
+			name:     "document title in legacy charset",
+			url:      server.URL + "/document",
+			expected: &domains.LinkMetadata{Title: "Привет, мир"},
+		},
+		{
+			name: "redirect is followed",
+			url:  server.URL + "/redirect",
+			expected: &domains.LinkMetadata{
+				Title:       "Open Graph & title",
+				Description: pointers.New("Description of page"),
+				SiteName:    pointers.New("Example"),
+				ImageURL:    pointers.New(server.URL + "/images/cover.png"),
+			},
+		},
+		{
+			name:          "page without metadata",
+			url:           server.URL + "/empty",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "metadata beyond body size limit",
+			url:           server.URL + "/large",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "not HTML page",
+			url:           server.URL + "/json",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "missing page",
+			url:           server.URL + "/missing",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "slow page",
+			url:           server.URL + "/slow",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "too many redirects",
+			url:           server.URL + "/redirect-loop",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "redirect to private address",
+			url:           server.URL + "/redirect-private",
+			expectedError: customerrors.ErrForbiddenLinkAddress,
+		},
+		{
+			name:          "redirect to unsupported scheme",
+			url:           server.URL + "/redirect-file",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "unsupported scheme",
+			url:           "ftp://example.com/file.html",
+			expectedError: customerrors.ErrLinkPreviewUnavailable,
+		},
+		{
+			name:          "private address",
+			url:           "http://10.0.0.1/",
+			expectedError: customerrors.ErrForbiddenLinkAddress,
+		},
+		{
+			name:          "IPv4-mapped link-local address",
+			url:           "http://[::ffff:169.254.169.254]/",
+			expectedError: customerrors.ErrForbiddenLinkAddress,
+		},
+	}
+
+	fetcher, err := previews.NewLinkPreviewFetcher(linkPreviewsConfig)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata, err := fetcher.Fetch(context.Background(), tc.url)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.Nil(t, metadata)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, metadata)
+		})
+	}
+}
+
+func TestLinkPreviewFetcher_Fetch_BlocksLoopback(t *testing.T) {
+	t.Parallel()
+
+	server := newLinkPreviewsServer(t)
+
+	blockingConfig := linkPreviewsConfig
+	blockingConfig.AllowedPrivateNetworks = nil
+
+	fetcher, err := previews.NewLinkPreviewFetcher(blockingConfig)
+	require.NoError(t, err)
+
+	// Server is reachable both by its address and by name, which resolves to loopback:
+	for _, url := range []string{
+		server.URL + "/opengraph",
+		strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/opengraph",
+	} {
+		metadata, err := fetcher.Fetch(context.Background(), url)
+		require.ErrorIs(t, err, customerrors.ErrForbiddenLinkAddress, url)
+		require.Nil(t, metadata)
+	}
+}
+
+func TestNewLinkPreviewFetcher(t *testing.T) {
+	t.Parallel()
+
+	invalidConfig := linkPreviewsConfig
+	invalidConfig.AllowedPrivateNetworks = []string{"10.0.0.1"}
+
+	fetcher, err := previews.NewLinkPreviewFetcher(invalidConfig)
+	require.Error(t, err)
+	require.Nil(t, fetcher)
+}
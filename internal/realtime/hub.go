@@ -0,0 +1,196 @@
+package realtime
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+)
+
+const (
+	subscriptionBufferSize = 64
+)
+
+// Hub holds real-time subscriptions of connected Users and delivers events to them.
+// Last published events are kept in history to replay them for reconnected Users.
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[uint64]map[*Subscription]struct{}
+	wg            sync.WaitGroup
+	closed        bool
+	lastEventID   uint64
+	history       []domains.Event // Ring buffer, where event is stored by index of ID modulo history size.
+}
+
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		subscriptions: make(map[uint64]map[*Subscription]struct{}),
+		history:       make([]domains.Event, historySize),
+
+		// Events IDs are started from current time to keep them growing after application restart:
+		lastEventID: uint64(time.Now().UnixMicro()),
+	}
+}
+
+// Subscribe registers new subscription of User. If lastEventID is provided, all events after it,
+// which are still stored in history, will be delivered first. Every subscription should be released
+// via Unsubscribe.
+func (h *Hub) Subscribe(userID, lastEventID uint64) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, customerrors.ErrRealtimeHubClosed
+	}
+
+	missedEvents := h.getMissedEvents(userID, lastEventID)
+	subscription := newSubscription(userID, len(missedEvents))
+
+	for _, event := range missedEvents {
+		subscription.events <- event
+	}
+
+	if _, ok := h.subscriptions[userID]; !ok {
+		h.subscriptions[userID] = make(map[*Subscription]struct{})
+	}
+
+	h.subscriptions[userID][subscription] = struct{}{}
+	h.wg.Add(1)
+
+	return subscription, nil
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	userSubscriptions, ok := h.subscriptions[subscription.userID]
+	if !ok {
+		return
+	}
+
+	if _, ok = userSubscriptions[subscription]; !ok {
+		return
+	}
+
+	subscription.close()
+	delete(userSubscriptions, subscription)
+
+	if len(userSubscriptions) == 0 {
+		delete(h.subscriptions, subscription.userID)
+	}
+
+	h.wg.Done()
+}
+
+// Publish assigns ID to event, stores it in history and delivers it to all subscriptions of event
+// recipients. Event, which already has ID, assigned by broker, keeps it, so IDs are the same on all
+// application instances. Ephemeral events are only delivered. Subscriptions, which can not keep up with
+// events flow, are closed to not block other recipients.
+func (h *Hub) Publish(_ context.Context, event domains.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !event.Ephemeral {
+		if event.ID == 0 {
+			h.lastEventID++
+			event.ID = h.lastEventID
+		}
+
+		h.lastEventID = max(h.lastEventID, event.ID)
+
+		if len(h.history) > 0 {
+			h.history[event.ID%uint64(len(h.history))] = event
+		}
+	}
+
+	for _, userID := range event.Recipients {
+		for subscription := range h.subscriptions[userID] {
+			select {
+			case subscription.events <- event:
+			default:
+				subscription.close()
+			}
+		}
+	}
+}
+
+// Close closes all subscriptions and waits until all of them will be released.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+
+	for _, userSubscriptions := range h.subscriptions {
+		for subscription := range userSubscriptions {
+			subscription.close()
+		}
+	}
+
+	h.mu.Unlock()
+
+	h.wg.Wait()
+}
+
+// getMissedEvents returns events of User from history, which were published after lastEventID.
+func (h *Hub) getMissedEvents(userID, lastEventID uint64) []domains.Event {
+	if lastEventID == 0 || lastEventID >= h.lastEventID || len(h.history) == 0 {
+		return nil
+	}
+
+	historySize := uint64(len(h.history))
+
+	firstEventID := lastEventID + 1
+	if h.lastEventID-firstEventID >= historySize { // Older events were already overwritten
+		firstEventID = h.lastEventID - historySize + 1
+	}
+
+	var missedEvents []domains.Event
+
+	for id := firstEventID; id <= h.lastEventID; id++ {
+		event := h.history[id%historySize]
+		if event.ID == id && slices.Contains(event.Recipients, userID) {
+			missedEvents = append(missedEvents, event)
+		}
+	}
+
+	return missedEvents
+}
+
+// Subscription represents single real-time connection of User.
+type Subscription struct {
+	userID    uint64
+	events    chan domains.Event
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newSubscription(userID uint64, missedEventsCount int) *Subscription {
+	return &Subscription{
+		userID: userID,
+		events: make(chan domains.Event, subscriptionBufferSize+missedEventsCount),
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *Subscription) UserID() uint64 {
+	return s.userID
+}
+
+// Events provides events, which should be delivered to User.
+func (s *Subscription) Events() <-chan domains.Event {
+	return s.events
+}
+
+// Done is closed, when subscription should be finished.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
@@ -0,0 +1,273 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/realtime"
+	"github.com/stretchr/testify/require"
+)
+
+const historySize = 4
+
+func TestHubPublish(t *testing.T) {
+	t.Parallel()
+
+	hub := realtime.NewHub(historySize)
+
+	recipient, err := hub.Subscribe(1, 0)
+	require.NoError(t, err)
+
+	outsider, err := hub.Subscribe(2, 0)
+	require.NoError(t, err)
+
+	event := domains.Event{
+		Type:       domains.EventTypeMessageCreated,
+		ChatID:     10,
+		Recipients: []uint64{1},
+	}
+
+	hub.Publish(context.Background(), event)
+
+	received := <-recipient.Events()
+	require.NotZero(t, received.ID)
+	require.Equal(t, event.Type, received.Type)
+	require.Equal(t, event.ChatID, received.ChatID)
+	require.Empty(t, outsider.Events())
+
+	hub.Unsubscribe(recipient)
+	hub.Unsubscribe(outsider)
+	hub.Close()
+}
+
+func TestHubPublishSlowSubscription(t *testing.T) {
+	t.Parallel()
+
+	hub := realtime.NewHub(historySize)
+
+	subscription, err := hub.Subscribe(1, 0)
+	require.NoError(t, err)
+
+	event := domains.Event{
+		Type:       domains.EventTypeMessageCreated,
+		Recipients: []uint64{1},
+	}
+
+	// Subscription, which is not read, must be closed after overflow instead of blocking publisher:
+	for range cap(subscription.Events()) + 1 {
+		hub.Publish(context.Background(), event)
+	}
+
+	select {
+	case <-subscription.Done():
+	default:
+		t.Fatal("slow subscription was not closed")
+	}
+
+	hub.Unsubscribe(subscription)
+	hub.Close()
+}
+
+func TestHubSubscribeReplay(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		lastEventIndex  int // Index of last received event or -1, if no events were received
+		expectedIndexes []int
+	}{
+		{
+			name:            "no last event",
+			lastEventIndex:  -1,
+			expectedIndexes: nil,
+		},
+		{
+			name:            "missed events are in history",
+			lastEventIndex:  3,
+			expectedIndexes: []int{5},
+		},
+		{
+			name:            "part of missed events is overwritten",
+			lastEventIndex:  0,
+			expectedIndexes: []int{3, 5},
+		},
+		{
+			name:            "all events were received",
+			lastEventIndex:  5,
+			expectedIndexes: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			hub := realtime.NewHub(historySize)
+			publisher, err := hub.Subscribe(1, 0)
+			require.NoError(t, err)
+
+			// Events with odd indexes are addressed to User 2, others to User 3:
+			eventsIDs := make([]uint64, 6)
+			for i := range eventsIDs {
+				recipient := uint64(3)
+				if i%2 == 1 {
+					recipient = 2
+				}
+
+				hub.Publish(
+					context.Background(),
+					domains.Event{
+						Type:       domains.EventTypeMessageCreated,
+						Recipients: []uint64{1, recipient},
+					},
+				)
+
+				eventsIDs[i] = (<-publisher.Events()).ID
+			}
+
+			var lastEventID uint64
+			if tc.lastEventIndex >= 0 {
+				lastEventID = eventsIDs[tc.lastEventIndex]
+			}
+
+			subscription, err := hub.Subscribe(2, lastEventID)
+			require.NoError(t, err)
+
+			var replayedIDs []uint64
+			for range len(subscription.Events()) {
+				replayedIDs = append(replayedIDs, (<-subscription.Events()).ID)
+			}
+
+			var expectedIDs []uint64
+			for _, index := range tc.expectedIndexes {
+				expectedIDs = append(expectedIDs, eventsIDs[index])
+			}
+
+			require.Equal(t, expectedIDs, replayedIDs)
+
+			hub.Unsubscribe(publisher)
+			hub.Unsubscribe(subscription)
+			hub.Close()
+		})
+	}
+}
+
+func TestHubPublishEphemeral(t *testing.T) {
+	t.Parallel()
+
+	hub := realtime.NewHub(historySize)
+
+	subscription, err := hub.Subscribe(1, 0)
+	require.NoError(t, err)
+
+	hub.Publish(
+		context.Background(),
+		domains.Event{
+			Type:       domains.EventTypeMessageCreated,
+			Recipients: []uint64{1},
+		},
+	)
+
+	lastEventID := (<-subscription.Events()).ID
+
+	hub.Publish(
+		context.Background(),
+		domains.Event{
+			Type:       domains.EventTypeTyping,
+			Recipients: []uint64{1},
+			Ephemeral:  true,
+		},
+	)
+
+	// Ephemeral event is delivered to connected User without ID:
+	received := <-subscription.Events()
+	require.Equal(t, domains.EventTypeTyping, received.Type)
+	require.Zero(t, received.ID)
+
+	hub.Unsubscribe(subscription)
+
+	// Ephemeral event is not replayed after reconnection:
+	subscription, err = hub.Subscribe(1, lastEventID)
+	require.NoError(t, err)
+	require.Empty(t, subscription.Events())
+
+	hub.Unsubscribe(subscription)
+	hub.Close()
+}
+
+func TestHubPublishAssignedID(t *testing.T) {
+	t.Parallel()
+
+	// Hubs of two application instances receive the same events with IDs, assigned by broker:
+	firstHub := realtime.NewHub(historySize)
+	secondHub := realtime.NewHub(historySize)
+
+	subscription, err := firstHub.Subscribe(1, 0)
+	require.NoError(t, err)
+
+	firstEventID := uint64(time.Now().Add(time.Hour).UnixMicro())
+
+	for _, id := range []uint64{firstEventID, firstEventID + 1} {
+		event := domains.Event{
+			ID:         id,
+			Type:       domains.EventTypeMessageCreated,
+			Recipients: []uint64{1},
+		}
+
+		firstHub.Publish(context.Background(), event)
+		secondHub.Publish(context.Background(), event)
+	}
+
+	received := <-subscription.Events()
+	require.Equal(t, firstEventID, received.ID)
+
+	firstHub.Unsubscribe(subscription)
+
+	// User reconnects to other instance and receives missed event by the same ID:
+	subscription, err = secondHub.Subscribe(1, received.ID)
+	require.NoError(t, err)
+
+	missed := <-subscription.Events()
+	require.Equal(t, firstEventID+1, missed.ID)
+
+	// Event without assigned ID continues sequence of assigned IDs:
+	secondHub.Publish(
+		context.Background(),
+		domains.Event{
+			Type:       domains.EventTypeMessageCreated,
+			Recipients: []uint64{1},
+		},
+	)
+	require.Equal(t, firstEventID+2, (<-subscription.Events()).ID)
+
+	secondHub.Unsubscribe(subscription)
+	firstHub.Close()
+	secondHub.Close()
+}
+
+func TestHubClose(t *testing.T) {
+	t.Parallel()
+
+	hub := realtime.NewHub(historySize)
+
+	subscription, err := hub.Subscribe(1, 0)
+	require.NoError(t, err)
+
+	closed := make(chan struct{})
+
+	go func() {
+		hub.Close()
+		close(closed)
+	}()
+
+	// Close must notify subscription and wait until it is released:
+	<-subscription.Done()
+	hub.Unsubscribe(subscription)
+	<-closed
+
+	_, err = hub.Subscribe(1, 0)
+	require.ErrorIs(t, err, customerrors.ErrRealtimeHubClosed)
+}
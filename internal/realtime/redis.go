@@ -0,0 +1,175 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisEventsChannel = "kfc:realtime:events"
+	redisEventIDKey    = "kfc:realtime:last_event_id"
+)
+
+// nextEventIDScript increments shared events ID counter. Counter is raised to the provided current time in
+// microseconds, if it is behind, to keep IDs growing after Redis restart the same way, as hub does after
+// application restart.
+var nextEventIDScript = redis.NewScript(`
+local id = redis.call("INCR", KEYS[1])
+local now = tonumber(ARGV[1])
+if id < now then
+	redis.call("SET", KEYS[1], ARGV[1])
+	id = now
+end
+return id
+`)
+
+// RedisBroker relays events through Redis Pub/Sub to hubs of all application instances, so User receives
+// events regardless of instance, which he is connected to. Events are not stored in Redis, but their IDs are
+// assigned by shared counter in Redis, so User can replay missed events after reconnection to any instance.
+type RedisBroker struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+	hub    *Hub
+	logger logging.Logger
+	done   chan struct{}
+}
+
+// NewRedisBroker connects to Redis and starts to relay events from it to provided hub.
+// Broker should be stopped via Close.
+func NewRedisBroker(cacheConfig config.CacheConfig, hub *Hub, logger logging.Logger) (*RedisBroker, error) {
+	client := redis.NewClient(
+		&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cacheConfig.Host, cacheConfig.Port),
+			Password: cacheConfig.Password,
+		},
+	)
+
+	pubsub := client.Subscribe(context.Background(), redisEventsChannel)
+
+	// Waiting for subscription confirmation to not lose events, which are published right after start:
+	if _, err := pubsub.Receive(context.Background()); err != nil {
+		_ = pubsub.Close()
+		_ = client.Close()
+
+		return nil, err
+	}
+
+	broker := &RedisBroker{
+		client: client,
+		pubsub: pubsub,
+		hub:    hub,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+
+	go broker.relay()
+
+	return broker, nil
+}
+
+// Publish assigns ID to event and sends it to all application instances. If Redis is unavailable, event is
+// delivered only to Users, who are connected to current instance. Event, which could not get shared ID, is
+// delivered as ephemeral, since ID of current instance would not match IDs of other instances on replay.
+func (b *RedisBroker) Publish(ctx context.Context, event domains.Event) {
+	if !event.Ephemeral {
+		id, err := nextEventIDScript.Run(
+			ctx,
+			b.client,
+			[]string{redisEventIDKey},
+			time.Now().UnixMicro(),
+		).Uint64()
+		if err != nil {
+			logging.LogErrorContext(ctx, b.logger, "Failed to assign ID to real-time event in Redis", err)
+
+			event.Ephemeral = true
+			b.hub.Publish(ctx, event)
+
+			return
+		}
+
+		event.ID = id
+	}
+
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		logging.LogErrorContext(ctx, b.logger, "Failed to marshal real-time event payload", err)
+
+		return
+	}
+
+	message, err := json.Marshal(
+		redisEvent{
+			ID:         event.ID,
+			Type:       event.Type,
+			ChatID:     event.ChatID,
+			Payload:    payload,
+			Recipients: event.Recipients,
+			Ephemeral:  event.Ephemeral,
+		},
+	)
+	if err != nil {
+		logging.LogErrorContext(ctx, b.logger, "Failed to marshal real-time event", err)
+
+		return
+	}
+
+	if err = b.client.Publish(ctx, redisEventsChannel, message).Err(); err != nil {
+		logging.LogErrorContext(ctx, b.logger, "Failed to publish real-time event to Redis", err)
+		b.hub.Publish(ctx, event)
+	}
+}
+
+// Close stops relaying of events and closes connection to Redis.
+func (b *RedisBroker) Close() error {
+	if err := b.pubsub.Close(); err != nil {
+		return err
+	}
+
+	<-b.done
+
+	return b.client.Close()
+}
+
+// relay delivers events, received from Redis, to Users of current instance.
+func (b *RedisBroker) relay() {
+	defer close(b.done)
+
+	for message := range b.pubsub.Channel() {
+		var event redisEvent
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			logging.LogError(b.logger, "Failed to unmarshal real-time event from Redis", err)
+
+			continue
+		}
+
+		b.hub.Publish(
+			context.Background(),
+			domains.Event{
+				ID:         event.ID,
+				Type:       event.Type,
+				ChatID:     event.ChatID,
+				Payload:    event.Payload,
+				Recipients: event.Recipients,
+				Ephemeral:  event.Ephemeral,
+			},
+		)
+	}
+}
+
+// redisEvent is representation of domains.Event in Redis, which keeps fields, hidden from clients.
+// Payload is kept raw to deliver it to clients as is.
+type redisEvent struct {
+	ID         uint64            `json:"id,omitempty"` // Absent for ephemeral events.
+	Type       domains.EventType `json:"type"`
+	ChatID     uint64            `json:"chatId"`
+	Payload    json.RawMessage   `json:"payload"`
+	Recipients []uint64          `json:"recipients"`
+	Ephemeral  bool              `json:"ephemeral"`
+}
@@ -0,0 +1,640 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	attachmentsTableName           = "attachments"
+	attachmentThumbnailsTableName  = "attachment_thumbnails"
+	uploaderIDColumnName           = "uploader_id"
+	storageKeyColumnName           = "storage_key"
+	filenameColumnName             = "filename"
+	mimeTypeColumnName             = "mime_type"
+	sizeColumnName                 = "size"
+	checksumColumnName             = "checksum"
+	previewStatusColumnName        = "preview_status"
+	previewClaimedAtColumnName     = "preview_claimed_at"
+	widthColumnName                = "width"
+	heightColumnName               = "height"
+	blurhashColumnName             = "blurhash"
+	attachmentIDColumnName         = "attachment_id"
+	nameColumnName                 = "name"
+	skipLockedSuffix               = "FOR UPDATE SKIP LOCKED"
+	attachmentThumbnailsConstraint = "(attachment_id, name)"
+)
+
+// attachmentColumns lists attachments table columns in order of domains.Attachment fields:
+var attachmentColumns = []string{
+	attachmentsTableName + "." + idColumnName,
+	attachmentsTableName + "." + chatIDColumnName,
+	attachmentsTableName + "." + uploaderIDColumnName,
+	attachmentsTableName + "." + messageIDColumnName,
+	attachmentsTableName + "." + storageKeyColumnName,
+	attachmentsTableName + "." + filenameColumnName,
+	attachmentsTableName + "." + mimeTypeColumnName,
+	attachmentsTableName + "." + sizeColumnName,
+	attachmentsTableName + "." + checksumColumnName,
+	attachmentsTableName + "." + previewStatusColumnName,
+	attachmentsTableName + "." + widthColumnName,
+	attachmentsTableName + "." + heightColumnName,
+	attachmentsTableName + "." + blurhashColumnName,
+	attachmentsTableName + "." + createdAtColumnName,
+}
+
+// attachmentThumbnailColumns lists attachment_thumbnails table columns in order of domains.AttachmentThumbnail
+// fields:
+var attachmentThumbnailColumns = []string{
+	idColumnName,
+	attachmentIDColumnName,
+	nameColumnName,
+	storageKeyColumnName,
+	mimeTypeColumnName,
+	sizeColumnName,
+	widthColumnName,
+	heightColumnName,
+	createdAtColumnName,
+}
+
+type AttachmentsRepository struct {
+	tx pg.Transaction
+}
+
+func NewAttachmentsRepository(
+	tx pg.Transaction,
+) *AttachmentsRepository {
+	return &AttachmentsRepository{
+		tx: tx,
+	}
+}
+
+func (repo *AttachmentsRepository) CreateAttachment(
+	ctx context.Context,
+	attachmentData domains.CreateAttachmentDTO,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(attachmentsTableName).
+		Columns(
+			chatIDColumnName,
+			uploaderIDColumnName,
+			storageKeyColumnName,
+			filenameColumnName,
+			mimeTypeColumnName,
+			sizeColumnName,
+			checksumColumnName,
+			previewStatusColumnName,
+		).
+		Values(
+			attachmentData.ChatID,
+			attachmentData.UploaderID,
+			attachmentData.StorageKey,
+			attachmentData.Filename,
+			attachmentData.MIMEType,
+			attachmentData.Size,
+			attachmentData.Checksum,
+			attachmentData.PreviewStatus,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var attachmentID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&attachmentID); err != nil {
+		return 0, err
+	}
+
+	return attachmentID, nil
+}
+
+func (repo *AttachmentsRepository) GetAttachmentByID(
+	ctx context.Context,
+	id uint64,
+) (*domains.Attachment, error) {
+	stmt, params, err := sq.
+		Select(attachmentColumns...).
+		From(attachmentsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &domains.Attachment{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanAttachment(attachment)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentsByIDsForUpdate locks attachments till the end of transaction to not send them with several
+// messages concurrently.
+func (repo *AttachmentsRepository) GetAttachmentsByIDsForUpdate(
+	ctx context.Context,
+	ids []uint64,
+) ([]domains.Attachment, error) {
+	builder := sq.
+		Select(attachmentColumns...).
+		From(attachmentsTableName).
+		Where(sq.Eq{idColumnName: ids}).
+		OrderBy(idColumnName).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar)
+
+	return repo.getAttachments(ctx, builder)
+}
+
+// SetAttachmentsMessage links attachments to message, which they were sent with.
+func (repo *AttachmentsRepository) SetAttachmentsMessage(
+	ctx context.Context,
+	messageID uint64,
+	attachmentIDs []uint64,
+) error {
+	stmt, params, err := sq.
+		Update(attachmentsTableName).
+		Where(sq.Eq{idColumnName: attachmentIDs}).
+		Set(messageIDColumnName, messageID).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetMessagesAttachments returns attachments of provided messages in order of their upload.
+func (repo *AttachmentsRepository) GetMessagesAttachments(
+	ctx context.Context,
+	messageIDs []uint64,
+) ([]domains.Attachment, error) {
+	builder := sq.
+		Select(attachmentColumns...).
+		From(attachmentsTableName).
+		Where(sq.Eq{messageIDColumnName: messageIDs}).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar)
+
+	return repo.getAttachments(ctx, builder)
+}
+
+// ClaimAttachmentsForPreview marks pending attachments as processing and returns them. Attachments, which
+// are processed longer than since claimedBefore, are claimed again. Attachments, which are claimed
+// concurrently by other transaction, are skipped.
+func (repo *AttachmentsRepository) ClaimAttachmentsForPreview(
+	ctx context.Context,
+	limit uint64,
+	claimedBefore time.Time,
+) ([]domains.Attachment, error) {
+	claimableQuery := sq.
+		Select(idColumnName).
+		From(attachmentsTableName).
+		Where(
+			sq.Or{
+				sq.Eq{previewStatusColumnName: domains.AttachmentPreviewStatusPending},
+				sq.And{
+					sq.Eq{previewStatusColumnName: domains.AttachmentPreviewStatusProcessing},
+					sq.Lt{previewClaimedAtColumnName: claimedBefore},
+				},
+			},
+		).
+		OrderBy(idColumnName).
+		Limit(limit).
+		Suffix(skipLockedSuffix)
+
+	builder := sq.
+		Update(attachmentsTableName).
+		Where(sq.Expr(idColumnName+" IN (?)", claimableQuery)).
+		Set(previewStatusColumnName, domains.AttachmentPreviewStatusProcessing).
+		Set(previewClaimedAtColumnName, time.Now()).
+		Suffix("RETURNING " + strings.Join(attachmentColumns, ", ")).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	return repo.getAttachments(ctx, builder)
+}
+
+// UpdateAttachmentPreview saves result of preview generation. Only attachment, which is being processed,
+// is updated.
+func (repo *AttachmentsRepository) UpdateAttachmentPreview(
+	ctx context.Context,
+	previewData domains.UpdateAttachmentPreviewDTO,
+) error {
+	stmt, params, err := sq.
+		Update(attachmentsTableName).
+		Where(
+			sq.Eq{
+				idColumnName:            previewData.AttachmentID,
+				previewStatusColumnName: domains.AttachmentPreviewStatusProcessing,
+			},
+		).
+		Set(previewStatusColumnName, previewData.Status).
+		Set(widthColumnName, previewData.Width).
+		Set(heightColumnName, previewData.Height).
+		Set(blurhashColumnName, previewData.Blurhash).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// CreateAttachmentThumbnails saves thumbnails of attachment. Thumbnail, which already exists, is replaced,
+// because attachment could be processed again after its processing was interrupted.
+func (repo *AttachmentsRepository) CreateAttachmentThumbnails(
+	ctx context.Context,
+	thumbnails ...domains.CreateAttachmentThumbnailDTO,
+) error {
+	builder := sq.
+		Insert(attachmentThumbnailsTableName).
+		Columns(
+			attachmentIDColumnName,
+			nameColumnName,
+			storageKeyColumnName,
+			mimeTypeColumnName,
+			sizeColumnName,
+			widthColumnName,
+			heightColumnName,
+		)
+
+	for _, thumbnail := range thumbnails {
+		builder = builder.Values(
+			thumbnail.AttachmentID,
+			thumbnail.Name,
+			thumbnail.StorageKey,
+			thumbnail.MIMEType,
+			thumbnail.Size,
+			thumbnail.Width,
+			thumbnail.Height,
+		)
+	}
+
+	stmt, params, err := builder.
+		Suffix(
+			fmt.Sprintf(
+				"ON CONFLICT %s DO UPDATE SET %s",
+				attachmentThumbnailsConstraint,
+				strings.Join(
+					[]string{
+						storageKeyColumnName + " = EXCLUDED." + storageKeyColumnName,
+						mimeTypeColumnName + " = EXCLUDED." + mimeTypeColumnName,
+						sizeColumnName + " = EXCLUDED." + sizeColumnName,
+						widthColumnName + " = EXCLUDED." + widthColumnName,
+						heightColumnName + " = EXCLUDED." + heightColumnName,
+					},
+					", ",
+				),
+			),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetAttachmentsThumbnails returns thumbnails of provided attachments.
+func (repo *AttachmentsRepository) GetAttachmentsThumbnails(
+	ctx context.Context,
+	attachmentIDs []uint64,
+) (thumbnails []domains.AttachmentThumbnail, err error) {
+	stmt, params, err := sq.
+		Select(attachmentThumbnailColumns...).
+		From(attachmentThumbnailsTableName).
+		Where(sq.Eq{attachmentIDColumnName: attachmentIDs}).
+		OrderBy(attachmentIDColumnName, idColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		thumbnail := domains.AttachmentThumbnail{}
+
+		err = rows.Scan(
+			&thumbnail.ID,
+			&thumbnail.AttachmentID,
+			&thumbnail.Name,
+			&thumbnail.StorageKey,
+			&thumbnail.MIMEType,
+			&thumbnail.Size,
+			&thumbnail.Width,
+			&thumbnail.Height,
+			&thumbnail.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnails = append(thumbnails, thumbnail)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return thumbnails, nil
+}
+
+func (repo *AttachmentsRepository) getAttachments(
+	ctx context.Context,
+	builder sq.Sqlizer,
+) (attachments []domains.Attachment, err error) {
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		attachment := domains.Attachment{}
+
+		err = rows.Scan(scanAttachment(&attachment)...)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// GetUsedStorageKeys returns those of provided storage keys, which are still referenced by attachments or
+// their thumbnails. Content of other keys can be safely deleted from storage.
+func (repo *AttachmentsRepository) GetUsedStorageKeys(
+	ctx context.Context,
+	storageKeys []string,
+) (usedKeys []string, err error) {
+	thumbnailsQuery := sq.
+		Select(storageKeyColumnName).
+		From(attachmentThumbnailsTableName).
+		Where(sq.Eq{storageKeyColumnName: storageKeys})
+
+	stmt, params, err := sq.
+		Select(storageKeyColumnName).
+		From(attachmentsTableName).
+		Where(sq.Eq{storageKeyColumnName: storageKeys}).
+		Suffix("UNION ?", thumbnailsQuery).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		var storageKey string
+		if err = rows.Scan(&storageKey); err != nil {
+			return nil, err
+		}
+
+		usedKeys = append(usedKeys, storageKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return usedKeys, nil
+}
+
+// scanAttachment returns pointers on domains.Attachment fields.
+func scanAttachment(attachment *domains.Attachment) []any {
+	return []any{
+		&attachment.ID,
+		&attachment.ChatID,
+		&attachment.UploaderID,
+		&attachment.MessageID,
+		&attachment.StorageKey,
+		&attachment.Filename,
+		&attachment.MIMEType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.PreviewStatus,
+		&attachment.Width,
+		&attachment.Height,
+		&attachment.Blurhash,
+		&attachment.CreatedAt,
+	}
+}
+
+// CopyAttachment creates copy of attachment, which is sent with provided message of provided chat. Copy refers
+// to the same content in storage and keeps ready preview of original. Preview of copy, which original is
+// being processed, is generated again, because copy does not have its own claim.
+func (repo *AttachmentsRepository) CopyAttachment(
+	ctx context.Context,
+	attachmentID, chatID, messageID uint64,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(attachmentsTableName).
+		Columns(
+			chatIDColumnName,
+			messageIDColumnName,
+			uploaderIDColumnName,
+			storageKeyColumnName,
+			filenameColumnName,
+			mimeTypeColumnName,
+			sizeColumnName,
+			checksumColumnName,
+			previewStatusColumnName,
+			widthColumnName,
+			heightColumnName,
+			blurhashColumnName,
+		).
+		Select(
+			sq.
+				Select().
+				Column(sq.Expr("?", chatID)).
+				Column(sq.Expr("?", messageID)).
+				Columns(
+					uploaderIDColumnName,
+					storageKeyColumnName,
+					filenameColumnName,
+					mimeTypeColumnName,
+					sizeColumnName,
+					checksumColumnName,
+				).
+				Column(
+					sq.Expr(
+						fmt.Sprintf(
+							"CASE WHEN %s = ? THEN ? ELSE %s END",
+							previewStatusColumnName,
+							previewStatusColumnName,
+						),
+						domains.AttachmentPreviewStatusProcessing,
+						domains.AttachmentPreviewStatusPending,
+					),
+				).
+				Columns(
+					widthColumnName,
+					heightColumnName,
+					blurhashColumnName,
+				).
+				From(attachmentsTableName).
+				Where(sq.Eq{idColumnName: attachmentID}),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var copyID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&copyID); err != nil {
+		return 0, err
+	}
+
+	return copyID, nil
+}
+
+// CopyAttachmentThumbnails creates copies of attachment thumbnails for copy of attachment. Copies refer to the
+// same content in storage.
+func (repo *AttachmentsRepository) CopyAttachmentThumbnails(
+	ctx context.Context,
+	attachmentID, copyID uint64,
+) error {
+	stmt, params, err := sq.
+		Insert(attachmentThumbnailsTableName).
+		Columns(
+			attachmentIDColumnName,
+			nameColumnName,
+			storageKeyColumnName,
+			mimeTypeColumnName,
+			sizeColumnName,
+			widthColumnName,
+			heightColumnName,
+		).
+		Select(
+			sq.
+				Select().
+				Column(sq.Expr("?", copyID)).
+				Columns(
+					nameColumnName,
+					storageKeyColumnName,
+					mimeTypeColumnName,
+					sizeColumnName,
+					widthColumnName,
+					heightColumnName,
+				).
+				From(attachmentThumbnailsTableName).
+				Where(sq.Eq{attachmentIDColumnName: attachmentID}),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
@@ -0,0 +1,1511 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	chatsTableName              = "chats"
+	chatMembersTableName        = "chat_members"
+	chatIDColumnName            = "chat_id"
+	typeColumnName              = "type"
+	directKeyColumnName         = "direct_key"
+	titleColumnName             = "title"
+	handleColumnName            = "handle"
+	roleColumnName              = "role"
+	lastReadMessageIDColumnName = "last_read_message_id"
+	unreadCountColumnName       = "unread_count"
+	chatInvitesTableName        = "chat_invites"
+	chatInviteUsesTableName     = "chat_invite_uses"
+	inviteIDColumnName          = "invite_id"
+	tokenColumnName             = "token"
+	createdByColumnName         = "created_by"
+	expiresAtColumnName         = "expires_at"
+	maxUsesColumnName           = "max_uses"
+	usesCountColumnName         = "uses_count"
+	revokedAtColumnName         = "revoked_at"
+	messageTTLColumnName        = "message_ttl"
+	chatDraftsTableName         = "chat_drafts"
+	onConflictDoNothingByKey    = "ON CONFLICT (%s) DO NOTHING"
+	forUpdateSuffix             = "FOR UPDATE"
+)
+
+// chatColumns lists chats table columns in order of domains.Chat fields:
+var chatColumns = []string{
+	chatsTableName + "." + idColumnName,
+	chatsTableName + "." + typeColumnName,
+	chatsTableName + "." + titleColumnName,
+	chatsTableName + "." + handleColumnName,
+	chatsTableName + "." + createdAtColumnName,
+	chatsTableName + "." + updatedAtColumnName,
+	chatsTableName + "." + messageTTLColumnName,
+}
+
+// chatMemberColumns lists chat_members table columns in order of domains.ChatMember fields:
+var chatMemberColumns = []string{
+	chatMembersTableName + "." + idColumnName,
+	chatMembersTableName + "." + chatIDColumnName,
+	chatMembersTableName + "." + userIDColumnName,
+	chatMembersTableName + "." + roleColumnName,
+	chatMembersTableName + "." + lastReadMessageIDColumnName,
+	chatMembersTableName + "." + unreadCountColumnName,
+	chatMembersTableName + "." + createdAtColumnName,
+	chatMembersTableName + "." + updatedAtColumnName,
+}
+
+// chatInviteColumns lists chat_invites table columns in order of domains.ChatInvite fields:
+var chatInviteColumns = []string{
+	chatInvitesTableName + "." + idColumnName,
+	chatInvitesTableName + "." + chatIDColumnName,
+	chatInvitesTableName + "." + tokenColumnName,
+	chatInvitesTableName + "." + createdByColumnName,
+	chatInvitesTableName + "." + expiresAtColumnName,
+	chatInvitesTableName + "." + maxUsesColumnName,
+	chatInvitesTableName + "." + usesCountColumnName,
+	chatInvitesTableName + "." + revokedAtColumnName,
+	chatInvitesTableName + "." + createdAtColumnName,
+}
+
+// chatDraftColumns lists chat_drafts table columns in order of domains.ChatDraft fields:
+var chatDraftColumns = []string{
+	chatDraftsTableName + "." + idColumnName,
+	chatDraftsTableName + "." + chatIDColumnName,
+	chatDraftsTableName + "." + userIDColumnName,
+	chatDraftsTableName + "." + textColumnName,
+	chatDraftsTableName + "." + createdAtColumnName,
+	chatDraftsTableName + "." + updatedAtColumnName,
+}
+
+type ChatsRepository struct {
+	tx pg.Transaction
+}
+
+func NewChatsRepository(
+	tx pg.Transaction,
+) *ChatsRepository {
+	return &ChatsRepository{
+		tx: tx,
+	}
+}
+
+func (repo *ChatsRepository) GetChatByID(ctx context.Context, id uint64) (*domains.Chat, error) {
+	stmt, params, err := sq.
+		Select(chatColumns...).
+		From(chatsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	chat := &domains.Chat{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChat(chat)...); err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// GetChatByIDForUpdate locks chat till the end of transaction to serialize concurrent changes of its members.
+func (repo *ChatsRepository) GetChatByIDForUpdate(
+	ctx context.Context,
+	id uint64,
+) (*domains.Chat, error) {
+	stmt, params, err := sq.
+		Select(chatColumns...).
+		From(chatsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	chat := &domains.Chat{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChat(chat)...); err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func (repo *ChatsRepository) GetDirectChat(
+	ctx context.Context,
+	firstUserID, secondUserID uint64,
+) (*domains.Chat, error) {
+	stmt, params, err := sq.
+		Select(chatColumns...).
+		From(chatsTableName).
+		Where(sq.Eq{directKeyColumnName: buildDirectKey(firstUserID, secondUserID)}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	chat := &domains.Chat{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChat(chat)...); err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// CreateDirectChat creates direct chat for provided users. If such chat was created concurrently,
+// sql.ErrNoRows will be returned due to unique direct key.
+func (repo *ChatsRepository) CreateDirectChat(
+	ctx context.Context,
+	firstUserID, secondUserID uint64,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(chatsTableName).
+		Columns(
+			typeColumnName,
+			directKeyColumnName,
+		).
+		Values(
+			domains.ChatTypeDirect,
+			buildDirectKey(firstUserID, secondUserID),
+		).
+		Suffix(fmt.Sprintf(onConflictDoNothingByKey, directKeyColumnName)).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var chatID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&chatID); err != nil {
+		return 0, err
+	}
+
+	return chatID, nil
+}
+
+func (repo *ChatsRepository) CreateGroupChat(ctx context.Context, title string) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(chatsTableName).
+		Columns(
+			typeColumnName,
+			titleColumnName,
+		).
+		Values(
+			domains.ChatTypeGroup,
+			title,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var chatID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&chatID); err != nil {
+		return 0, err
+	}
+
+	return chatID, nil
+}
+
+// CreateChannel creates channel with optional public handle. If handle is already taken, sql.ErrNoRows will be
+// returned due to its uniqueness.
+func (repo *ChatsRepository) CreateChannel(ctx context.Context, title string, handle *string) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(chatsTableName).
+		Columns(
+			typeColumnName,
+			titleColumnName,
+			handleColumnName,
+		).
+		Values(
+			domains.ChatTypeChannel,
+			title,
+			handle,
+		).
+		Suffix(fmt.Sprintf(onConflictDoNothingByKey, handleColumnName)).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var chatID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&chatID); err != nil {
+		return 0, err
+	}
+
+	return chatID, nil
+}
+
+// GetChannels returns public channels, which have handle, along with their subscribers counts. Members of
+// channels are not loaded.
+func (repo *ChatsRepository) GetChannels(
+	ctx context.Context,
+	filters *domains.ChannelsFilters,
+	pagination *domains.Pagination,
+) (channels []domains.Chat, err error) {
+	subscribersCountQuery := sq.
+		Select("COUNT(*)").
+		From(chatMembersTableName).
+		Where(
+			fmt.Sprintf(
+				"%s.%s = %s.%s",
+				chatMembersTableName,
+				chatIDColumnName,
+				chatsTableName,
+				idColumnName,
+			),
+		)
+
+	builder := sq.
+		Select(chatColumns...).
+		Column(sq.Alias(subscribersCountQuery, "subscribers_count")).
+		From(chatsTableName).
+		Where(sq.Eq{chatsTableName + "." + typeColumnName: domains.ChatTypeChannel}).
+		Where(sq.NotEq{chatsTableName + "." + handleColumnName: nil}).
+		OrderBy(fmt.Sprintf("%s.%s %s", chatsTableName, idColumnName, desc)).
+		PlaceholderFormat(sq.Dollar)
+
+	if filters != nil && filters.Handle != nil && *filters.Handle != "" {
+		searchTerm := "%" + strings.ToLower(*filters.Handle) + "%"
+		builder = builder.Where(sq.ILike{chatsTableName + "." + handleColumnName: searchTerm})
+	}
+
+	if pagination != nil && pagination.Limit != nil {
+		builder = builder.Limit(*pagination.Limit)
+	}
+
+	if pagination != nil && pagination.Offset != nil {
+		builder = builder.Offset(*pagination.Offset)
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		channel := domains.Chat{}
+		if err = rows.Scan(append(scanChat(&channel), &channel.SubscribersCount)...); err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, channel)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+func (repo *ChatsRepository) DeleteChat(ctx context.Context, id uint64) error {
+	stmt, params, err := sq.
+		Delete(chatsTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *ChatsRepository) AddChatMembers(
+	ctx context.Context,
+	chatID uint64,
+	role domains.ChatMemberRole,
+	userIDs ...uint64,
+) error {
+	builder := sq.
+		Insert(chatMembersTableName).
+		Columns(
+			chatIDColumnName,
+			userIDColumnName,
+			roleColumnName,
+		).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	for _, userID := range userIDs {
+		builder = builder.Values(chatID, userID, role)
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *ChatsRepository) GetUserChats(
+	ctx context.Context,
+	userID uint64,
+) ([]domains.Chat, error) {
+	stmt, params, err := sq.
+		Select(chatColumns...).
+		From(chatsTableName).
+		InnerJoin(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s",
+				chatMembersTableName,
+				chatMembersTableName,
+				chatIDColumnName,
+				chatsTableName,
+				idColumnName,
+			),
+		).
+		Where(sq.Eq{chatMembersTableName + "." + userIDColumnName: userID}).
+		OrderBy(
+			fmt.Sprintf("%s.%s %s", chatsTableName, updatedAtColumnName, desc),
+			fmt.Sprintf("%s.%s %s", chatsTableName, idColumnName, desc),
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	var chats []domains.Chat
+
+	for rows.Next() {
+		chat := domains.Chat{}
+
+		err = rows.Scan(scanChat(&chat)...)
+		if err != nil {
+			return nil, err
+		}
+
+		chats = append(chats, chat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return chats, nil
+}
+
+func (repo *ChatsRepository) GetChatsMembers(
+	ctx context.Context,
+	chatIDs []uint64,
+) ([]domains.ChatMember, error) {
+	stmt, params, err := sq.
+		Select(chatMemberColumns...).
+		From(chatMembersTableName).
+		Where(sq.Eq{chatIDColumnName: chatIDs}).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, asc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	var members []domains.ChatMember
+
+	for rows.Next() {
+		member := domains.ChatMember{}
+
+		err = rows.Scan(scanChatMember(&member)...)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetChatMembersIDs returns IDs of all Users, who are members of chat. Unlike GetChatsMembers, only IDs are
+// selected, so it is used to find recipients of chat events, including events of channels with many subscribers.
+func (repo *ChatsRepository) GetChatMembersIDs(ctx context.Context, chatID uint64) (ids []uint64, err error) {
+	stmt, params, err := sq.
+		Select(userIDColumnName).
+		From(chatMembersTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, asc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		var id uint64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetChatsMembersCounts returns numbers of members by chats IDs. Chats without members are skipped.
+func (repo *ChatsRepository) GetChatsMembersCounts(
+	ctx context.Context,
+	chatIDs []uint64,
+) (counts map[uint64]uint64, err error) {
+	stmt, params, err := sq.
+		Select(
+			chatIDColumnName,
+			"COUNT(*)",
+		).
+		From(chatMembersTableName).
+		Where(sq.Eq{chatIDColumnName: chatIDs}).
+		GroupBy(chatIDColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	counts = make(map[uint64]uint64)
+
+	for rows.Next() {
+		var chatID, count uint64
+		if err = rows.Scan(&chatID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[chatID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (repo *ChatsRepository) GetChatMember(
+	ctx context.Context,
+	chatID, userID uint64,
+) (*domains.ChatMember, error) {
+	stmt, params, err := sq.
+		Select(chatMemberColumns...).
+		From(chatMembersTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	member := &domains.ChatMember{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChatMember(member)...); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// GetChatMemberForUpdate locks membership of User till the end of transaction.
+func (repo *ChatsRepository) GetChatMemberForUpdate(
+	ctx context.Context,
+	chatID, userID uint64,
+) (*domains.ChatMember, error) {
+	stmt, params, err := sq.
+		Select(chatMemberColumns...).
+		From(chatMembersTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	member := &domains.ChatMember{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChatMember(member)...); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// IncrementUnreadCounters increments unread messages counters of all chat members except provided User by
+// number of new messages.
+func (repo *ChatsRepository) IncrementUnreadCounters(
+	ctx context.Context,
+	chatID, exceptUserID, delta uint64,
+) error {
+	stmt, params, err := sq.
+		Update(chatMembersTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		Where(sq.NotEq{userIDColumnName: exceptUserID}).
+		Set(unreadCountColumnName, sq.Expr(unreadCountColumnName+" + ?", delta)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// UpdateLastReadMessage moves read pointer of User to provided message and recalculates unread messages
+// counter. Counter is calculated only by messages after read pointer using chat history index.
+func (repo *ChatsRepository) UpdateLastReadMessage(
+	ctx context.Context,
+	chatID, userID, messageID uint64,
+) error {
+	unreadCountQuery := sq.
+		Select("COUNT(*)").
+		From(messagesTableName).
+		Where(
+			sq.Eq{
+				messagesTableName + "." + chatIDColumnName: chatID,
+			},
+		).
+		Where(sq.Eq{messagesTableName + "." + threadRootIDColumnName: nil}). // Replies are counted in threads
+		Where(sq.Gt{messagesTableName + "." + idColumnName: messageID}).
+		Where(sq.NotEq{messagesTableName + "." + authorIDColumnName: userID}).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		Where(notDeletedForUserCondition(userID))
+
+	stmt, params, err := sq.
+		Update(chatMembersTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		Set(lastReadMessageIDColumnName, messageID).
+		Set(unreadCountColumnName, sq.Expr("(?)", unreadCountQuery)).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// RecountUnreadCounters recalculates unread messages counters of chat members, whose read pointer is before
+// provided message. Used, when messages are deleted after being counted as unread.
+func (repo *ChatsRepository) RecountUnreadCounters(
+	ctx context.Context,
+	chatID, fromMessageID uint64,
+) error {
+	unreadCountQuery := sq.
+		Select("COUNT(*)").
+		From(messagesTableName).
+		Where(
+			fmt.Sprintf(
+				"%s.%s = %s.%s",
+				messagesTableName,
+				chatIDColumnName,
+				chatMembersTableName,
+				chatIDColumnName,
+			),
+		).
+		Where(sq.Eq{messagesTableName + "." + threadRootIDColumnName: nil}). // Replies are counted in threads
+		Where(
+			fmt.Sprintf(
+				"%s.%s > COALESCE(%s.%s, 0)",
+				messagesTableName,
+				idColumnName,
+				chatMembersTableName,
+				lastReadMessageIDColumnName,
+			),
+		).
+		Where(
+			fmt.Sprintf(
+				"%s.%s <> %s.%s",
+				messagesTableName,
+				authorIDColumnName,
+				chatMembersTableName,
+				userIDColumnName,
+			),
+		).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		Where(
+			fmt.Sprintf(
+				"NOT EXISTS (SELECT 1 FROM %s WHERE %s.%s = %s.%s AND %s.%s = %s.%s)",
+				messageDeletionsTableName,
+				messageDeletionsTableName,
+				messageIDColumnName,
+				messagesTableName,
+				idColumnName,
+				messageDeletionsTableName,
+				userIDColumnName,
+				chatMembersTableName,
+				userIDColumnName,
+			),
+		)
+
+	stmt, params, err := sq.
+		Update(chatMembersTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		Where(sq.Expr("COALESCE("+lastReadMessageIDColumnName+", 0) < ?", fromMessageID)).
+		Set(unreadCountColumnName, sq.Expr("(?)", unreadCountQuery)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetUnreadThreadsCounts returns numbers of threads with unread replies, which User joined, by chats IDs.
+// Chats without such threads are skipped.
+func (repo *ChatsRepository) GetUnreadThreadsCounts(
+	ctx context.Context,
+	userID uint64,
+	chatIDs []uint64,
+) (counts map[uint64]uint64, err error) {
+	stmt, params, err := sq.
+		Select(
+			chatIDColumnName,
+			"COUNT(*)",
+		).
+		From(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				userIDColumnName: userID,
+				chatIDColumnName: chatIDs,
+			},
+		).
+		Where(sq.Gt{unreadCountColumnName: 0}).
+		GroupBy(chatIDColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	counts = make(map[uint64]uint64)
+
+	for rows.Next() {
+		var chatID, count uint64
+		if err = rows.Scan(&chatID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[chatID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetChannelsUnreadCounts returns numbers of unread messages of User by channels IDs. Counters of channels
+// subscribers are not incremented on posting, so they are calculated from last read message of User in the
+// same way as on reading. Channels without unread messages are skipped.
+func (repo *ChatsRepository) GetChannelsUnreadCounts(
+	ctx context.Context,
+	userID uint64,
+	chatIDs []uint64,
+) (counts map[uint64]uint64, err error) {
+	stmt, params, err := sq.
+		Select(
+			messagesTableName+"."+chatIDColumnName,
+			"COUNT(*)",
+		).
+		From(messagesTableName).
+		Join(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s AND %s.%s = ?",
+				chatMembersTableName,
+				chatMembersTableName,
+				chatIDColumnName,
+				messagesTableName,
+				chatIDColumnName,
+				chatMembersTableName,
+				userIDColumnName,
+			),
+			userID,
+		).
+		Where(sq.Eq{messagesTableName + "." + chatIDColumnName: chatIDs}).
+		Where(sq.Eq{messagesTableName + "." + threadRootIDColumnName: nil}). // Replies are counted in threads
+		Where(sq.NotEq{messagesTableName + "." + authorIDColumnName: userID}).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		Where(notDeletedForUserCondition(userID)).
+		Where(
+			fmt.Sprintf(
+				"%s.%s > COALESCE(%s.%s, 0)",
+				messagesTableName,
+				idColumnName,
+				chatMembersTableName,
+				lastReadMessageIDColumnName,
+			),
+		).
+		GroupBy(messagesTableName + "." + chatIDColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	counts = make(map[uint64]uint64)
+
+	for rows.Next() {
+		var chatID, count uint64
+		if err = rows.Scan(&chatID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[chatID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (repo *ChatsRepository) UpdateChatMemberRole(
+	ctx context.Context,
+	chatID, userID uint64,
+	role domains.ChatMemberRole,
+) error {
+	stmt, params, err := sq.
+		Update(chatMembersTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		Set(roleColumnName, role).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *ChatsRepository) DeleteChatMember(ctx context.Context, chatID, userID uint64) error {
+	stmt, params, err := sq.
+		Delete(chatMembersTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// DeleteThreadParticipants removes User from all threads of chat, so he does not receive unread replies of
+// chat, which he is not a member of anymore.
+func (repo *ChatsRepository) DeleteThreadParticipants(ctx context.Context, chatID, userID uint64) error {
+	stmt, params, err := sq.
+		Delete(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				chatIDColumnName: chatID,
+				userIDColumnName: userID,
+			},
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// TouchChat updates chat's last activity time, which is used for chats ordering.
+func (repo *ChatsRepository) TouchChat(ctx context.Context, chatID uint64) error {
+	stmt, params, err := sq.
+		Update(chatsTableName).
+		Where(sq.Eq{idColumnName: chatID}).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// UpdateChatMessageTTL sets lifetime of messages, which are sent to chat after this change.
+func (repo *ChatsRepository) UpdateChatMessageTTL(
+	ctx context.Context,
+	chatID uint64,
+	messageTTL domains.MessageTTL,
+) error {
+	stmt, params, err := sq.
+		Update(chatsTableName).
+		Where(sq.Eq{idColumnName: chatID}).
+		Set(messageTTLColumnName, messageTTL).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *ChatsRepository) CreateChatInvite(
+	ctx context.Context,
+	inviteData domains.CreateChatInviteDTO,
+	token string,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(chatInvitesTableName).
+		Columns(
+			chatIDColumnName,
+			tokenColumnName,
+			createdByColumnName,
+			expiresAtColumnName,
+			maxUsesColumnName,
+		).
+		Values(
+			inviteData.ChatID,
+			token,
+			inviteData.ActorID,
+			inviteData.ExpiresAt,
+			inviteData.MaxUses,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var inviteID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&inviteID); err != nil {
+		return 0, err
+	}
+
+	return inviteID, nil
+}
+
+func (repo *ChatsRepository) GetChatInviteByID(ctx context.Context, id uint64) (*domains.ChatInvite, error) {
+	return repo.getChatInvite(ctx, sq.Eq{idColumnName: id})
+}
+
+func (repo *ChatsRepository) GetChatInviteByToken(
+	ctx context.Context,
+	token string,
+) (*domains.ChatInvite, error) {
+	return repo.getChatInvite(ctx, sq.Eq{tokenColumnName: token})
+}
+
+// GetChatInvites returns all invites of the chat, including inactive ones, starting from the newest.
+func (repo *ChatsRepository) GetChatInvites(
+	ctx context.Context,
+	chatID uint64,
+) (invites []domains.ChatInvite, err error) {
+	stmt, params, err := sq.
+		Select(chatInviteColumns...).
+		From(chatInvitesTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, desc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		invite := domains.ChatInvite{}
+		if err = rows.Scan(scanChatInvite(&invite)...); err != nil {
+			return nil, err
+		}
+
+		invites = append(invites, invite)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// RevokeChatInvite marks invite as revoked. Revocation time of already revoked invite is kept.
+func (repo *ChatsRepository) RevokeChatInvite(ctx context.Context, id uint64) error {
+	stmt, params, err := sq.
+		Update(chatInvitesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Where(sq.Eq{revokedAtColumnName: nil}).
+		Set(revokedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// IncrementChatInviteUses increments number of invite uses. Use itself should be recorded by
+// CreateChatInviteUse.
+func (repo *ChatsRepository) IncrementChatInviteUses(ctx context.Context, id uint64) error {
+	stmt, params, err := sq.
+		Update(chatInvitesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Set(usesCountColumnName, sq.Expr(usesCountColumnName+" + 1")).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// CreateChatInviteUse records, that User joined chat by invite.
+func (repo *ChatsRepository) CreateChatInviteUse(ctx context.Context, inviteID, userID uint64) error {
+	stmt, params, err := sq.
+		Insert(chatInviteUsesTableName).
+		Columns(
+			inviteIDColumnName,
+			userIDColumnName,
+		).
+		Values(
+			inviteID,
+			userID,
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *ChatsRepository) getChatInvite(ctx context.Context, condition sq.Eq) (*domains.ChatInvite, error) {
+	stmt, params, err := sq.
+		Select(chatInviteColumns...).
+		From(chatInvitesTableName).
+		Where(condition).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &domains.ChatInvite{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChatInvite(invite)...); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// SaveChatDraft creates draft of User in chat or replaces text of existing one.
+func (repo *ChatsRepository) SaveChatDraft(
+	ctx context.Context,
+	draftData domains.SaveChatDraftDTO,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(chatDraftsTableName).
+		Columns(
+			chatIDColumnName,
+			userIDColumnName,
+			textColumnName,
+			updatedAtColumnName,
+		).
+		Values(
+			draftData.ChatID,
+			draftData.UserID,
+			draftData.Text,
+			time.Now(),
+		).
+		Suffix(
+			fmt.Sprintf(
+				"ON CONFLICT (%s, %s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s",
+				chatIDColumnName,
+				userIDColumnName,
+				textColumnName,
+				textColumnName,
+				updatedAtColumnName,
+				updatedAtColumnName,
+			),
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var draftID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&draftID); err != nil {
+		return 0, err
+	}
+
+	return draftID, nil
+}
+
+// GetChatDraft returns draft of User in chat. If User has no draft, sql.ErrNoRows will be returned.
+func (repo *ChatsRepository) GetChatDraft(ctx context.Context, chatID, userID uint64) (*domains.ChatDraft, error) {
+	stmt, params, err := sq.
+		Select(chatDraftColumns...).
+		From(chatDraftsTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &domains.ChatDraft{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanChatDraft(draft)...); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// GetUserChatDrafts returns drafts of User in provided chats.
+func (repo *ChatsRepository) GetUserChatDrafts(
+	ctx context.Context,
+	userID uint64,
+	chatIDs []uint64,
+) (drafts []domains.ChatDraft, err error) {
+	stmt, params, err := sq.
+		Select(chatDraftColumns...).
+		From(chatDraftsTableName).
+		Where(sq.Eq{userIDColumnName: userID}).
+		Where(sq.Eq{chatIDColumnName: chatIDs}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		draft := domains.ChatDraft{}
+		if err = rows.Scan(scanChatDraft(&draft)...); err != nil {
+			return nil, err
+		}
+
+		drafts = append(drafts, draft)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
+
+// DeleteChatDraft deletes draft of User in chat and reports, whether draft existed.
+func (repo *ChatsRepository) DeleteChatDraft(ctx context.Context, chatID, userID uint64) (bool, error) {
+	stmt, params, err := sq.
+		Delete(chatDraftsTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// scanChat returns pointers on domains.Chat fields, which are stored in chats table.
+func scanChat(chat *domains.Chat) []any {
+	return []any{
+		&chat.ID,
+		&chat.Type,
+		&chat.Title,
+		&chat.Handle,
+		&chat.CreatedAt,
+		&chat.UpdatedAt,
+		&chat.MessageTTL,
+	}
+}
+
+// scanChatMember returns pointers on domains.ChatMember fields, which are stored in chat_members table.
+func scanChatMember(member *domains.ChatMember) []any {
+	return []any{
+		&member.ID,
+		&member.ChatID,
+		&member.UserID,
+		&member.Role,
+		&member.LastReadMessageID,
+		&member.UnreadCount,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	}
+}
+
+// scanChatInvite returns pointers on domains.ChatInvite fields, which are stored in chat_invites table.
+func scanChatInvite(invite *domains.ChatInvite) []any {
+	return []any{
+		&invite.ID,
+		&invite.ChatID,
+		&invite.Token,
+		&invite.CreatedBy,
+		&invite.ExpiresAt,
+		&invite.MaxUses,
+		&invite.UsesCount,
+		&invite.RevokedAt,
+		&invite.CreatedAt,
+	}
+}
+
+// scanChatDraft returns pointers on domains.ChatDraft fields, which are stored in chat_drafts table.
+func scanChatDraft(draft *domains.ChatDraft) []any {
+	return []any{
+		&draft.ID,
+		&draft.ChatID,
+		&draft.UserID,
+		&draft.Text,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+	}
+}
+
+// buildDirectKey builds unique key of direct chat, which does not depend on users order.
+func buildDirectKey(firstUserID, secondUserID uint64) string {
+	if firstUserID > secondUserID {
+		firstUserID, secondUserID = secondUserID, firstUserID
+	}
+
+	return fmt.Sprintf("%d:%d", firstUserID, secondUserID)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDirectKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		firstUserID  uint64
+		secondUserID uint64
+		expected     string
+	}{
+		{
+			name:         "ordered users",
+			firstUserID:  1,
+			secondUserID: 2,
+			expected:     "1:2",
+		},
+		{
+			name:         "reversed users",
+			firstUserID:  20,
+			secondUserID: 3,
+			expected:     "3:20",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, buildDirectKey(tc.firstUserID, tc.secondUserID))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+)
+
+// MemoryPresenceRepository keeps presence of Users in memory of current instance. It behaves the same way
+// as PresenceRepository and is suitable for tests and single-node runs.
+type MemoryPresenceRepository struct {
+	mu          sync.Mutex
+	connections map[uint64]memoryConnections
+	lastSeenAt  map[uint64]time.Time
+}
+
+func NewMemoryPresenceRepository() *MemoryPresenceRepository {
+	return &MemoryPresenceRepository{
+		connections: make(map[uint64]memoryConnections),
+		lastSeenAt:  make(map[uint64]time.Time),
+	}
+}
+
+func (repo *MemoryPresenceRepository) AddConnection(
+	_ context.Context,
+	userID uint64,
+	ttl time.Duration,
+) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	connections := repo.getConnections(userID)
+	connections.count++
+	connections.expiresAt = time.Now().Add(ttl)
+	repo.connections[userID] = connections
+
+	return connections.count, nil
+}
+
+func (repo *MemoryPresenceRepository) ProlongConnections(
+	_ context.Context,
+	userID uint64,
+	ttl time.Duration,
+	lastSeenAt time.Time,
+) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	connections := repo.getConnections(userID)
+	if connections.count == 0 { // Counter has already expired, but connection is still alive
+		connections.count = 1
+	}
+
+	connections.expiresAt = time.Now().Add(ttl)
+	repo.connections[userID] = connections
+	repo.lastSeenAt[userID] = lastSeenAt
+
+	return nil
+}
+
+func (repo *MemoryPresenceRepository) RemoveConnection(
+	_ context.Context,
+	userID uint64,
+	lastSeenAt time.Time,
+) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	connections := repo.getConnections(userID)
+	if connections.count > 1 {
+		connections.count--
+		repo.connections[userID] = connections
+
+		return connections.count, nil
+	}
+
+	delete(repo.connections, userID)
+	repo.lastSeenAt[userID] = lastSeenAt
+
+	return 0, nil
+}
+
+func (repo *MemoryPresenceRepository) GetPresence(
+	_ context.Context,
+	userID uint64,
+) (*domains.Presence, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	presence := &domains.Presence{
+		UserID: userID,
+		Online: repo.getConnections(userID).count > 0,
+	}
+
+	if lastSeenAt, ok := repo.lastSeenAt[userID]; ok {
+		presence.LastSeenAt = &lastSeenAt
+	}
+
+	return presence, nil
+}
+
+func (repo *MemoryPresenceRepository) GetPresences(
+	_ context.Context,
+	userIDs []uint64,
+) ([]domains.Presence, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	presences := make([]domains.Presence, len(userIDs))
+	for i, userID := range userIDs {
+		presences[i] = domains.Presence{
+			UserID: userID,
+			Online: repo.getConnections(userID).count > 0,
+		}
+
+		if lastSeenAt, ok := repo.lastSeenAt[userID]; ok {
+			presences[i].LastSeenAt = &lastSeenAt
+		}
+	}
+
+	return presences, nil
+}
+
+// getConnections returns connections of User, treating expired ones as absent.
+func (repo *MemoryPresenceRepository) getConnections(userID uint64) memoryConnections {
+	connections, ok := repo.connections[userID]
+	if !ok || time.Now().After(connections.expiresAt) {
+		return memoryConnections{}
+	}
+
+	return connections
+}
+
+type memoryConnections struct {
+	count     int64
+	expiresAt time.Time
+}
@@ -0,0 +1,2843 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	messagesTableName                = "messages"
+	messageRevisionsTableName        = "message_revisions"
+	messageDeletionsTableName        = "message_deletions"
+	messageReactionsTableName        = "message_reactions"
+	threadParticipantsTableName      = "thread_participants"
+	messageMentionsTableName         = "message_mentions"
+	messagePinsTableName             = "message_pins"
+	scheduledMessagesTableName       = "scheduled_messages"
+	pollsTableName                   = "polls"
+	pollOptionsTableName             = "poll_options"
+	pollVotesTableName               = "poll_votes"
+	linkPreviewsTableName            = "link_previews"
+	messageLinkPreviewsTableName     = "message_link_previews"
+	systemEventColumnName            = "system_event"
+	pinnedByColumnName               = "pinned_by"
+	authorIDColumnName               = "author_id"
+	messageIDColumnName              = "message_id"
+	textColumnName                   = "text"
+	editedAtColumnName               = "edited_at"
+	deletedAtColumnName              = "deleted_at"
+	emojiColumnName                  = "emoji"
+	replyToMessageIDColumnName       = "reply_to_message_id"
+	threadRootIDColumnName           = "thread_root_id"
+	threadReplyCountColumnName       = "thread_reply_count"
+	threadLastReplyAtColumnName      = "thread_last_reply_at"
+	searchVectorColumnName           = "search_vector"
+	forwardedFromMessageIDColumnName = "forwarded_from_message_id"
+	forwardedFromChatIDColumnName    = "forwarded_from_chat_id"
+	forwardedFromAuthorIDColumnName  = "forwarded_from_author_id"
+	sendAtColumnName                 = "send_at"
+	failedAtColumnName               = "failed_at"
+	questionColumnName               = "question"
+	multipleChoiceColumnName         = "multiple_choice"
+	anonymousColumnName              = "anonymous"
+	closesAtColumnName               = "closes_at"
+	pollIDColumnName                 = "poll_id"
+	optionIDColumnName               = "option_id"
+	urlColumnName                    = "url"
+	statusColumnName                 = "status"
+	claimedAtColumnName              = "claimed_at"
+	descriptionColumnName            = "description"
+	siteNameColumnName               = "site_name"
+	imageURLColumnName               = "image_url"
+	linkPreviewIDColumnName          = "link_preview_id"
+	expiredMessagesAlias             = "expired_messages"
+	threadRepliesAlias               = "replies"
+
+	// Search query is built for both languages of search vector. Headline is built with russian
+	// configuration, which stems latin words with english stemmer, so matches in both languages are highlighted:
+	searchQueryExpression    = "(websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?))"
+	searchHeadlineExpression = "ts_headline('russian', %s.%s, %s, " +
+		"'StartSel=" + searchHighlightStart + ", StopSel=" + searchHighlightStop + ", " +
+		"MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=\" ... \"')"
+	searchRankExpression = "ts_rank_cd(%s.%s, %s)"
+	searchRankAlias      = "rank"
+
+	// Private use characters are used as highlight markers, because they do not appear in regular text and
+	// survive HTML escaping of snippet:
+	searchHighlightStart = "\ue000"
+	searchHighlightStop  = "\ue001"
+)
+
+// mentionColumns lists message_mentions table columns in order of domains.Mention fields:
+var mentionColumns = []string{
+	messageMentionsTableName + "." + idColumnName,
+	messageMentionsTableName + "." + messageIDColumnName,
+	messageMentionsTableName + "." + chatIDColumnName,
+	messageMentionsTableName + "." + userIDColumnName,
+	messageMentionsTableName + "." + typeColumnName,
+	messageMentionsTableName + "." + createdAtColumnName,
+}
+
+// messageColumns lists messages table columns in order of domains.Message fields:
+var messageColumns = []string{
+	messagesTableName + "." + idColumnName,
+	messagesTableName + "." + chatIDColumnName,
+	messagesTableName + "." + authorIDColumnName,
+	messagesTableName + "." + typeColumnName,
+	messagesTableName + "." + systemEventColumnName,
+	messagesTableName + "." + textColumnName,
+	messagesTableName + "." + createdAtColumnName,
+	messagesTableName + "." + updatedAtColumnName,
+	messagesTableName + "." + editedAtColumnName,
+	messagesTableName + "." + deletedAtColumnName,
+	messagesTableName + "." + replyToMessageIDColumnName,
+	messagesTableName + "." + threadRootIDColumnName,
+	messagesTableName + "." + threadReplyCountColumnName,
+	messagesTableName + "." + threadLastReplyAtColumnName,
+	messagesTableName + "." + expiresAtColumnName,
+	messagesTableName + "." + forwardedFromMessageIDColumnName,
+	messagesTableName + "." + forwardedFromChatIDColumnName,
+	messagesTableName + "." + forwardedFromAuthorIDColumnName,
+}
+
+// threadParticipantColumns lists thread_participants table columns in order of domains.ThreadParticipant fields:
+var threadParticipantColumns = []string{
+	threadParticipantsTableName + "." + idColumnName,
+	threadParticipantsTableName + "." + threadRootIDColumnName,
+	threadParticipantsTableName + "." + chatIDColumnName,
+	threadParticipantsTableName + "." + userIDColumnName,
+	threadParticipantsTableName + "." + lastReadMessageIDColumnName,
+	threadParticipantsTableName + "." + unreadCountColumnName,
+	threadParticipantsTableName + "." + createdAtColumnName,
+	threadParticipantsTableName + "." + updatedAtColumnName,
+}
+
+type MessagesRepository struct {
+	tx pg.Transaction
+}
+
+func NewMessagesRepository(
+	tx pg.Transaction,
+) *MessagesRepository {
+	return &MessagesRepository{
+		tx: tx,
+	}
+}
+
+func (repo *MessagesRepository) CreateMessage(
+	ctx context.Context,
+	messageData domains.CreateMessageDTO,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(messagesTableName).
+		Columns(
+			chatIDColumnName,
+			authorIDColumnName,
+			typeColumnName,
+			systemEventColumnName,
+			textColumnName,
+			replyToMessageIDColumnName,
+			threadRootIDColumnName,
+			expiresAtColumnName,
+			forwardedFromMessageIDColumnName,
+			forwardedFromChatIDColumnName,
+			forwardedFromAuthorIDColumnName,
+		).
+		Values(
+			messageData.ChatID,
+			messageData.AuthorID,
+			messageData.Type,
+			jsonColumn[domains.SystemEvent]{value: &messageData.SystemEvent},
+			messageData.Text,
+			messageData.ReplyToMessageID,
+			messageData.ThreadRootID,
+			messageData.ExpiresAt,
+			messageData.ForwardedFromMessageID,
+			messageData.ForwardedFromChatID,
+			messageData.ForwardedFromAuthorID,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var messageID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&messageID); err != nil {
+		return 0, err
+	}
+
+	return messageID, nil
+}
+
+func (repo *MessagesRepository) GetMessageByID(
+	ctx context.Context,
+	id uint64,
+) (*domains.Message, error) {
+	stmt, params, err := sq.
+		Select(messageColumns...).
+		From(messagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domains.Message{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanMessage(message)...); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetMessageByIDForUpdate locks message till the end of transaction to serialize its concurrent changes.
+func (repo *MessagesRepository) GetMessageByIDForUpdate(
+	ctx context.Context,
+	id uint64,
+) (*domains.Message, error) {
+	stmt, params, err := sq.
+		Select(messageColumns...).
+		From(messagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domains.Message{}
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanMessage(message)...); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetChatMessagesByIDs returns provided messages of chat, which are visible to User, from older to newer.
+// Messages of other chats, deleted for everyone messages and messages, which were deleted by User only for
+// himself, are skipped.
+func (repo *MessagesRepository) GetChatMessagesByIDs(
+	ctx context.Context,
+	chatID, userID uint64,
+	ids []uint64,
+) (messages []domains.Message, err error) {
+	stmt, params, err := sq.
+		Select(messageColumns...).
+		From(messagesTableName).
+		Where(
+			sq.Eq{
+				messagesTableName + "." + idColumnName:        ids,
+				messagesTableName + "." + chatIDColumnName:    chatID,
+				messagesTableName + "." + deletedAtColumnName: nil,
+			},
+		).
+		Where(notDeletedForUserCondition(userID)).
+		OrderBy(fmt.Sprintf("%s.%s %s", messagesTableName, idColumnName, asc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		message := domains.Message{}
+
+		err = rows.Scan(scanMessage(&message)...)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// GetChatMessages returns chat messages using keyset pagination by message ID. Replies in threads and messages,
+// which were deleted by User only for himself, are skipped. Messages are always returned from older to newer.
+func (repo *MessagesRepository) GetChatMessages(
+	ctx context.Context,
+	chatID, userID uint64,
+	cursor domains.MessagesCursor,
+) ([]domains.Message, error) {
+	return repo.getMessages(
+		ctx,
+		sq.Eq{
+			chatIDColumnName:       chatID,
+			threadRootIDColumnName: nil,
+		},
+		userID,
+		cursor,
+	)
+}
+
+// GetThreadMessages returns replies in thread of provided root message using keyset pagination by message ID.
+// Messages, which were deleted by User only for himself, are skipped. Messages are always returned from older
+// to newer.
+func (repo *MessagesRepository) GetThreadMessages(
+	ctx context.Context,
+	threadRootID, userID uint64,
+	cursor domains.MessagesCursor,
+) ([]domains.Message, error) {
+	return repo.getMessages(
+		ctx,
+		sq.Eq{threadRootIDColumnName: threadRootID},
+		userID,
+		cursor,
+	)
+}
+
+// getMessages returns messages, which satisfy provided condition, using keyset pagination by message ID.
+func (repo *MessagesRepository) getMessages(
+	ctx context.Context,
+	condition sq.Eq,
+	userID uint64,
+	cursor domains.MessagesCursor,
+) ([]domains.Message, error) {
+	builder := sq.
+		Select(messageColumns...).
+		From(messagesTableName).
+		Where(condition).
+		Where(notDeletedForUserCondition(userID)).
+		Limit(cursor.Limit).
+		PlaceholderFormat(sq.Dollar)
+
+	// Newer messages are read in ascending order, all other cases - in descending order from the cursor:
+	ascending := cursor.After != nil
+
+	switch {
+	case cursor.After != nil:
+		builder = builder.
+			Where(sq.Gt{idColumnName: *cursor.After}).
+			OrderBy(fmt.Sprintf("%s %s", idColumnName, asc))
+	case cursor.Before != nil:
+		builder = builder.
+			Where(sq.Lt{idColumnName: *cursor.Before}).
+			OrderBy(fmt.Sprintf("%s %s", idColumnName, desc))
+	default:
+		builder = builder.OrderBy(fmt.Sprintf("%s %s", idColumnName, desc))
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	var messages []domains.Message
+
+	for rows.Next() {
+		message := domains.Message{}
+
+		err = rows.Scan(scanMessage(&message)...)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !ascending {
+		slices.Reverse(messages)
+	}
+
+	return messages, nil
+}
+
+// UpdateMessageText sets new text of message and marks it as edited.
+func (repo *MessagesRepository) UpdateMessageText(ctx context.Context, id uint64, text string) error {
+	now := time.Now()
+
+	stmt, params, err := sq.
+		Update(messagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Set(textColumnName, text).
+		Set(editedAtColumnName, now).
+		Set(updatedAtColumnName, now).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// DeleteMessage turns message into tombstone: its text is erased, but message keeps its place in history.
+func (repo *MessagesRepository) DeleteMessage(ctx context.Context, id uint64) error {
+	now := time.Now()
+
+	stmt, params, err := sq.
+		Update(messagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Set(textColumnName, "").
+		Set(deletedAtColumnName, now).
+		Set(updatedAtColumnName, now).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// CreateMessageRevision saves previous text of edited message.
+func (repo *MessagesRepository) CreateMessageRevision(
+	ctx context.Context,
+	messageID uint64,
+	text string,
+) error {
+	stmt, params, err := sq.
+		Insert(messageRevisionsTableName).
+		Columns(
+			messageIDColumnName,
+			textColumnName,
+		).
+		Values(
+			messageID,
+			text,
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *MessagesRepository) DeleteMessageRevisions(ctx context.Context, messageID uint64) error {
+	stmt, params, err := sq.
+		Delete(messageRevisionsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// HideMessage deletes message only for provided User.
+func (repo *MessagesRepository) HideMessage(ctx context.Context, messageID, userID uint64) error {
+	stmt, params, err := sq.
+		Insert(messageDeletionsTableName).
+		Columns(
+			messageIDColumnName,
+			userIDColumnName,
+		).
+		Values(
+			messageID,
+			userID,
+		).
+		Suffix(
+			fmt.Sprintf(
+				onConflictDoNothingByKey,
+				messageIDColumnName+", "+userIDColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// AddMessageReaction saves reaction of User on message and reports, whether it was added. Reaction is not
+// added, if User has already left it.
+func (repo *MessagesRepository) AddMessageReaction(
+	ctx context.Context,
+	reactionData domains.MessageReactionDTO,
+) (bool, error) {
+	stmt, params, err := sq.
+		Insert(messageReactionsTableName).
+		Columns(
+			messageIDColumnName,
+			userIDColumnName,
+			emojiColumnName,
+		).
+		Values(
+			reactionData.MessageID,
+			reactionData.UserID,
+			reactionData.Emoji,
+		).
+		Suffix(
+			fmt.Sprintf(
+				onConflictDoNothingByKey,
+				messageIDColumnName+", "+userIDColumnName+", "+emojiColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteMessageReaction deletes reaction of User on message and reports, whether it existed.
+func (repo *MessagesRepository) DeleteMessageReaction(
+	ctx context.Context,
+	reactionData domains.MessageReactionDTO,
+) (bool, error) {
+	stmt, params, err := sq.
+		Delete(messageReactionsTableName).
+		Where(
+			sq.Eq{
+				messageIDColumnName: reactionData.MessageID,
+				userIDColumnName:    reactionData.UserID,
+				emojiColumnName:     reactionData.Emoji,
+			},
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (repo *MessagesRepository) DeleteMessageReactions(ctx context.Context, messageID uint64) error {
+	stmt, params, err := sq.
+		Delete(messageReactionsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetMessagesReactions returns reactions of provided messages, aggregated by emoji. Reactions of each message
+// are ordered by time, when emoji was used first time. ReactedByMe is calculated for provided User.
+func (repo *MessagesRepository) GetMessagesReactions(
+	ctx context.Context,
+	messageIDs []uint64,
+	userID uint64,
+) (reactions []domains.MessageReaction, err error) {
+	stmt, params, err := sq.
+		Select(
+			messageIDColumnName,
+			emojiColumnName,
+			"COUNT(*)",
+		).
+		Column(sq.Expr(fmt.Sprintf("BOOL_OR(%s = ?)", userIDColumnName), userID)).
+		From(messageReactionsTableName).
+		Where(sq.Eq{messageIDColumnName: messageIDs}).
+		GroupBy(messageIDColumnName, emojiColumnName).
+		OrderBy(messageIDColumnName, fmt.Sprintf("MIN(%s)", idColumnName)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		reaction := domains.MessageReaction{}
+
+		err = rows.Scan(
+			&reaction.MessageID,
+			&reaction.Emoji,
+			&reaction.Count,
+			&reaction.ReactedByMe,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		reactions = append(reactions, reaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
+
+// AddThreadReply increments replies counter of thread root message and sets time of the last reply.
+func (repo *MessagesRepository) AddThreadReply(
+	ctx context.Context,
+	threadRootID uint64,
+	repliedAt time.Time,
+) error {
+	stmt, params, err := sq.
+		Update(messagesTableName).
+		Where(sq.Eq{idColumnName: threadRootID}).
+		Set(threadReplyCountColumnName, sq.Expr(threadReplyCountColumnName+" + 1")).
+		Set(threadLastReplyAtColumnName, repliedAt).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// AddThreadParticipants joins provided Users to thread. Users, who have already joined the thread, are skipped.
+func (repo *MessagesRepository) AddThreadParticipants(
+	ctx context.Context,
+	chatID, threadRootID uint64,
+	userIDs ...uint64,
+) error {
+	builder := sq.
+		Insert(threadParticipantsTableName).
+		Columns(
+			threadRootIDColumnName,
+			chatIDColumnName,
+			userIDColumnName,
+		).
+		Suffix(
+			fmt.Sprintf(
+				onConflictDoNothingByKey,
+				threadRootIDColumnName+", "+userIDColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	for _, userID := range userIDs {
+		builder = builder.Values(threadRootID, chatID, userID)
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *MessagesRepository) GetThreadParticipant(
+	ctx context.Context,
+	threadRootID, userID uint64,
+) (*domains.ThreadParticipant, error) {
+	stmt, params, err := sq.
+		Select(threadParticipantColumns...).
+		From(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				threadRootIDColumnName: threadRootID,
+				userIDColumnName:       userID,
+			},
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &domains.ThreadParticipant{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanThreadParticipant(participant)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// GetThreadParticipantForUpdate locks participation of User in thread till the end of transaction.
+func (repo *MessagesRepository) GetThreadParticipantForUpdate(
+	ctx context.Context,
+	threadRootID, userID uint64,
+) (*domains.ThreadParticipant, error) {
+	stmt, params, err := sq.
+		Select(threadParticipantColumns...).
+		From(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				threadRootIDColumnName: threadRootID,
+				userIDColumnName:       userID,
+			},
+		).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &domains.ThreadParticipant{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanThreadParticipant(participant)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// GetUserThreadParticipants returns participation of User in threads of provided root messages. Threads, which
+// User has not joined, are skipped.
+func (repo *MessagesRepository) GetUserThreadParticipants(
+	ctx context.Context,
+	threadRootIDs []uint64,
+	userID uint64,
+) (participants []domains.ThreadParticipant, err error) {
+	stmt, params, err := sq.
+		Select(threadParticipantColumns...).
+		From(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				threadRootIDColumnName: threadRootIDs,
+				userIDColumnName:       userID,
+			},
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		participant := domains.ThreadParticipant{}
+
+		err = rows.Scan(scanThreadParticipant(&participant)...)
+		if err != nil {
+			return nil, err
+		}
+
+		participants = append(participants, participant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+// IncrementThreadUnreadCounters increments unread replies counters of all thread participants except provided
+// User.
+func (repo *MessagesRepository) IncrementThreadUnreadCounters(
+	ctx context.Context,
+	threadRootID, exceptUserID uint64,
+) error {
+	stmt, params, err := sq.
+		Update(threadParticipantsTableName).
+		Where(sq.Eq{threadRootIDColumnName: threadRootID}).
+		Where(sq.NotEq{userIDColumnName: exceptUserID}).
+		Set(unreadCountColumnName, sq.Expr(unreadCountColumnName+" + 1")).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// UpdateThreadLastReadMessage moves read pointer of User in thread to provided reply and recalculates unread
+// replies counter. Counter is calculated only by replies after read pointer using thread index.
+func (repo *MessagesRepository) UpdateThreadLastReadMessage(
+	ctx context.Context,
+	threadRootID, userID, messageID uint64,
+) error {
+	unreadCountQuery := sq.
+		Select("COUNT(*)").
+		From(messagesTableName).
+		Where(sq.Eq{messagesTableName + "." + threadRootIDColumnName: threadRootID}).
+		Where(sq.Gt{messagesTableName + "." + idColumnName: messageID}).
+		Where(sq.NotEq{messagesTableName + "." + authorIDColumnName: userID})
+
+	stmt, params, err := sq.
+		Update(threadParticipantsTableName).
+		Where(
+			sq.Eq{
+				threadRootIDColumnName: threadRootID,
+				userIDColumnName:       userID,
+			},
+		).
+		Set(lastReadMessageIDColumnName, messageID).
+		Set(unreadCountColumnName, sq.Expr("(?)", unreadCountQuery)).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// RecountThreadUnreadCounters recalculates unread replies counters of all participants of provided threads
+// after some of their replies were deleted.
+func (repo *MessagesRepository) RecountThreadUnreadCounters(ctx context.Context, threadRootIDs []uint64) error {
+	unreadCountQuery := sq.
+		Select("COUNT(*)").
+		From(messagesTableName).
+		Where(
+			fmt.Sprintf(
+				"%s.%s = %s.%s",
+				messagesTableName,
+				threadRootIDColumnName,
+				threadParticipantsTableName,
+				threadRootIDColumnName,
+			),
+		).
+		Where(
+			fmt.Sprintf(
+				"%s.%s > COALESCE(%s.%s, 0)",
+				messagesTableName,
+				idColumnName,
+				threadParticipantsTableName,
+				lastReadMessageIDColumnName,
+			),
+		).
+		Where(
+			fmt.Sprintf(
+				"%s.%s <> %s.%s",
+				messagesTableName,
+				authorIDColumnName,
+				threadParticipantsTableName,
+				userIDColumnName,
+			),
+		)
+
+	stmt, params, err := sq.
+		Update(threadParticipantsTableName).
+		Where(sq.Eq{threadRootIDColumnName: threadRootIDs}).
+		Set(unreadCountColumnName, sq.Expr("(?)", unreadCountQuery)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// scanMention returns pointers on domains.Mention fields, which are stored in message_mentions table.
+func scanMention(mention *domains.Mention) []any {
+	return []any{
+		&mention.ID,
+		&mention.MessageID,
+		&mention.ChatID,
+		&mention.UserID,
+		&mention.Type,
+		&mention.CreatedAt,
+	}
+}
+
+// scanMessage returns pointers on domains.Message fields, which are stored in messages table.
+func scanMessage(message *domains.Message) []any {
+	return []any{
+		&message.ID,
+		&message.ChatID,
+		&message.AuthorID,
+		&message.Type,
+		jsonColumn[domains.SystemEvent]{value: &message.SystemEvent},
+		&message.Text,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+		&message.EditedAt,
+		&message.DeletedAt,
+		&message.ReplyToMessageID,
+		&message.ThreadRootID,
+		&message.ThreadReplyCount,
+		&message.ThreadLastReplyAt,
+		&message.ExpiresAt,
+		&message.ForwardedFromMessageID,
+		&message.ForwardedFromChatID,
+		&message.ForwardedFromAuthorID,
+	}
+}
+
+// scanThreadParticipant returns pointers on domains.ThreadParticipant fields.
+func scanThreadParticipant(participant *domains.ThreadParticipant) []any {
+	return []any{
+		&participant.ID,
+		&participant.ThreadRootID,
+		&participant.ChatID,
+		&participant.UserID,
+		&participant.LastReadMessageID,
+		&participant.UnreadCount,
+		&participant.CreatedAt,
+		&participant.UpdatedAt,
+	}
+}
+
+// CreateMentions saves mentions of Users in message. Repeated mentions of the same User are skipped.
+func (repo *MessagesRepository) CreateMentions(ctx context.Context, mentions ...domains.CreateMentionDTO) error {
+	builder := sq.
+		Insert(messageMentionsTableName).
+		Columns(
+			messageIDColumnName,
+			chatIDColumnName,
+			userIDColumnName,
+			typeColumnName,
+		).
+		Suffix(
+			fmt.Sprintf(
+				onConflictDoNothingByKey,
+				messageIDColumnName+", "+userIDColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	for _, mention := range mentions {
+		builder = builder.Values(mention.MessageID, mention.ChatID, mention.UserID, mention.Type)
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *MessagesRepository) GetMessageMentions(
+	ctx context.Context,
+	messageID uint64,
+) (mentions []domains.Mention, err error) {
+	stmt, params, err := sq.
+		Select(mentionColumns...).
+		From(messageMentionsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		OrderBy(fmt.Sprintf("%s %s", idColumnName, asc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		mention := domains.Mention{}
+
+		err = rows.Scan(scanMention(&mention)...)
+		if err != nil {
+			return nil, err
+		}
+
+		mentions = append(mentions, mention)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// GetUserMentions returns mentions of User along with messages using keyset pagination by mention ID.
+// Mentions in deleted messages and in chats, which User has left, are skipped. Mentions are returned from
+// newer to older.
+func (repo *MessagesRepository) GetUserMentions(
+	ctx context.Context,
+	userID uint64,
+	cursor domains.MentionsCursor,
+) (mentions []domains.Mention, err error) {
+	builder := sq.
+		Select(mentionColumns...).
+		Columns(messageColumns...).
+		From(messageMentionsTableName).
+		Join(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s",
+				messagesTableName,
+				messagesTableName,
+				idColumnName,
+				messageMentionsTableName,
+				messageIDColumnName,
+			),
+		).
+		Join(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s AND %s.%s = %s.%s",
+				chatMembersTableName,
+				chatMembersTableName,
+				chatIDColumnName,
+				messageMentionsTableName,
+				chatIDColumnName,
+				chatMembersTableName,
+				userIDColumnName,
+				messageMentionsTableName,
+				userIDColumnName,
+			),
+		).
+		Where(sq.Eq{messageMentionsTableName + "." + userIDColumnName: userID}).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		Where(notDeletedForUserCondition(userID)).
+		OrderBy(fmt.Sprintf("%s.%s %s", messageMentionsTableName, idColumnName, desc)).
+		Limit(cursor.Limit).
+		PlaceholderFormat(sq.Dollar)
+
+	if cursor.Before != nil {
+		builder = builder.Where(sq.Lt{messageMentionsTableName + "." + idColumnName: *cursor.Before})
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		mention := domains.Mention{Message: &domains.Message{}}
+
+		err = rows.Scan(append(scanMention(&mention), scanMessage(mention.Message)...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		mentions = append(mentions, mention)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// SearchMessages performs full-text search of messages in chats, which User is a member of. Deleted messages
+// are skipped. Results are ordered by relevance and then from newer to older.
+func (repo *MessagesRepository) SearchMessages(
+	ctx context.Context,
+	searchData domains.SearchMessagesDTO,
+) (results []domains.MessageSearchResult, err error) {
+	builder := sq.
+		Select(messageColumns...).
+		Column(
+			sq.Expr(
+				fmt.Sprintf(searchHeadlineExpression, messagesTableName, textColumnName, searchQueryExpression),
+				searchData.Query,
+				searchData.Query,
+			),
+		).
+		Column(
+			sq.Alias(
+				sq.Expr(
+					fmt.Sprintf(searchRankExpression, messagesTableName, searchVectorColumnName, searchQueryExpression),
+					searchData.Query,
+					searchData.Query,
+				),
+				searchRankAlias,
+			),
+		).
+		From(messagesTableName).
+		Where(
+			fmt.Sprintf("%s.%s @@ %s", messagesTableName, searchVectorColumnName, searchQueryExpression),
+			searchData.Query,
+			searchData.Query,
+		).
+		Where(
+			sq.Expr(
+				fmt.Sprintf("%s.%s IN (?)", messagesTableName, chatIDColumnName),
+				sq.
+					Select(chatIDColumnName).
+					From(chatMembersTableName).
+					Where(sq.Eq{userIDColumnName: searchData.UserID}),
+			),
+		).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		Where(notDeletedForUserCondition(searchData.UserID)).
+		OrderBy(
+			fmt.Sprintf("%s %s", searchRankAlias, desc),
+			fmt.Sprintf("%s.%s %s", messagesTableName, idColumnName, desc),
+		).
+		Limit(searchData.Limit).
+		Offset(searchData.Offset).
+		PlaceholderFormat(sq.Dollar)
+
+	if searchData.ChatID != nil {
+		builder = builder.Where(sq.Eq{messagesTableName + "." + chatIDColumnName: *searchData.ChatID})
+	}
+
+	if searchData.AuthorID != nil {
+		builder = builder.Where(sq.Eq{messagesTableName + "." + authorIDColumnName: *searchData.AuthorID})
+	}
+
+	if searchData.From != nil {
+		builder = builder.Where(sq.GtOrEq{messagesTableName + "." + createdAtColumnName: *searchData.From})
+	}
+
+	if searchData.To != nil {
+		builder = builder.Where(sq.Lt{messagesTableName + "." + createdAtColumnName: *searchData.To})
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		result := domains.MessageSearchResult{}
+
+		err = rows.Scan(append(scanMessage(&result.Message), &result.Snippet, &result.Rank)...)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Snippet = highlightSnippet(result.Snippet)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// notDeletedForUserCondition skips messages, which were deleted by User only for himself.
+func notDeletedForUserCondition(userID uint64) sq.Sqlizer {
+	return sq.Expr(
+		fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM %s WHERE %s.%s = %s.%s AND %s.%s = ?)",
+			messageDeletionsTableName,
+			messageDeletionsTableName,
+			messageIDColumnName,
+			messagesTableName,
+			idColumnName,
+			messageDeletionsTableName,
+			userIDColumnName,
+		),
+		userID,
+	)
+}
+
+// highlightSnippet escapes headline of message and replaces highlight markers with <mark> tags.
+func highlightSnippet(headline string) string {
+	return strings.NewReplacer(
+		searchHighlightStart, "<mark>",
+		searchHighlightStop, "</mark>",
+	).Replace(html.EscapeString(headline))
+}
+
+// jsonColumn stores nullable value in JSON column. Nil value is stored as NULL.
+type jsonColumn[T any] struct {
+	value **T
+}
+
+func (c jsonColumn[T]) Value() (driver.Value, error) {
+	if *c.value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(*c.value)
+}
+
+func (c jsonColumn[T]) Scan(src any) error {
+	var data []byte
+
+	switch src := src.(type) {
+	case nil:
+		*c.value = nil
+
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("unsupported type of JSON column: %T", src)
+	}
+
+	value := new(T)
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+
+	*c.value = value
+
+	return nil
+}
+
+// pinColumns lists message_pins table columns in order of domains.Pin fields:
+var pinColumns = []string{
+	messagePinsTableName + "." + idColumnName,
+	messagePinsTableName + "." + chatIDColumnName,
+	messagePinsTableName + "." + messageIDColumnName,
+	messagePinsTableName + "." + pinnedByColumnName,
+	messagePinsTableName + "." + createdAtColumnName,
+}
+
+// scanPin returns pointers on domains.Pin fields, which are stored in message_pins table.
+func scanPin(pin *domains.Pin) []any {
+	return []any{
+		&pin.ID,
+		&pin.ChatID,
+		&pin.MessageID,
+		&pin.PinnedBy,
+		&pin.CreatedAt,
+	}
+}
+
+// CreatePin pins message in chat and reports, whether message was not pinned before.
+func (repo *MessagesRepository) CreatePin(ctx context.Context, chatID, messageID, pinnedBy uint64) (bool, error) {
+	stmt, params, err := sq.
+		Insert(messagePinsTableName).
+		Columns(
+			chatIDColumnName,
+			messageIDColumnName,
+			pinnedByColumnName,
+		).
+		Values(
+			chatID,
+			messageID,
+			pinnedBy,
+		).
+		Suffix(fmt.Sprintf(onConflictDoNothingByKey, messageIDColumnName)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (repo *MessagesRepository) GetMessagePin(ctx context.Context, messageID uint64) (*domains.Pin, error) {
+	stmt, params, err := sq.
+		Select(pinColumns...).
+		From(messagePinsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	pin := &domains.Pin{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanPin(pin)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pin, nil
+}
+
+// DeletePin unpins message and reports, whether message was pinned before.
+func (repo *MessagesRepository) DeletePin(ctx context.Context, messageID uint64) (bool, error) {
+	stmt, params, err := sq.
+		Delete(messagePinsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (repo *MessagesRepository) CountChatPins(ctx context.Context, chatID uint64) (int, error) {
+	stmt, params, err := sq.
+		Select("COUNT(*)").
+		From(messagePinsTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetChatPins returns pinned messages of chat from the most recently pinned. Messages, which were deleted
+// by User only for himself, are skipped.
+func (repo *MessagesRepository) GetChatPins(
+	ctx context.Context,
+	chatID, userID uint64,
+) (pins []domains.Pin, err error) {
+	stmt, params, err := sq.
+		Select(pinColumns...).
+		Columns(messageColumns...).
+		From(messagePinsTableName).
+		Join(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s",
+				messagesTableName,
+				messagesTableName,
+				idColumnName,
+				messagePinsTableName,
+				messageIDColumnName,
+			),
+		).
+		Where(sq.Eq{messagePinsTableName + "." + chatIDColumnName: chatID}).
+		Where(notDeletedForUserCondition(userID)).
+		OrderBy(fmt.Sprintf("%s.%s %s", messagePinsTableName, idColumnName, desc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		pin := domains.Pin{Message: &domains.Message{}}
+
+		err = rows.Scan(append(scanPin(&pin), scanMessage(pin.Message)...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		pins = append(pins, pin)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+// scheduledMessageColumns lists scheduled_messages table columns in order of domains.ScheduledMessage fields:
+var scheduledMessageColumns = []string{
+	scheduledMessagesTableName + "." + idColumnName,
+	scheduledMessagesTableName + "." + chatIDColumnName,
+	scheduledMessagesTableName + "." + authorIDColumnName,
+	scheduledMessagesTableName + "." + textColumnName,
+	scheduledMessagesTableName + "." + replyToMessageIDColumnName,
+	scheduledMessagesTableName + "." + sendAtColumnName,
+	scheduledMessagesTableName + "." + failedAtColumnName,
+	scheduledMessagesTableName + "." + createdAtColumnName,
+	scheduledMessagesTableName + "." + updatedAtColumnName,
+}
+
+// scanScheduledMessage returns pointers on domains.ScheduledMessage fields.
+func scanScheduledMessage(message *domains.ScheduledMessage) []any {
+	return []any{
+		&message.ID,
+		&message.ChatID,
+		&message.AuthorID,
+		&message.Text,
+		&message.ReplyToMessageID,
+		&message.SendAt,
+		&message.FailedAt,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+	}
+}
+
+func (repo *MessagesRepository) CreateScheduledMessage(
+	ctx context.Context,
+	messageData domains.ScheduleMessageDTO,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(scheduledMessagesTableName).
+		Columns(
+			chatIDColumnName,
+			authorIDColumnName,
+			textColumnName,
+			replyToMessageIDColumnName,
+			sendAtColumnName,
+		).
+		Values(
+			messageData.ChatID,
+			messageData.AuthorID,
+			messageData.Text,
+			messageData.ReplyToMessageID,
+			messageData.SendAt,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo *MessagesRepository) GetScheduledMessageByID(
+	ctx context.Context,
+	id uint64,
+) (*domains.ScheduledMessage, error) {
+	stmt, params, err := sq.
+		Select(scheduledMessageColumns...).
+		From(scheduledMessagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domains.ScheduledMessage{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanScheduledMessage(message)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// LockPendingScheduledMessage locks scheduled message, which has not failed yet, till the end of transaction.
+// Message, which is already locked by another transaction, is skipped, so concurrent dispatchers never wait
+// for each other and get sql.ErrNoRows instead.
+func (repo *MessagesRepository) LockPendingScheduledMessage(
+	ctx context.Context,
+	id uint64,
+) (*domains.ScheduledMessage, error) {
+	stmt, params, err := sq.
+		Select(scheduledMessageColumns...).
+		From(scheduledMessagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Where(sq.Eq{failedAtColumnName: nil}).
+		Suffix(skipLockedSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &domains.ScheduledMessage{}
+
+	err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanScheduledMessage(message)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetUserScheduledMessages returns scheduled messages of author in chat in order of sending.
+func (repo *MessagesRepository) GetUserScheduledMessages(
+	ctx context.Context,
+	chatID, authorID uint64,
+) ([]domains.ScheduledMessage, error) {
+	return repo.getScheduledMessages(
+		ctx,
+		sq.
+			Select(scheduledMessageColumns...).
+			From(scheduledMessagesTableName).
+			Where(sq.Eq{chatIDColumnName: chatID}).
+			Where(sq.Eq{authorIDColumnName: authorID}).
+			OrderBy(
+				fmt.Sprintf("%s %s", sendAtColumnName, asc),
+				fmt.Sprintf("%s %s", idColumnName, asc),
+			),
+	)
+}
+
+// GetDueScheduledMessages returns messages, which should have been sent by the provided moment, in order of
+// sending. Failed messages are skipped.
+func (repo *MessagesRepository) GetDueScheduledMessages(
+	ctx context.Context,
+	now time.Time,
+	limit uint64,
+) ([]domains.ScheduledMessage, error) {
+	return repo.getScheduledMessages(
+		ctx,
+		sq.
+			Select(scheduledMessageColumns...).
+			From(scheduledMessagesTableName).
+			Where(sq.Eq{failedAtColumnName: nil}).
+			Where(sq.LtOrEq{sendAtColumnName: now}).
+			OrderBy(
+				fmt.Sprintf("%s %s", sendAtColumnName, asc),
+				fmt.Sprintf("%s %s", idColumnName, asc),
+			).
+			Limit(limit),
+	)
+}
+
+// getScheduledMessages returns scheduled messages, which are selected by provided query.
+func (repo *MessagesRepository) getScheduledMessages(
+	ctx context.Context,
+	builder sq.SelectBuilder,
+) (messages []domains.ScheduledMessage, err error) {
+	stmt, params, err := builder.
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		message := domains.ScheduledMessage{}
+		if err = rows.Scan(scanScheduledMessage(&message)...); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (repo *MessagesRepository) CountUserScheduledMessages(ctx context.Context, chatID, authorID uint64) (int, error) {
+	stmt, params, err := sq.
+		Select("COUNT(*)").
+		From(scheduledMessagesTableName).
+		Where(sq.Eq{chatIDColumnName: chatID}).
+		Where(sq.Eq{authorIDColumnName: authorID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateScheduledMessage sets new text and sending time of scheduled message. Failed message becomes pending
+// again.
+func (repo *MessagesRepository) UpdateScheduledMessage(
+	ctx context.Context,
+	id uint64,
+	text string,
+	sendAt time.Time,
+) error {
+	stmt, params, err := sq.
+		Update(scheduledMessagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Set(textColumnName, text).
+		Set(sendAtColumnName, sendAt).
+		Set(failedAtColumnName, nil).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// FailScheduledMessage marks scheduled message as failed, if it was not changed after the provided moment of
+// its last update. Changed message is left pending to be sent with new data.
+func (repo *MessagesRepository) FailScheduledMessage(
+	ctx context.Context,
+	id uint64,
+	updatedAt time.Time,
+) error {
+	now := time.Now()
+
+	stmt, params, err := sq.
+		Update(scheduledMessagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		Where(sq.Eq{updatedAtColumnName: updatedAt}).
+		Set(failedAtColumnName, now).
+		Set(updatedAtColumnName, now).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+func (repo *MessagesRepository) DeleteScheduledMessage(ctx context.Context, id uint64) error {
+	stmt, params, err := sq.
+		Delete(scheduledMessagesTableName).
+		Where(sq.Eq{idColumnName: id}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// LockExpiredMessages locks batch of messages, which expired before provided time, from the earliest expired,
+// and returns them along with replies in their threads, which are deleted together with root messages.
+// Expired messages, which are locked by other transaction, are skipped, while replies of locked messages are
+// waited for to be locked too.
+func (repo *MessagesRepository) LockExpiredMessages(
+	ctx context.Context,
+	expiredBefore time.Time,
+	limit uint64,
+) (messages []domains.Message, err error) {
+	expiredQuery := sq.
+		Select(idColumnName).
+		From(messagesTableName).
+		Where(sq.LtOrEq{expiresAtColumnName: expiredBefore}).
+		OrderBy(expiresAtColumnName).
+		Limit(limit).
+		Suffix(skipLockedSuffix)
+
+	stmt, params, err := sq.
+		Select(messageColumns...).
+		Prefix("WITH "+expiredMessagesAlias+" AS (?)", expiredQuery).
+		From(messagesTableName).
+		Where(
+			fmt.Sprintf(
+				"%s.%s IN (SELECT %s FROM %s) OR %s.%s IN (SELECT %s FROM %s)",
+				messagesTableName,
+				idColumnName,
+				idColumnName,
+				expiredMessagesAlias,
+				messagesTableName,
+				threadRootIDColumnName,
+				idColumnName,
+				expiredMessagesAlias,
+			),
+		).
+		OrderBy(fmt.Sprintf("%s.%s %s", messagesTableName, idColumnName, asc)).
+		Suffix(forUpdateSuffix).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		message := domains.Message{}
+
+		err = rows.Scan(scanMessage(&message)...)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// DeleteMessages erases messages completely. Their reactions, revisions, mentions, pins and attachments are
+// deleted in cascade.
+func (repo *MessagesRepository) DeleteMessages(ctx context.Context, ids []uint64) error {
+	stmt, params, err := sq.
+		Delete(messagesTableName).
+		Where(sq.Eq{idColumnName: ids}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// RecountThreadReplies recalculates replies counter and time of the last reply of provided thread root
+// messages after some of their replies were deleted.
+func (repo *MessagesRepository) RecountThreadReplies(ctx context.Context, threadRootIDs []uint64) error {
+	repliesCondition := fmt.Sprintf(
+		"%s.%s = %s.%s",
+		threadRepliesAlias,
+		threadRootIDColumnName,
+		messagesTableName,
+		idColumnName,
+	)
+
+	replyCountQuery := sq.
+		Select("COUNT(*)").
+		From(messagesTableName + " AS " + threadRepliesAlias).
+		Where(repliesCondition)
+
+	lastReplyAtQuery := sq.
+		Select(fmt.Sprintf("MAX(%s.%s)", threadRepliesAlias, createdAtColumnName)).
+		From(messagesTableName + " AS " + threadRepliesAlias).
+		Where(repliesCondition)
+
+	stmt, params, err := sq.
+		Update(messagesTableName).
+		Where(sq.Eq{idColumnName: threadRootIDs}).
+		Set(threadReplyCountColumnName, sq.Expr("(?)", replyCountQuery)).
+		Set(threadLastReplyAtColumnName, sq.Expr("(?)", lastReplyAtQuery)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// pollColumns lists polls table columns in order of domains.Poll fields:
+var pollColumns = []string{
+	pollsTableName + "." + idColumnName,
+	pollsTableName + "." + messageIDColumnName,
+	pollsTableName + "." + questionColumnName,
+	pollsTableName + "." + multipleChoiceColumnName,
+	pollsTableName + "." + anonymousColumnName,
+	pollsTableName + "." + closesAtColumnName,
+	pollsTableName + "." + createdAtColumnName,
+}
+
+// pollOptionColumns lists poll_options table columns in order of domains.PollOption fields:
+var pollOptionColumns = []string{
+	pollOptionsTableName + "." + idColumnName,
+	pollOptionsTableName + "." + pollIDColumnName,
+	pollOptionsTableName + "." + textColumnName,
+}
+
+// CreatePoll saves poll of message along with its options in provided order.
+func (repo *MessagesRepository) CreatePoll(
+	ctx context.Context,
+	messageID uint64,
+	pollData domains.CreatePollDTO,
+) (uint64, error) {
+	stmt, params, err := sq.
+		Insert(pollsTableName).
+		Columns(
+			messageIDColumnName,
+			questionColumnName,
+			multipleChoiceColumnName,
+			anonymousColumnName,
+			closesAtColumnName,
+		).
+		Values(
+			messageID,
+			pollData.Question,
+			pollData.MultipleChoice,
+			pollData.Anonymous,
+			pollData.ClosesAt,
+		).
+		Suffix(returningIDSuffix).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var pollID uint64
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(&pollID); err != nil {
+		return 0, err
+	}
+
+	builder := sq.
+		Insert(pollOptionsTableName).
+		Columns(
+			pollIDColumnName,
+			textColumnName,
+		).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	// Options get IDs in order of values, which is used as order of options:
+	for _, option := range pollData.Options {
+		builder = builder.Values(pollID, option)
+	}
+
+	stmt, params, err = builder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = repo.tx.ExecContext(ctx, stmt, params...); err != nil {
+		return 0, err
+	}
+
+	return pollID, nil
+}
+
+// GetMessagesPolls returns polls of provided messages along with numbers of Users, who voted in them.
+// Options of polls are not loaded.
+func (repo *MessagesRepository) GetMessagesPolls(
+	ctx context.Context,
+	messageIDs []uint64,
+) (polls []domains.Poll, err error) {
+	votersCountQuery := sq.
+		Select(fmt.Sprintf("COUNT(DISTINCT %s)", userIDColumnName)).
+		From(pollVotesTableName).
+		Where(
+			fmt.Sprintf(
+				"%s.%s = %s.%s",
+				pollVotesTableName,
+				pollIDColumnName,
+				pollsTableName,
+				idColumnName,
+			),
+		)
+
+	stmt, params, err := sq.
+		Select(pollColumns...).
+		Column(sq.Alias(votersCountQuery, "voters_count")).
+		From(pollsTableName).
+		Where(sq.Eq{pollsTableName + "." + messageIDColumnName: messageIDs}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		poll := domains.Poll{}
+		if err = rows.Scan(append(scanPoll(&poll), &poll.VotersCount)...); err != nil {
+			return nil, err
+		}
+
+		polls = append(polls, poll)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+// GetPollsOptions returns options of provided polls along with numbers of votes for them. Options of each
+// poll are ordered as they were created. VotedByMe is calculated for provided User. Voters are not loaded.
+func (repo *MessagesRepository) GetPollsOptions(
+	ctx context.Context,
+	pollIDs []uint64,
+	userID uint64,
+) (options []domains.PollOption, err error) {
+	optionVotesCondition := fmt.Sprintf(
+		"%s.%s = %s.%s",
+		pollVotesTableName,
+		optionIDColumnName,
+		pollOptionsTableName,
+		idColumnName,
+	)
+
+	votesCountQuery := sq.
+		Select("COUNT(*)").
+		From(pollVotesTableName).
+		Where(optionVotesCondition)
+
+	votedByMeQuery := sq.
+		Select("1").
+		From(pollVotesTableName).
+		Where(optionVotesCondition).
+		Where(sq.Eq{pollVotesTableName + "." + userIDColumnName: userID})
+
+	stmt, params, err := sq.
+		Select(pollOptionColumns...).
+		Column(sq.Alias(votesCountQuery, "votes_count")).
+		Column(sq.Expr("EXISTS (?)", votedByMeQuery)).
+		From(pollOptionsTableName).
+		Where(sq.Eq{pollOptionsTableName + "." + pollIDColumnName: pollIDs}).
+		OrderBy(pollOptionsTableName + "." + idColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		option := domains.PollOption{}
+		if err = rows.Scan(append(scanPollOption(&option), &option.VotesCount, &option.VotedByMe)...); err != nil {
+			return nil, err
+		}
+
+		options = append(options, option)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// GetPollsVotes returns votes of provided polls in order of voting.
+func (repo *MessagesRepository) GetPollsVotes(
+	ctx context.Context,
+	pollIDs []uint64,
+) (votes []domains.PollVote, err error) {
+	stmt, params, err := sq.
+		Select(
+			pollIDColumnName,
+			optionIDColumnName,
+			userIDColumnName,
+		).
+		From(pollVotesTableName).
+		Where(sq.Eq{pollIDColumnName: pollIDs}).
+		OrderBy(idColumnName).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		vote := domains.PollVote{}
+		if err = rows.Scan(&vote.PollID, &vote.OptionID, &vote.UserID); err != nil {
+			return nil, err
+		}
+
+		votes = append(votes, vote)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// CreatePollVotes saves votes of User for provided options of poll. Repeated vote for the same option
+// violates uniqueness of votes.
+func (repo *MessagesRepository) CreatePollVotes(
+	ctx context.Context,
+	pollID, userID uint64,
+	optionIDs []uint64,
+) error {
+	builder := sq.
+		Insert(pollVotesTableName).
+		Columns(
+			pollIDColumnName,
+			optionIDColumnName,
+			userIDColumnName,
+		).
+		PlaceholderFormat(sq.Dollar) // pq postgres driver works only with $ placeholders
+
+	for _, optionID := range optionIDs {
+		builder = builder.Values(pollID, optionID, userID)
+	}
+
+	stmt, params, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// DeletePollVotes deletes all votes of User in poll and reports, whether User has voted.
+func (repo *MessagesRepository) DeletePollVotes(ctx context.Context, pollID, userID uint64) (bool, error) {
+	stmt, params, err := sq.
+		Delete(pollVotesTableName).
+		Where(sq.Eq{pollIDColumnName: pollID}).
+		Where(sq.Eq{userIDColumnName: userID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteMessagePoll deletes poll of message along with its options and votes.
+func (repo *MessagesRepository) DeleteMessagePoll(ctx context.Context, messageID uint64) error {
+	stmt, params, err := sq.
+		Delete(pollsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// scanPoll returns pointers on domains.Poll fields, which are stored in polls table.
+func scanPoll(poll *domains.Poll) []any {
+	return []any{
+		&poll.ID,
+		&poll.MessageID,
+		&poll.Question,
+		&poll.MultipleChoice,
+		&poll.Anonymous,
+		&poll.ClosesAt,
+		&poll.CreatedAt,
+	}
+}
+
+// scanPollOption returns pointers on domains.PollOption fields, which are stored in poll_options table.
+func scanPollOption(option *domains.PollOption) []any {
+	return []any{
+		&option.ID,
+		&option.PollID,
+		&option.Text,
+	}
+}
+
+// linkPreviewColumns lists link_previews table columns in order of domains.LinkPreview fields:
+var linkPreviewColumns = []string{
+	linkPreviewsTableName + "." + idColumnName,
+	linkPreviewsTableName + "." + urlColumnName,
+	linkPreviewsTableName + "." + statusColumnName,
+	linkPreviewsTableName + "." + titleColumnName,
+	linkPreviewsTableName + "." + descriptionColumnName,
+	linkPreviewsTableName + "." + siteNameColumnName,
+	linkPreviewsTableName + "." + imageURLColumnName,
+	linkPreviewsTableName + "." + createdAtColumnName,
+	linkPreviewsTableName + "." + updatedAtColumnName,
+}
+
+// SetMessageLinkPreview attaches preview of provided link to message instead of previous one. Preview of link,
+// which was not previewed before, is created as pending, otherwise cached preview is used.
+func (repo *MessagesRepository) SetMessageLinkPreview(ctx context.Context, messageID uint64, url string) error {
+	stmt, params, err := sq.
+		Insert(linkPreviewsTableName).
+		Columns(urlColumnName).
+		Values(url).
+		Suffix(fmt.Sprintf(onConflictDoNothingByKey, urlColumnName)).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = repo.tx.ExecContext(ctx, stmt, params...); err != nil {
+		return err
+	}
+
+	// Type of message ID is specified, because types of selected values are not inferred from inserted columns:
+	linkPreviewQuery := sq.
+		Select().
+		Column(sq.Expr("?::INTEGER", messageID)).
+		Column(idColumnName).
+		From(linkPreviewsTableName).
+		Where(sq.Eq{urlColumnName: url})
+
+	stmt, params, err = sq.
+		Insert(messageLinkPreviewsTableName).
+		Columns(
+			messageIDColumnName,
+			linkPreviewIDColumnName,
+		).
+		Select(linkPreviewQuery).
+		Suffix(
+			fmt.Sprintf(
+				"ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s",
+				messageIDColumnName,
+				linkPreviewIDColumnName,
+				linkPreviewIDColumnName,
+			),
+		).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// CopyMessageLinkPreview attaches preview of link of provided message to its copy. Message without preview is
+// skipped.
+func (repo *MessagesRepository) CopyMessageLinkPreview(ctx context.Context, messageID, copyID uint64) error {
+	// Type of copy ID is specified, because types of selected values are not inferred from inserted columns:
+	linkPreviewQuery := sq.
+		Select().
+		Column(sq.Expr("?::INTEGER", copyID)).
+		Column(linkPreviewIDColumnName).
+		From(messageLinkPreviewsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID})
+
+	stmt, params, err := sq.
+		Insert(messageLinkPreviewsTableName).
+		Columns(
+			messageIDColumnName,
+			linkPreviewIDColumnName,
+		).
+		Select(linkPreviewQuery).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// DeleteMessageLinkPreview detaches preview of link from message. Cached preview is kept for other messages.
+func (repo *MessagesRepository) DeleteMessageLinkPreview(ctx context.Context, messageID uint64) error {
+	stmt, params, err := sq.
+		Delete(messageLinkPreviewsTableName).
+		Where(sq.Eq{messageIDColumnName: messageID}).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+
+	return err
+}
+
+// GetMessagesLinkPreviews returns ready previews of links, which provided messages contain.
+func (repo *MessagesRepository) GetMessagesLinkPreviews(
+	ctx context.Context,
+	messageIDs []uint64,
+) (previews []domains.MessageLinkPreview, err error) {
+	stmt, params, err := sq.
+		Select(messageLinkPreviewsTableName + "." + messageIDColumnName).
+		Columns(linkPreviewColumns...).
+		From(messageLinkPreviewsTableName).
+		InnerJoin(
+			fmt.Sprintf(
+				"%s ON %s.%s = %s.%s",
+				linkPreviewsTableName,
+				linkPreviewsTableName,
+				idColumnName,
+				messageLinkPreviewsTableName,
+				linkPreviewIDColumnName,
+			),
+		).
+		Where(sq.Eq{messageLinkPreviewsTableName + "." + messageIDColumnName: messageIDs}).
+		Where(sq.Eq{linkPreviewsTableName + "." + statusColumnName: domains.LinkPreviewStatusReady}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		preview := domains.MessageLinkPreview{}
+
+		err = rows.Scan(append([]any{&preview.MessageID}, scanLinkPreview(&preview.LinkPreview)...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		previews = append(previews, preview)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return previews, nil
+}
+
+// GetLinkPreviewMessages returns messages, which contain link of provided preview. Messages, which were
+// deleted for everyone, are skipped.
+func (repo *MessagesRepository) GetLinkPreviewMessages(
+	ctx context.Context,
+	linkPreviewID uint64,
+) (messages []domains.Message, err error) {
+	previewMessagesQuery := sq.
+		Select(messageIDColumnName).
+		From(messageLinkPreviewsTableName).
+		Where(sq.Eq{linkPreviewIDColumnName: linkPreviewID})
+
+	stmt, params, err := sq.
+		Select(messageColumns...).
+		From(messagesTableName).
+		Where(sq.Expr(messagesTableName+"."+idColumnName+" IN (?)", previewMessagesQuery)).
+		Where(sq.Eq{messagesTableName + "." + deletedAtColumnName: nil}).
+		OrderBy(fmt.Sprintf("%s.%s %s", messagesTableName, idColumnName, asc)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		message := domains.Message{}
+
+		err = rows.Scan(scanMessage(&message)...)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ClaimLinkPreviews marks pending previews as processing and returns them. Previews, which are processed
+// longer than since claimedBefore, are claimed again. Previews, which are claimed concurrently by other
+// transaction, are skipped.
+func (repo *MessagesRepository) ClaimLinkPreviews(
+	ctx context.Context,
+	limit uint64,
+	claimedBefore time.Time,
+) (previews []domains.LinkPreview, err error) {
+	claimableQuery := sq.
+		Select(idColumnName).
+		From(linkPreviewsTableName).
+		Where(
+			sq.Or{
+				sq.Eq{statusColumnName: domains.LinkPreviewStatusPending},
+				sq.And{
+					sq.Eq{statusColumnName: domains.LinkPreviewStatusProcessing},
+					sq.Lt{claimedAtColumnName: claimedBefore},
+				},
+			},
+		).
+		OrderBy(idColumnName).
+		Limit(limit).
+		Suffix(skipLockedSuffix)
+
+	stmt, params, err := sq.
+		Update(linkPreviewsTableName).
+		Where(sq.Expr(idColumnName+" IN (?)", claimableQuery)).
+		Set(statusColumnName, domains.LinkPreviewStatusProcessing).
+		Set(claimedAtColumnName, time.Now()).
+		Suffix("RETURNING " + strings.Join(linkPreviewColumns, ", ")).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.tx.QueryContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		rowsErr := rows.Close()
+		if rowsErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w; %w", err, rowsErr)
+
+				return
+			}
+
+			err = rowsErr
+		}
+	}()
+
+	for rows.Next() {
+		preview := domains.LinkPreview{}
+		if err = rows.Scan(scanLinkPreview(&preview)...); err != nil {
+			return nil, err
+		}
+
+		previews = append(previews, preview)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return previews, nil
+}
+
+// UpdateLinkPreview saves result of fetching of link metadata. Only preview, which is being processed,
+// is updated. Reports, whether preview was updated.
+func (repo *MessagesRepository) UpdateLinkPreview(
+	ctx context.Context,
+	previewData domains.UpdateLinkPreviewDTO,
+) (bool, error) {
+	stmt, params, err := sq.
+		Update(linkPreviewsTableName).
+		Where(
+			sq.Eq{
+				idColumnName:     previewData.LinkPreviewID,
+				statusColumnName: domains.LinkPreviewStatusProcessing,
+			},
+		).
+		Set(statusColumnName, previewData.Status).
+		Set(titleColumnName, previewData.Title).
+		Set(descriptionColumnName, previewData.Description).
+		Set(siteNameColumnName, previewData.SiteName).
+		Set(imageURLColumnName, previewData.ImageURL).
+		Set(updatedAtColumnName, time.Now()).
+		PlaceholderFormat(sq.Dollar). // pq postgres driver works only with $ placeholders
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := repo.tx.ExecContext(
+		ctx,
+		stmt,
+		params...,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// scanLinkPreview returns pointers on domains.LinkPreview fields, which are stored in link_previews table.
+func scanLinkPreview(preview *domains.LinkPreview) []any {
+	return []any{
+		&preview.ID,
+		&preview.URL,
+		&preview.Status,
+		&preview.Title,
+		&preview.Description,
+		&preview.SiteName,
+		&preview.ImageURL,
+		&preview.CreatedAt,
+		&preview.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightSnippet(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		headline string
+		expected string
+	}{
+		{
+			name:     "without matches",
+			headline: "plain text",
+			expected: "plain text",
+		},
+		{
+			name:     "several matches",
+			headline: searchHighlightStart + "Встреча" + searchHighlightStop + " at " + searchHighlightStart + "office" + searchHighlightStop,
+			expected: "<mark>Встреча</mark> at <mark>office</mark>",
+		},
+		{
+			name:     "html in text is escaped",
+			headline: "<script>" + searchHighlightStart + "alert" + searchHighlightStop + "</script> & co",
+			expected: "&lt;script&gt;<mark>alert</mark>&lt;/script&gt; &amp; co",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, highlightSnippet(tc.headline))
+		})
+	}
+}
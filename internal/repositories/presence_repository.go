@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	"github.com/DKhorkov/libs/cache"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	presenceConnectionsKeyTemplate = "presence:connections:%d"
+	presenceLastSeenKeyTemplate    = "presence:last_seen:%d"
+)
+
+// PresenceRepository keeps presence of Users in Redis, so it is shared between all application instances.
+// Connections counter of User expires, if it was not prolonged, to not keep User online forever after
+// crash of instance, which held his connection.
+type PresenceRepository struct {
+	cacheProvider cache.Provider
+	client        redis.Cmdable // Used for batch reading, which cache.Provider does not support
+}
+
+func NewPresenceRepository(cacheProvider cache.Provider, client redis.Cmdable) *PresenceRepository {
+	return &PresenceRepository{
+		cacheProvider: cacheProvider,
+		client:        client,
+	}
+}
+
+func (repo *PresenceRepository) AddConnection(
+	ctx context.Context,
+	userID uint64,
+	ttl time.Duration,
+) (int64, error) {
+	key := fmt.Sprintf(presenceConnectionsKeyTemplate, userID)
+
+	connections, err := repo.cacheProvider.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = repo.cacheProvider.GetEx(ctx, key, ttl); err != nil {
+		return 0, err
+	}
+
+	return connections, nil
+}
+
+func (repo *PresenceRepository) ProlongConnections(
+	ctx context.Context,
+	userID uint64,
+	ttl time.Duration,
+	lastSeenAt time.Time,
+) error {
+	key := fmt.Sprintf(presenceConnectionsKeyTemplate, userID)
+
+	_, err := repo.cacheProvider.GetEx(ctx, key, ttl)
+
+	switch {
+	case errors.Is(err, redis.Nil):
+		// Counter has already expired, but connection is still alive, so it should be restored:
+		if err = repo.cacheProvider.SetNX(ctx, key, 1, ttl); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	// Last seen time is updated on every prolongation to stay actual, if instance crashes:
+	return repo.setLastSeenAt(ctx, userID, lastSeenAt)
+}
+
+func (repo *PresenceRepository) RemoveConnection(
+	ctx context.Context,
+	userID uint64,
+	lastSeenAt time.Time,
+) (int64, error) {
+	key := fmt.Sprintf(presenceConnectionsKeyTemplate, userID)
+
+	connections, err := repo.cacheProvider.Decr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if connections > 0 {
+		return connections, nil
+	}
+
+	// Counter could become negative, if it had expired before connection was removed:
+	if err = repo.cacheProvider.Del(ctx, key); err != nil {
+		return 0, err
+	}
+
+	if err = repo.setLastSeenAt(ctx, userID, lastSeenAt); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+func (repo *PresenceRepository) GetPresence(
+	ctx context.Context,
+	userID uint64,
+) (*domains.Presence, error) {
+	presence := &domains.Presence{UserID: userID}
+
+	rawConnections, err := repo.cacheProvider.Get(ctx, fmt.Sprintf(presenceConnectionsKeyTemplate, userID))
+
+	switch {
+	case errors.Is(err, redis.Nil): // User has no alive connections
+	case err != nil:
+		return nil, err
+	default:
+		if presence.Online, err = parseConnections(rawConnections); err != nil {
+			return nil, err
+		}
+	}
+
+	rawLastSeenAt, err := repo.cacheProvider.Get(ctx, fmt.Sprintf(presenceLastSeenKeyTemplate, userID))
+
+	switch {
+	case errors.Is(err, redis.Nil): // User has never been online
+	case err != nil:
+		return nil, err
+	default:
+		if presence.LastSeenAt, err = parseLastSeenAt(rawLastSeenAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return presence, nil
+}
+
+// GetPresences returns presences of provided Users in order of their IDs. Keys of all Users are read in one
+// round trip.
+func (repo *PresenceRepository) GetPresences(
+	ctx context.Context,
+	userIDs []uint64,
+) ([]domains.Presence, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(userIDs)*2)
+	for _, userID := range userIDs {
+		keys = append(
+			keys,
+			fmt.Sprintf(presenceConnectionsKeyTemplate, userID),
+			fmt.Sprintf(presenceLastSeenKeyTemplate, userID),
+		)
+	}
+
+	values, err := repo.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	presences := make([]domains.Presence, len(userIDs))
+	for i, userID := range userIDs {
+		presences[i].UserID = userID
+
+		// Absent keys are returned as nil values:
+		if rawConnections, ok := values[i*2].(string); ok {
+			if presences[i].Online, err = parseConnections(rawConnections); err != nil {
+				return nil, err
+			}
+		}
+
+		if rawLastSeenAt, ok := values[i*2+1].(string); ok {
+			if presences[i].LastSeenAt, err = parseLastSeenAt(rawLastSeenAt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return presences, nil
+}
+
+func (repo *PresenceRepository) setLastSeenAt(
+	ctx context.Context,
+	userID uint64,
+	lastSeenAt time.Time,
+) error {
+	return repo.cacheProvider.Set(
+		ctx,
+		fmt.Sprintf(presenceLastSeenKeyTemplate, userID),
+		lastSeenAt.UnixMicro(),
+		0, // Last seen time should be kept forever
+	)
+}
+
+// parseConnections reports, whether User is online, by raw value of his connections counter.
+func parseConnections(rawConnections string) (bool, error) {
+	connections, err := strconv.ParseInt(rawConnections, 10, 64)
+	if err != nil {
+		return false, err
+	}
+
+	return connections > 0, nil
+}
+
+// parseLastSeenAt parses raw value of last seen time of User, which is stored in microseconds.
+func parseLastSeenAt(rawLastSeenAt string) (*time.Time, error) {
+	lastSeenAt, err := strconv.ParseInt(rawLastSeenAt, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return pointers.New(time.UnixMicro(lastSeenAt)), nil
+}
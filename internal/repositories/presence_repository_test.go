@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	mockcache "github.com/DKhorkov/libs/cache/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPresenceRepository_RemoveConnection(t *testing.T) {
+	t.Parallel()
+
+	const userID uint64 = 1
+
+	lastSeenAt := time.Now()
+
+	testCases := []struct {
+		name                string
+		decrementResult     int64
+		expectedConnections int64
+		expectLastSeen      bool
+	}{
+		{
+			name:                "other connections are alive",
+			decrementResult:     1,
+			expectedConnections: 1,
+		},
+		{
+			name:           "last connection",
+			expectLastSeen: true,
+		},
+		{
+			name:            "counter has expired before",
+			decrementResult: -1,
+			expectLastSeen:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			cacheProvider := mockcache.NewMockProvider(ctrl)
+
+			cacheProvider.EXPECT().
+				Decr(gomock.Any(), "presence:connections:1").
+				Return(tc.decrementResult, nil)
+
+			if tc.expectLastSeen {
+				cacheProvider.EXPECT().Del(gomock.Any(), "presence:connections:1").Return(nil)
+				cacheProvider.EXPECT().
+					Set(gomock.Any(), "presence:last_seen:1", lastSeenAt.UnixMicro(), time.Duration(0)).
+					Return(nil)
+			}
+
+			connections, err := NewPresenceRepository(cacheProvider, nil).
+				RemoveConnection(context.Background(), userID, lastSeenAt)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedConnections, connections)
+		})
+	}
+}
+
+func TestPresenceRepository_GetPresence(t *testing.T) {
+	t.Parallel()
+
+	const userID uint64 = 1
+
+	lastSeenAt := time.UnixMicro(time.Now().UnixMicro())
+	rawLastSeenAt := strconv.FormatInt(lastSeenAt.UnixMicro(), 10)
+
+	testCases := []struct {
+		name               string
+		connections        string
+		connectionsErr     error
+		lastSeenAt         string
+		lastSeenAtErr      error
+		expectedOnline     bool
+		expectedLastSeenAt *time.Time
+	}{
+		{
+			name:           "never been online",
+			connectionsErr: redis.Nil,
+			lastSeenAtErr:  redis.Nil,
+		},
+		{
+			name:               "online",
+			connections:        "2",
+			lastSeenAt:         rawLastSeenAt,
+			expectedOnline:     true,
+			expectedLastSeenAt: &lastSeenAt,
+		},
+		{
+			name:               "offline",
+			connectionsErr:     redis.Nil,
+			lastSeenAt:         rawLastSeenAt,
+			expectedLastSeenAt: &lastSeenAt,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			cacheProvider := mockcache.NewMockProvider(ctrl)
+
+			cacheProvider.EXPECT().
+				Get(gomock.Any(), "presence:connections:1").
+				Return(tc.connections, tc.connectionsErr)
+			cacheProvider.EXPECT().
+				Get(gomock.Any(), "presence:last_seen:1").
+				Return(tc.lastSeenAt, tc.lastSeenAtErr)
+
+			presence, err := NewPresenceRepository(cacheProvider, nil).GetPresence(context.Background(), userID)
+			require.NoError(t, err)
+			require.Equal(t, userID, presence.UserID)
+			require.Equal(t, tc.expectedOnline, presence.Online)
+			require.Equal(t, tc.expectedLastSeenAt, presence.LastSeenAt)
+		})
+	}
+}
+
+func TestPresenceRepository_GetPresences(t *testing.T) {
+	t.Parallel()
+
+	lastSeenAt := time.UnixMicro(time.Now().UnixMicro())
+	client := &mgetClient{
+		values: []any{"1", strconv.FormatInt(lastSeenAt.UnixMicro(), 10), nil, nil},
+	}
+
+	presences, err := NewPresenceRepository(nil, client).GetPresences(context.Background(), []uint64{1, 2})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{"presence:connections:1", "presence:last_seen:1", "presence:connections:2", "presence:last_seen:2"},
+		client.keys,
+	)
+	require.Equal(
+		t,
+		[]domains.Presence{
+			{UserID: 1, Online: true, LastSeenAt: &lastSeenAt},
+			{UserID: 2},
+		},
+		presences,
+	)
+}
+
+func TestMemoryPresenceRepository(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userID uint64 = 1
+		ttl           = time.Minute
+	)
+
+	ctx := context.Background()
+	repo := NewMemoryPresenceRepository()
+
+	presence, err := repo.GetPresence(ctx, userID)
+	require.NoError(t, err)
+	require.False(t, presence.Online)
+	require.Nil(t, presence.LastSeenAt)
+
+	for expected := range int64(2) {
+		connections, err := repo.AddConnection(ctx, userID, ttl)
+		require.NoError(t, err)
+		require.Equal(t, expected+1, connections)
+	}
+
+	presence, err = repo.GetPresence(ctx, userID)
+	require.NoError(t, err)
+	require.True(t, presence.Online)
+
+	presences, err := repo.GetPresences(ctx, []uint64{userID, userID + 1})
+	require.NoError(t, err)
+	require.Equal(t, []domains.Presence{{UserID: userID, Online: true}, {UserID: userID + 1}}, presences)
+
+	lastSeenAt := time.Now()
+	for expected := int64(1); expected >= 0; expected-- {
+		connections, err := repo.RemoveConnection(ctx, userID, lastSeenAt)
+		require.NoError(t, err)
+		require.Equal(t, expected, connections)
+	}
+
+	presence, err = repo.GetPresence(ctx, userID)
+	require.NoError(t, err)
+	require.False(t, presence.Online)
+	require.NotNil(t, presence.LastSeenAt)
+	require.True(t, lastSeenAt.Equal(*presence.LastSeenAt))
+
+	// Connection, which was not prolonged, expires:
+	_, err = repo.AddConnection(ctx, userID, 0)
+	require.NoError(t, err)
+
+	presence, err = repo.GetPresence(ctx, userID)
+	require.NoError(t, err)
+	require.False(t, presence.Online)
+}
+
+// mgetClient returns predefined values of keys, which are read in batch.
+type mgetClient struct {
+	redis.Cmdable
+
+	keys   []string
+	values []any
+}
+
+func (c *mgetClient) MGet(_ context.Context, keys ...string) *redis.SliceCmd {
+	c.keys = keys
+
+	return redis.NewSliceResult(c.values, nil)
+}
@@ -23,6 +23,17 @@ const (
 	asc                 = "ASC"
 )
 
+// userColumns lists users table columns in order of domains.User fields:
+var userColumns = []string{
+	idColumnName,
+	usernameColumnName,
+	emailColumnName,
+	emailConfirmedColumnName,
+	passwordColumnName,
+	createdAtColumnName,
+	updatedAtColumnName,
+}
+
 type UsersRepository struct {
 	tx pg.Transaction
 }
@@ -37,7 +48,7 @@ func NewUsersRepository(
 
 func (repo *UsersRepository) GetUserByID(ctx context.Context, id uint64) (*domains.User, error) {
 	stmt, params, err := sq.
-		Select(selectAllColumns).
+		Select(userColumns...).
 		From(usersTableName).
 		Where(sq.Eq{idColumnName: id}).
 		PlaceholderFormat(sq.Dollar).
@@ -47,9 +58,7 @@ func (repo *UsersRepository) GetUserByID(ctx context.Context, id uint64) (*domai
 	}
 
 	user := &domains.User{}
-
-	columns := pg.GetEntityColumns(user)
-	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanUser(user)...); err != nil {
 		return nil, err
 	}
 
@@ -61,7 +70,7 @@ func (repo *UsersRepository) GetUserByUsername(
 	username string,
 ) (*domains.User, error) {
 	stmt, params, err := sq.
-		Select(selectAllColumns).
+		Select(userColumns...).
 		From(usersTableName).
 		Where(sq.Eq{usernameColumnName: username}).
 		PlaceholderFormat(sq.Dollar).
@@ -71,9 +80,7 @@ func (repo *UsersRepository) GetUserByUsername(
 	}
 
 	user := &domains.User{}
-
-	columns := pg.GetEntityColumns(user)
-	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanUser(user)...); err != nil {
 		return nil, err
 	}
 
@@ -85,7 +92,7 @@ func (repo *UsersRepository) GetUserByEmail(
 	email string,
 ) (*domains.User, error) {
 	stmt, params, err := sq.
-		Select(selectAllColumns).
+		Select(userColumns...).
 		From(usersTableName).
 		Where(sq.Eq{emailColumnName: email}).
 		PlaceholderFormat(sq.Dollar).
@@ -95,9 +102,7 @@ func (repo *UsersRepository) GetUserByEmail(
 	}
 
 	user := &domains.User{}
-
-	columns := pg.GetEntityColumns(user)
-	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(columns...); err != nil {
+	if err = repo.tx.QueryRowContext(ctx, stmt, params...).Scan(scanUser(user)...); err != nil {
 		return nil, err
 	}
 
@@ -110,7 +115,7 @@ func (repo *UsersRepository) GetUsers(
 	pagination *domains.Pagination,
 ) ([]domains.User, error) {
 	builder := sq.
-		Select(selectAllColumns).
+		Select(userColumns...).
 		From(usersTableName).
 		OrderBy(fmt.Sprintf("%s %s", idColumnName, desc)).
 		PlaceholderFormat(sq.Dollar)
@@ -168,10 +173,7 @@ func (repo *UsersRepository) GetUsers(
 
 	for rows.Next() {
 		user := domains.User{}
-		columns := pg.GetEntityColumns(&user) // Only pointer to use rows.Scan() successfully
-
-		err = rows.Scan(columns...)
-		if err != nil {
+		if err = rows.Scan(scanUser(&user)...); err != nil {
 			return nil, err
 		}
 
@@ -209,3 +211,16 @@ func (repo *UsersRepository) UpdateUser(
 
 	return err
 }
+
+// scanUser returns pointers on domains.User fields, which are stored in users table.
+func scanUser(user *domains.User) []any {
+	return []any{
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.EmailConfirmed,
+		&user.Password,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScanUser checks, that every domains.User field is read from users table, so adding field to User without
+// adding column breaks the test instead of every query of users.
+func TestScanUser(t *testing.T) {
+	t.Parallel()
+
+	user := &domains.User{}
+
+	require.Len(t, scanUser(user), len(userColumns))
+	require.Equal(t, pg.GetEntityColumns(user), scanUser(user))
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	"github.com/google/uuid"
+)
+
+const (
+	attachmentStorageKeyTemplate = "chats/%d/%s"
+	thumbnailStorageKeyTemplate  = "%s_%s" // Thumbnail is stored next to attachment
+)
+
+type AttachmentsService struct {
+	uow                          interfaces.UnitOfWork
+	newAttachmentsRepositoryFunc func(tx pg.Transaction) interfaces.AttachmentsRepository
+	newBlobStorageFunc           func() interfaces.BlobStorage
+	newImagePreviewGeneratorFunc func() interfaces.ImagePreviewGenerator
+}
+
+func NewAttachmentsService(
+	uow interfaces.UnitOfWork,
+	newAttachmentsRepositoryFunc func(tx pg.Transaction) interfaces.AttachmentsRepository,
+	newBlobStorageFunc func() interfaces.BlobStorage,
+	newImagePreviewGeneratorFunc func() interfaces.ImagePreviewGenerator,
+) *AttachmentsService {
+	return &AttachmentsService{
+		uow:                          uow,
+		newAttachmentsRepositoryFunc: newAttachmentsRepositoryFunc,
+		newBlobStorageFunc:           newBlobStorageFunc,
+		newImagePreviewGeneratorFunc: newImagePreviewGeneratorFunc,
+	}
+}
+
+// UploadAttachment streams attachment content to blob storage, calculating its size and checksum on the fly,
+// and saves attachment. Content is deleted from storage, if attachment could not be saved. Preview of
+// supported images is generated later by background worker.
+func (s *AttachmentsService) UploadAttachment(
+	ctx context.Context,
+	attachmentData domains.UploadAttachmentDTO,
+) (attachment *domains.Attachment, err error) {
+	blobStorage := s.newBlobStorageFunc()
+	storageKey := fmt.Sprintf(attachmentStorageKeyTemplate, attachmentData.ChatID, uuid.NewString())
+	content := &attachmentReader{
+		reader:  attachmentData.Content,
+		maxSize: attachmentData.MaxSize,
+		hash:    sha256.New(),
+	}
+
+	defer func() {
+		if err != nil {
+			// Storage may keep partially uploaded content, which is not needed anymore:
+			_ = blobStorage.Delete(context.WithoutCancel(ctx), storageKey)
+		}
+	}()
+
+	if err = blobStorage.Put(ctx, storageKey, content, attachmentData.MIMEType); err != nil {
+		return nil, err
+	}
+
+	previewStatus := domains.AttachmentPreviewStatusNone
+	if s.newImagePreviewGeneratorFunc().Supports(attachmentData.MIMEType) {
+		previewStatus = domains.AttachmentPreviewStatusPending
+	}
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			attachmentID, err := attachmentsRepository.CreateAttachment(
+				ctx,
+				domains.CreateAttachmentDTO{
+					ChatID:        attachmentData.ChatID,
+					UploaderID:    attachmentData.UploaderID,
+					StorageKey:    storageKey,
+					Filename:      attachmentData.Filename,
+					MIMEType:      attachmentData.MIMEType,
+					Size:          uint64(content.size),
+					Checksum:      hex.EncodeToString(content.hash.Sum(nil)),
+					PreviewStatus: previewStatus,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			if attachment, err = attachmentsRepository.GetAttachmentByID(ctx, attachmentID); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentsService) GetAttachmentByID(
+	ctx context.Context,
+	id uint64,
+) (attachment *domains.Attachment, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+			if attachment, err = attachmentsRepository.GetAttachmentByID(ctx, id); err != nil {
+				return err
+			}
+
+			attachments := []domains.Attachment{*attachment}
+			if err = attachAttachmentsThumbnails(ctx, attachmentsRepository, attachments); err != nil {
+				return err
+			}
+
+			attachment = &attachments[0]
+
+			return nil
+		},
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrAttachmentNotFound, err)
+	case err != nil:
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// DownloadAttachment provides URL for direct download of attachment or its thumbnail with provided name from
+// storage or, if storage does not support it, content of attachment. Original is provided for empty name.
+func (s *AttachmentsService) DownloadAttachment(
+	ctx context.Context,
+	attachment domains.Attachment,
+	thumbnailName domains.ThumbnailName,
+) (*domains.AttachmentDownload, error) {
+	storageKey := attachment.StorageKey
+	download := &domains.AttachmentDownload{
+		Filename: attachment.Filename,
+		MIMEType: attachment.MIMEType,
+		Size:     attachment.Size,
+	}
+
+	if thumbnailName != "" {
+		index := slices.IndexFunc(
+			attachment.Thumbnails,
+			func(thumbnail domains.AttachmentThumbnail) bool {
+				return thumbnail.Name == thumbnailName
+			},
+		)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: thumbnail not found", customerrors.ErrAttachmentNotFound)
+		}
+
+		thumbnail := attachment.Thumbnails[index]
+		storageKey = thumbnail.StorageKey
+		download.Filename = thumbnailFilename(attachment.Filename, thumbnail)
+		download.MIMEType = thumbnail.MIMEType
+		download.Size = thumbnail.Size
+	}
+
+	blobStorage := s.newBlobStorageFunc()
+
+	var err error
+	if download.URL, err = blobStorage.GetDownloadURL(ctx, storageKey, download.Filename, download.MIMEType); err != nil {
+		return nil, err
+	}
+
+	if download.URL != "" {
+		return download, nil
+	}
+
+	if download.Content, err = blobStorage.Get(ctx, storageKey); err != nil {
+		return nil, err
+	}
+
+	return download, nil
+}
+
+// ClaimAttachmentsForPreview takes batch of attachments, which are waiting for preview generation.
+// Attachments, which have been processed longer than claimTimeout, are taken again.
+func (s *AttachmentsService) ClaimAttachmentsForPreview(
+	ctx context.Context,
+	limit uint64,
+	claimTimeout time.Duration,
+) (attachments []domains.Attachment, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			attachments, err = attachmentsRepository.ClaimAttachmentsForPreview(
+				ctx,
+				limit,
+				time.Now().Add(-claimTimeout),
+			)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// GenerateAttachmentPreview generates thumbnails of claimed attachment and saves them along with image
+// dimensions and blurhash. Attachment, which content is not a valid image, is marked as failed and is not
+// processed again.
+func (s *AttachmentsService) GenerateAttachmentPreview(
+	ctx context.Context,
+	attachment domains.Attachment,
+) (*domains.Attachment, error) {
+	blobStorage := s.newBlobStorageFunc()
+
+	content, err := blobStorage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := s.newImagePreviewGeneratorFunc().Generate(content)
+	_ = content.Close()
+
+	switch {
+	case errors.Is(err, customerrors.ErrInvalidImage):
+		return s.savePreview(
+			ctx,
+			domains.UpdateAttachmentPreviewDTO{
+				AttachmentID: attachment.ID,
+				Status:       domains.AttachmentPreviewStatusFailed,
+			},
+			nil,
+		)
+	case err != nil:
+		return nil, err
+	}
+
+	thumbnails := make([]domains.CreateAttachmentThumbnailDTO, len(preview.Thumbnails))
+	for i, thumbnail := range preview.Thumbnails {
+		thumbnails[i] = domains.CreateAttachmentThumbnailDTO{
+			AttachmentID: attachment.ID,
+			Name:         thumbnail.Name,
+			StorageKey:   fmt.Sprintf(thumbnailStorageKeyTemplate, attachment.StorageKey, thumbnail.Name),
+			MIMEType:     thumbnail.MIMEType,
+			Size:         uint64(len(thumbnail.Content)),
+			Width:        thumbnail.Width,
+			Height:       thumbnail.Height,
+		}
+
+		err = blobStorage.Put(
+			ctx,
+			thumbnails[i].StorageKey,
+			bytes.NewReader(thumbnail.Content),
+			thumbnail.MIMEType,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.savePreview(
+		ctx,
+		domains.UpdateAttachmentPreviewDTO{
+			AttachmentID: attachment.ID,
+			Status:       domains.AttachmentPreviewStatusReady,
+			Width:        &preview.Width,
+			Height:       &preview.Height,
+			Blurhash:     &preview.Blurhash,
+		},
+		thumbnails,
+	)
+}
+
+// DeleteAttachmentsContent deletes content of deleted attachments and their thumbnails from storage. Deletion
+// of other content is not interrupted by failure.
+func (s *AttachmentsService) DeleteAttachmentsContent(ctx context.Context, storageKeys []string) error {
+	blobStorage := s.newBlobStorageFunc()
+
+	var errs []error
+
+	for _, storageKey := range storageKeys {
+		if err := blobStorage.Delete(ctx, storageKey); err != nil {
+			errs = append(errs, fmt.Errorf("storage key %s: %w", storageKey, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *AttachmentsService) savePreview(
+	ctx context.Context,
+	previewData domains.UpdateAttachmentPreviewDTO,
+	thumbnails []domains.CreateAttachmentThumbnailDTO,
+) (attachment *domains.Attachment, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			if len(thumbnails) > 0 {
+				if err = attachmentsRepository.CreateAttachmentThumbnails(ctx, thumbnails...); err != nil {
+					return err
+				}
+			}
+
+			if err = attachmentsRepository.UpdateAttachmentPreview(ctx, previewData); err != nil {
+				return err
+			}
+
+			attachment, err = attachmentsRepository.GetAttachmentByID(ctx, previewData.AttachmentID)
+			if err != nil {
+				return err
+			}
+
+			attachments := []domains.Attachment{*attachment}
+			if err = attachAttachmentsThumbnails(ctx, attachmentsRepository, attachments); err != nil {
+				return err
+			}
+
+			attachment = &attachments[0]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// attachAttachmentsThumbnails sets thumbnails to attachments with ready preview.
+func attachAttachmentsThumbnails(
+	ctx context.Context,
+	attachmentsRepository interfaces.AttachmentsRepository,
+	attachments []domains.Attachment,
+) error {
+	var attachmentIDs []uint64
+
+	for _, attachment := range attachments {
+		if attachment.PreviewStatus == domains.AttachmentPreviewStatusReady {
+			attachmentIDs = append(attachmentIDs, attachment.ID)
+		}
+	}
+
+	if len(attachmentIDs) == 0 {
+		return nil
+	}
+
+	thumbnails, err := attachmentsRepository.GetAttachmentsThumbnails(ctx, attachmentIDs)
+	if err != nil {
+		return err
+	}
+
+	thumbnailsByAttachment := make(map[uint64][]domains.AttachmentThumbnail)
+	for _, thumbnail := range thumbnails {
+		thumbnailsByAttachment[thumbnail.AttachmentID] = append(
+			thumbnailsByAttachment[thumbnail.AttachmentID],
+			thumbnail,
+		)
+	}
+
+	for i := range attachments {
+		attachments[i].Thumbnails = thumbnailsByAttachment[attachments[i].ID]
+	}
+
+	return nil
+}
+
+// thumbnailFilename builds name of thumbnail file from name of attachment, for example "photo_small.png".
+func thumbnailFilename(filename string, thumbnail domains.AttachmentThumbnail) string {
+	extension := ".png"
+	if extensions, err := mime.ExtensionsByType(thumbnail.MIMEType); err == nil && len(extensions) > 0 {
+		extension = extensions[0]
+	}
+
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + "_" + string(thumbnail.Name) + extension
+}
+
+// attachmentReader reads attachment content, calculating its size and checksum. Reading fails, when content
+// exceeds maximum size, to stop upload as soon as possible.
+type attachmentReader struct {
+	reader  io.Reader
+	maxSize int64
+	size    int64
+	hash    hash.Hash
+}
+
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	r.hash.Write(p[:n])
+
+	if r.size > r.maxSize {
+		return n, fmt.Errorf(
+			"%w: maximum size is %d bytes",
+			customerrors.ErrAttachmentTooLarge,
+			r.maxSize,
+		)
+	}
+
+	return n, err
+}
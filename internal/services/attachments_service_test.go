@@ -0,0 +1,180 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/services"
+	mockpreviews "github.com/DKhorkov/kfc/mocks/previews"
+	mockrepositories "github.com/DKhorkov/kfc/mocks/repositories"
+	mockstorages "github.com/DKhorkov/kfc/mocks/storages"
+	mockunitofwork "github.com/DKhorkov/kfc/mocks/uow"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttachmentsService_GenerateAttachmentPreview(t *testing.T) {
+	t.Parallel()
+
+	attachment := domains.Attachment{
+		ID:            1,
+		ChatID:        2,
+		StorageKey:    "chats/2/key",
+		MIMEType:      "image/png",
+		PreviewStatus: domains.AttachmentPreviewStatusProcessing,
+	}
+
+	preview := &domains.ImagePreview{
+		Width:    100,
+		Height:   50,
+		Blurhash: "LEHV6nWB2yk8",
+		Thumbnails: []domains.ImageThumbnail{
+			{Name: domains.ThumbnailNameSmall, MIMEType: "image/png", Width: 16, Height: 8, Content: []byte("small")},
+		},
+	}
+
+	thumbnail := domains.AttachmentThumbnail{
+		ID:           3,
+		AttachmentID: attachment.ID,
+		Name:         domains.ThumbnailNameSmall,
+		StorageKey:   "chats/2/key_small",
+		MIMEType:     "image/png",
+		Size:         5,
+		Width:        16,
+		Height:       8,
+	}
+
+	testCases := []struct {
+		name            string
+		generateError   error
+		expectedStatus  domains.AttachmentPreviewStatus
+		errorExpected   bool
+		expectedUpdated bool
+	}{
+		{
+			name:            "ready",
+			expectedStatus:  domains.AttachmentPreviewStatusReady,
+			expectedUpdated: true,
+		},
+		{
+			name:            "invalid image",
+			generateError:   customerrors.ErrInvalidImage,
+			expectedStatus:  domains.AttachmentPreviewStatusFailed,
+			expectedUpdated: true,
+		},
+		{
+			name:          "unexpected error is retried later",
+			generateError: errors.New("out of memory"),
+			errorExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+			blobStorage := mockstorages.NewMockBlobStorage(ctrl)
+			generator := mockpreviews.NewMockImagePreviewGenerator(ctrl)
+
+			blobStorage.EXPECT().
+				Get(gomock.Any(), attachment.StorageKey).
+				Return(io.NopCloser(strings.NewReader("content")), nil)
+
+			if tc.generateError != nil {
+				generator.EXPECT().Generate(gomock.Any()).Return(nil, tc.generateError)
+			} else {
+				generator.EXPECT().Generate(gomock.Any()).Return(preview, nil)
+				blobStorage.EXPECT().
+					Put(gomock.Any(), thumbnail.StorageKey, gomock.Any(), thumbnail.MIMEType).
+					Return(nil)
+				attachmentsRepository.EXPECT().
+					CreateAttachmentThumbnails(
+						gomock.Any(),
+						domains.CreateAttachmentThumbnailDTO{
+							AttachmentID: attachment.ID,
+							Name:         thumbnail.Name,
+							StorageKey:   thumbnail.StorageKey,
+							MIMEType:     thumbnail.MIMEType,
+							Size:         thumbnail.Size,
+							Width:        thumbnail.Width,
+							Height:       thumbnail.Height,
+						},
+					).
+					Return(nil)
+			}
+
+			var expectedAttachment *domains.Attachment
+
+			if tc.expectedUpdated {
+				unitOfWork.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+							return fn(ctx, nil)
+						},
+					)
+
+				updated := attachment
+				updated.PreviewStatus = tc.expectedStatus
+				previewData := domains.UpdateAttachmentPreviewDTO{
+					AttachmentID: attachment.ID,
+					Status:       tc.expectedStatus,
+				}
+
+				if tc.expectedStatus == domains.AttachmentPreviewStatusReady {
+					previewData.Width = pointers.New(preview.Width)
+					previewData.Height = pointers.New(preview.Height)
+					previewData.Blurhash = pointers.New(preview.Blurhash)
+					updated.Width, updated.Height, updated.Blurhash = previewData.Width, previewData.Height, previewData.Blurhash
+
+					attachmentsRepository.EXPECT().
+						GetAttachmentsThumbnails(gomock.Any(), []uint64{attachment.ID}).
+						Return([]domains.AttachmentThumbnail{thumbnail}, nil)
+				}
+
+				attachmentsRepository.EXPECT().UpdateAttachmentPreview(gomock.Any(), previewData).Return(nil)
+				attachmentsRepository.EXPECT().GetAttachmentByID(gomock.Any(), attachment.ID).Return(&updated, nil)
+
+				expectedAttachment = &updated
+				if tc.expectedStatus == domains.AttachmentPreviewStatusReady {
+					expectedAttachment.Thumbnails = []domains.AttachmentThumbnail{thumbnail}
+				}
+			}
+
+			service := services.NewAttachmentsService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				func() interfaces.BlobStorage {
+					return blobStorage
+				},
+				func() interfaces.ImagePreviewGenerator {
+					return generator
+				},
+			)
+
+			processed, err := service.GenerateAttachmentPreview(context.Background(), attachment)
+			if tc.errorExpected {
+				require.ErrorIs(t, err, tc.generateError)
+				require.Nil(t, processed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, expectedAttachment, processed)
+		})
+	}
+}
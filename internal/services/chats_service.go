@@ -0,0 +1,1105 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+)
+
+// chatInviteTokenSize is a number of random bytes in invite token, which makes it impossible to guess.
+const chatInviteTokenSize = 32
+
+type ChatsService struct {
+	uow                    interfaces.UnitOfWork
+	newChatsRepositoryFunc func(tx pg.Transaction) interfaces.ChatsRepository
+}
+
+func NewChatsService(
+	uow interfaces.UnitOfWork,
+	newChatsRepositoryFunc func(tx pg.Transaction) interfaces.ChatsRepository,
+) *ChatsService {
+	return &ChatsService{
+		uow:                    uow,
+		newChatsRepositoryFunc: newChatsRepositoryFunc,
+	}
+}
+
+func (s *ChatsService) GetOrCreateDirectChat(
+	ctx context.Context,
+	userID, interlocutorID uint64,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			chat, err = chatsRepository.GetDirectChat(ctx, userID, interlocutorID)
+
+			switch {
+			case err == nil:
+				return attachChatsMembers(ctx, chatsRepository, chat)
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+
+			var chatID uint64
+
+			chatID, err = chatsRepository.CreateDirectChat(ctx, userID, interlocutorID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows): // Чат был создан параллельным запросом:
+				if chat, err = chatsRepository.GetDirectChat(ctx, userID, interlocutorID); err != nil {
+					return err
+				}
+
+				return attachChatsMembers(ctx, chatsRepository, chat)
+			case err != nil:
+				return err
+			}
+
+			if err = chatsRepository.AddChatMembers(
+				ctx,
+				chatID,
+				domains.ChatMemberRoleMember,
+				userID,
+				interlocutorID,
+			); err != nil {
+				return err
+			}
+
+			if chat, err = chatsRepository.GetChatByID(ctx, chatID); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func (s *ChatsService) CreateGroupChat(
+	ctx context.Context,
+	chatData domains.CreateGroupChatDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			chatID, err := chatsRepository.CreateGroupChat(ctx, chatData.Title)
+			if err != nil {
+				return err
+			}
+
+			err = chatsRepository.AddChatMembers(
+				ctx,
+				chatID,
+				domains.ChatMemberRoleOwner,
+				chatData.OwnerID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(chatData.MemberIDs) > 0 {
+				err = chatsRepository.AddChatMembers(
+					ctx,
+					chatID,
+					domains.ChatMemberRoleMember,
+					chatData.MemberIDs...,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			if chat, err = chatsRepository.GetChatByID(ctx, chatID); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// CreateChannel creates channel, where current User becomes owner. Channel with handle is public and can be
+// found by it.
+func (s *ChatsService) CreateChannel(
+	ctx context.Context,
+	channelData domains.CreateChannelDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			chatID, err := chatsRepository.CreateChannel(ctx, channelData.Title, channelData.Handle)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrChannelHandleTaken, err)
+			case err != nil:
+				return err
+			}
+
+			err = chatsRepository.AddChatMembers(
+				ctx,
+				chatID,
+				domains.ChatMemberRoleOwner,
+				channelData.OwnerID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if chat, err = chatsRepository.GetChatByID(ctx, chatID); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// GetChannels provides public channels with filtration and pagination.
+func (s *ChatsService) GetChannels(
+	ctx context.Context,
+	filters *domains.ChannelsFilters,
+	pagination *domains.Pagination,
+) (channels []domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			channels, err = s.newChatsRepositoryFunc(tx).GetChannels(ctx, filters, pagination)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// SubscribeToChannel adds User to public channel as a subscriber. Private channels can be joined only by
+// invites, so they are treated as not found.
+func (s *ChatsService) SubscribeToChannel(
+	ctx context.Context,
+	chatID, userID uint64,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			chat, err = chatsRepository.GetChatByIDForUpdate(ctx, chatID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+			case err != nil:
+				return err
+			case chat.Type != domains.ChatTypeChannel || chat.Handle == nil:
+				return customerrors.ErrChatNotFound
+			}
+
+			_, err = chatsRepository.GetChatMember(ctx, chatID, userID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				err = chatsRepository.AddChatMembers(ctx, chatID, domains.ChatMemberRoleMember, userID)
+				if err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// AddGroupChatMembers adds Users to group chat. Users, who are already members of the chat, are skipped.
+func (s *ChatsService) AddGroupChatMembers(
+	ctx context.Context,
+	membersData domains.AddChatMembersDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			var actor *domains.ChatMember
+
+			chat, actor, err = lockGroupChat(ctx, chatsRepository, membersData.ChatID, membersData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if !actor.Role.CanManageMembers() {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			members, err := chatsRepository.GetChatsMembers(ctx, []uint64{chat.ID})
+			if err != nil {
+				return err
+			}
+
+			newMembersIDs := make([]uint64, 0, len(membersData.UserIDs))
+			for _, userID := range membersData.UserIDs {
+				isMember := slices.ContainsFunc(
+					members,
+					func(member domains.ChatMember) bool {
+						return member.UserID == userID
+					},
+				)
+
+				if !isMember && !slices.Contains(newMembersIDs, userID) {
+					newMembersIDs = append(newMembersIDs, userID)
+				}
+			}
+
+			// Only group chats are limited, since posting to channels does not touch their subscribers:
+			if chat.Type == domains.ChatTypeGroup && len(members)+len(newMembersIDs) > membersData.MembersLimit {
+				return fmt.Errorf(
+					"%w: chat can not have more than %d members",
+					customerrors.ErrChatMembersLimitExceeded,
+					membersData.MembersLimit,
+				)
+			}
+
+			if len(newMembersIDs) > 0 {
+				err = chatsRepository.AddChatMembers(
+					ctx,
+					chat.ID,
+					domains.ChatMemberRoleMember,
+					newMembersIDs...,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// RemoveGroupChatMember removes member from group chat. Owner can remove admins and members,
+// admins - only members.
+func (s *ChatsService) RemoveGroupChatMember(
+	ctx context.Context,
+	memberData domains.ChangeChatMemberDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			var actor *domains.ChatMember
+
+			chat, actor, err = lockGroupChat(ctx, chatsRepository, memberData.ChatID, memberData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			member, err := getChatMember(ctx, chatsRepository, chat.ID, memberData.UserID)
+			if err != nil {
+				return wrapChatMemberNotFound(err)
+			}
+
+			// Owner can not be removed by anyone and can only leave chat:
+			canRemove := member.Role != domains.ChatMemberRoleOwner &&
+				(actor.Role == domains.ChatMemberRoleOwner || member.Role == domains.ChatMemberRoleMember)
+			if !actor.Role.CanManageMembers() || !canRemove {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			if err = chatsRepository.DeleteChatMember(ctx, chat.ID, member.UserID); err != nil {
+				return err
+			}
+
+			if err = chatsRepository.DeleteThreadParticipants(ctx, chat.ID, member.UserID); err != nil {
+				return err
+			}
+
+			if _, err = chatsRepository.DeleteChatDraft(ctx, chat.ID, member.UserID); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// ChangeGroupChatMemberRole promotes or demotes member of group chat. Only owner can change roles.
+func (s *ChatsService) ChangeGroupChatMemberRole(
+	ctx context.Context,
+	roleData domains.ChangeChatMemberRoleDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			var actor *domains.ChatMember
+
+			chat, actor, err = lockGroupChat(ctx, chatsRepository, roleData.ChatID, roleData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if actor.Role != domains.ChatMemberRoleOwner {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			member, err := getChatMember(ctx, chatsRepository, chat.ID, roleData.UserID)
+			if err != nil {
+				return wrapChatMemberNotFound(err)
+			}
+
+			// Owner role can be only passed to other member, when owner leaves chat:
+			if member.Role == domains.ChatMemberRoleOwner {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			if member.Role != roleData.Role {
+				err = chatsRepository.UpdateChatMemberRole(ctx, chat.ID, member.UserID, roleData.Role)
+				if err != nil {
+					return err
+				}
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// LeaveGroupChat removes User from group chat. If owner leaves, ownership is passed to the oldest admin
+// or, if there are no admins, to the oldest member. Chat is deleted, when its last member leaves.
+func (s *ChatsService) LeaveGroupChat(ctx context.Context, chatID, userID uint64) error {
+	return s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			_, member, err := lockGroupChat(ctx, chatsRepository, chatID, userID)
+			if err != nil {
+				return err
+			}
+
+			members, err := chatsRepository.GetChatsMembers(ctx, []uint64{chatID})
+			if err != nil {
+				return err
+			}
+
+			successor := chooseChatOwnerSuccessor(members, userID)
+			if successor == nil {
+				return chatsRepository.DeleteChat(ctx, chatID)
+			}
+
+			if err = chatsRepository.DeleteChatMember(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			if err = chatsRepository.DeleteThreadParticipants(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			if _, err = chatsRepository.DeleteChatDraft(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			if member.Role != domains.ChatMemberRoleOwner {
+				return nil
+			}
+
+			return chatsRepository.UpdateChatMemberRole(
+				ctx,
+				chatID,
+				successor.UserID,
+				domains.ChatMemberRoleOwner,
+			)
+		},
+	)
+}
+
+// CreateChatInvite creates invite link to group chat. Available for owner and admins of the chat.
+func (s *ChatsService) CreateChatInvite(
+	ctx context.Context,
+	inviteData domains.CreateChatInviteDTO,
+) (invite *domains.ChatInvite, err error) {
+	token, err := generateChatInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			_, actor, err := lockGroupChat(ctx, chatsRepository, inviteData.ChatID, inviteData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if !actor.Role.CanManageInvites() {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			inviteID, err := chatsRepository.CreateChatInvite(ctx, inviteData, token)
+			if err != nil {
+				return err
+			}
+
+			invite, err = chatsRepository.GetChatInviteByID(ctx, inviteID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// GetChatInvites returns all invites of group chat. Available for owner and admins of the chat.
+func (s *ChatsService) GetChatInvites(
+	ctx context.Context,
+	chatID, actorID uint64,
+) (invites []domains.ChatInvite, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			actor, err := getChatMember(ctx, chatsRepository, chatID, actorID)
+			if err != nil {
+				return err
+			}
+
+			// Direct chats have only members, so this also rejects them:
+			if !actor.Role.CanManageInvites() {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			invites, err = chatsRepository.GetChatInvites(ctx, chatID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+// RevokeChatInvite revokes invite link to group chat, so nobody can join chat by it anymore. Available for
+// owner and admins of the chat.
+func (s *ChatsService) RevokeChatInvite(ctx context.Context, inviteData domains.RevokeChatInviteDTO) error {
+	return s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			chat, actor, err := lockGroupChat(ctx, chatsRepository, inviteData.ChatID, inviteData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if !actor.Role.CanManageInvites() {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			invite, err := chatsRepository.GetChatInviteByID(ctx, inviteData.InviteID)
+			if err != nil {
+				return wrapChatInviteNotFound(err)
+			}
+
+			if invite.ChatID != chat.ID {
+				return customerrors.ErrChatInviteNotFound
+			}
+
+			return chatsRepository.RevokeChatInvite(ctx, invite.ID)
+		},
+	)
+}
+
+// JoinChatByInvite adds User to group chat by invite token and records the use of invite. If User is already
+// a member of the chat, invite is not used.
+func (s *ChatsService) JoinChatByInvite(
+	ctx context.Context,
+	joinData domains.JoinChatByInviteDTO,
+) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			invite, err := chatsRepository.GetChatInviteByToken(ctx, joinData.Token)
+			if err != nil {
+				return wrapChatInviteNotFound(err)
+			}
+
+			// Chat is locked before invite is checked to serialize joins with each other, with revocation
+			// of invite and with other changes of members:
+			chat, err = chatsRepository.GetChatByIDForUpdate(ctx, invite.ChatID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+			case err != nil:
+				return err
+			}
+
+			if invite, err = chatsRepository.GetChatInviteByID(ctx, invite.ID); err != nil {
+				return wrapChatInviteNotFound(err)
+			}
+
+			if err = checkChatInviteActive(invite, time.Now()); err != nil {
+				return err
+			}
+
+			_, err = chatsRepository.GetChatMember(ctx, chat.ID, joinData.UserID)
+
+			switch {
+			case err == nil: // User is already a member of the chat
+				return attachChatsMembers(ctx, chatsRepository, chat)
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+
+			if chat.Type == domains.ChatTypeGroup {
+				membersCounts, err := chatsRepository.GetChatsMembersCounts(ctx, []uint64{chat.ID})
+				if err != nil {
+					return err
+				}
+
+				if int(membersCounts[chat.ID])+1 > joinData.MembersLimit {
+					return fmt.Errorf(
+						"%w: chat can not have more than %d members",
+						customerrors.ErrChatMembersLimitExceeded,
+						joinData.MembersLimit,
+					)
+				}
+			}
+
+			err = chatsRepository.AddChatMembers(
+				ctx,
+				chat.ID,
+				domains.ChatMemberRoleMember,
+				joinData.UserID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err = chatsRepository.IncrementChatInviteUses(ctx, invite.ID); err != nil {
+				return err
+			}
+
+			if err = chatsRepository.CreateChatInviteUse(ctx, invite.ID, joinData.UserID); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+// SaveChatDraft creates or replaces draft of chat member.
+func (s *ChatsService) SaveChatDraft(
+	ctx context.Context,
+	draftData domains.SaveChatDraftDTO,
+) (draft *domains.ChatDraft, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			if _, err = getChatMember(ctx, chatsRepository, draftData.ChatID, draftData.UserID); err != nil {
+				return err
+			}
+
+			if _, err = chatsRepository.SaveChatDraft(ctx, draftData); err != nil {
+				return err
+			}
+
+			draft, err = chatsRepository.GetChatDraft(ctx, draftData.ChatID, draftData.UserID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// GetChatDraft returns draft of chat member.
+func (s *ChatsService) GetChatDraft(
+	ctx context.Context,
+	chatID, userID uint64,
+) (draft *domains.ChatDraft, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			if _, err = getChatMember(ctx, chatsRepository, chatID, userID); err != nil {
+				return err
+			}
+
+			draft, err = chatsRepository.GetChatDraft(ctx, chatID, userID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %w", customerrors.ErrChatDraftNotFound, err)
+			}
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// DeleteChatDraft deletes draft of chat member, for example, when it was sent or cleared.
+func (s *ChatsService) DeleteChatDraft(ctx context.Context, chatID, userID uint64) error {
+	return s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			if _, err := getChatMember(ctx, chatsRepository, chatID, userID); err != nil {
+				return err
+			}
+
+			deleted, err := chatsRepository.DeleteChatDraft(ctx, chatID, userID)
+			if err != nil {
+				return err
+			}
+
+			if !deleted {
+				return customerrors.ErrChatDraftNotFound
+			}
+
+			return nil
+		},
+	)
+}
+
+func (s *ChatsService) GetUserChats(
+	ctx context.Context,
+	userID uint64,
+) (chats []domains.Chat, err error) {
+	var channelsUnreadCounts map[uint64]uint64
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+			if chats, err = chatsRepository.GetUserChats(ctx, userID); err != nil {
+				return err
+			}
+
+			chatsPointers := make([]*domains.Chat, len(chats))
+			chatsIDs := make([]uint64, len(chats))
+
+			var channelsIDs []uint64
+
+			for i := range chats {
+				chatsPointers[i] = &chats[i]
+				chatsIDs[i] = chats[i].ID
+
+				if chats[i].Type == domains.ChatTypeChannel {
+					channelsIDs = append(channelsIDs, chats[i].ID)
+				}
+			}
+
+			if err = attachChatsMembers(ctx, chatsRepository, chatsPointers...); err != nil {
+				return err
+			}
+
+			unreadThreadsCounts, err := chatsRepository.GetUnreadThreadsCounts(ctx, userID, chatsIDs)
+			if err != nil {
+				return err
+			}
+
+			for i := range chats {
+				chats[i].UnreadThreadsCount = unreadThreadsCounts[chats[i].ID]
+			}
+
+			drafts, err := chatsRepository.GetUserChatDrafts(ctx, userID, chatsIDs)
+			if err != nil {
+				return err
+			}
+
+			draftsByChatID := make(map[uint64]*domains.ChatDraft, len(drafts))
+			for i := range drafts {
+				draftsByChatID[drafts[i].ChatID] = &drafts[i]
+			}
+
+			for i := range chats {
+				chats[i].Draft = draftsByChatID[chats[i].ID]
+			}
+
+			if len(channelsIDs) == 0 {
+				return nil
+			}
+
+			channelsUnreadCounts, err = chatsRepository.GetChannelsUnreadCounts(ctx, userID, channelsIDs)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range chats {
+		if chats[i].Type == domains.ChatTypeChannel {
+			chats[i].UnreadCount = channelsUnreadCounts[chats[i].ID]
+
+			continue
+		}
+
+		if member := chats[i].GetMember(userID); member != nil {
+			chats[i].UnreadCount = member.UnreadCount
+		}
+	}
+
+	return chats, nil
+}
+
+func (s *ChatsService) GetChatByID(ctx context.Context, id uint64) (chat *domains.Chat, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+			if chat, err = chatsRepository.GetChatByID(ctx, id); err != nil {
+				return err
+			}
+
+			return attachChatsMembers(ctx, chatsRepository, chat)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+	}
+
+	return chat, nil
+}
+
+// GetUnreadThreadsCount returns number of threads with unread replies in chat, which User joined.
+func (s *ChatsService) GetUnreadThreadsCount(
+	ctx context.Context,
+	chatID, userID uint64,
+) (count uint64, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			counts, err := chatsRepository.GetUnreadThreadsCounts(ctx, userID, []uint64{chatID})
+			if err != nil {
+				return err
+			}
+
+			count = counts[chatID]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetChannelUnreadCount returns number of unread messages of User in channel.
+func (s *ChatsService) GetChannelUnreadCount(
+	ctx context.Context,
+	chatID, userID uint64,
+) (count uint64, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			counts, err := chatsRepository.GetChannelsUnreadCounts(ctx, userID, []uint64{chatID})
+			if err != nil {
+				return err
+			}
+
+			count = counts[chatID]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *ChatsService) GetChatMember(
+	ctx context.Context,
+	chatID, userID uint64,
+) (member *domains.ChatMember, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+			if member, err = chatsRepository.GetChatMember(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrNotChatMember, err)
+	}
+
+	return member, nil
+}
+
+// GetChatMembersIDs returns IDs of all members of chat. Channels are provided without members, so recipients of
+// chat events are found by this method.
+func (s *ChatsService) GetChatMembersIDs(ctx context.Context, chatID uint64) (ids []uint64, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			ids, err = s.newChatsRepositoryFunc(tx).GetChatMembersIDs(ctx, chatID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// lockGroupChat locks group chat or channel for changes till the end of transaction and provides it along with
+// membership of actor, who performs changes.
+func lockGroupChat(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, actorID uint64,
+) (*domains.Chat, *domains.ChatMember, error) {
+	chat, err := chatsRepository.GetChatByIDForUpdate(ctx, chatID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil, fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+	case err != nil:
+		return nil, nil, err
+	}
+
+	actor, err := getChatMember(ctx, chatsRepository, chatID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if chat.Type == domains.ChatTypeDirect {
+		return nil, nil, customerrors.ErrNotGroupChat
+	}
+
+	return chat, actor, nil
+}
+
+func getChatMember(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, userID uint64,
+) (*domains.ChatMember, error) {
+	member, err := chatsRepository.GetChatMember(ctx, chatID, userID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrNotChatMember, err)
+	case err != nil:
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// wrapChatMemberNotFound marks absence of membership of User, who is the target of operation, and not
+// the actor, as not found error.
+func wrapChatMemberNotFound(err error) error {
+	if errors.Is(err, customerrors.ErrNotChatMember) {
+		return fmt.Errorf("%w: %w", customerrors.ErrChatMemberNotFound, err)
+	}
+
+	return err
+}
+
+// wrapChatInviteNotFound marks absence of invite in storage as not found error.
+func wrapChatInviteNotFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", customerrors.ErrChatInviteNotFound, err)
+	}
+
+	return err
+}
+
+// checkChatInviteActive returns error, if invite can not be used to join chat at the provided moment.
+func checkChatInviteActive(invite *domains.ChatInvite, now time.Time) error {
+	switch {
+	case invite.RevokedAt != nil:
+		return fmt.Errorf("%w: invite was revoked", customerrors.ErrChatInviteExpired)
+	case invite.ExpiresAt != nil && !now.Before(*invite.ExpiresAt):
+		return fmt.Errorf("%w: invite has expired", customerrors.ErrChatInviteExpired)
+	case invite.MaxUses != nil && invite.UsesCount >= *invite.MaxUses:
+		return fmt.Errorf("%w: invite has been used up", customerrors.ErrChatInviteExpired)
+	}
+
+	return nil
+}
+
+// generateChatInviteToken generates URL safe token of invite by cryptographically secure random generator.
+func generateChatInviteToken() (string, error) {
+	token := make([]byte, chatInviteTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// chooseChatOwnerSuccessor chooses member, who will become owner of the chat, if current owner leaves it.
+// Members are expected to be ordered by joining time. Returns nil, if there are no other members.
+func chooseChatOwnerSuccessor(members []domains.ChatMember, ownerID uint64) *domains.ChatMember {
+	var successor *domains.ChatMember
+
+	for i, member := range members {
+		if member.UserID == ownerID {
+			continue
+		}
+
+		if member.Role == domains.ChatMemberRoleAdmin {
+			return &members[i]
+		}
+
+		if successor == nil {
+			successor = &members[i]
+		}
+	}
+
+	return successor
+}
+
+// attachChatsMembers loads members of all provided chats by single query and attaches them to chats. Subscribers
+// of channels are not attached, since their number is unbounded and they should not know each other, so only
+// their number is counted.
+func attachChatsMembers(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chats ...*domains.Chat,
+) error {
+	var chatIDs, channelsIDs []uint64
+
+	for _, chat := range chats {
+		if chat.Type == domains.ChatTypeChannel {
+			channelsIDs = append(channelsIDs, chat.ID)
+		} else {
+			chatIDs = append(chatIDs, chat.ID)
+		}
+	}
+
+	membersByChatID := make(map[uint64][]domains.ChatMember, len(chatIDs))
+
+	if len(chatIDs) > 0 {
+		members, err := chatsRepository.GetChatsMembers(ctx, chatIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			membersByChatID[member.ChatID] = append(membersByChatID[member.ChatID], member)
+		}
+	}
+
+	var subscribersCounts map[uint64]uint64
+
+	if len(channelsIDs) > 0 {
+		var err error
+		if subscribersCounts, err = chatsRepository.GetChatsMembersCounts(ctx, channelsIDs); err != nil {
+			return err
+		}
+	}
+
+	for _, chat := range chats {
+		if chat.Type == domains.ChatTypeChannel {
+			chat.SubscribersCount = subscribersCounts[chat.ID]
+
+			continue
+		}
+
+		chat.Members = membersByChatID[chat.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,483 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/services"
+	mockrepositories "github.com/DKhorkov/kfc/mocks/repositories"
+	mockunitofwork "github.com/DKhorkov/kfc/mocks/uow"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChatsService_LeaveGroupChat(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID  uint64 = 1
+		ownerID uint64 = 10
+	)
+
+	member := func(userID uint64, role domains.ChatMemberRole) domains.ChatMember {
+		return domains.ChatMember{ChatID: chatID, UserID: userID, Role: role}
+	}
+
+	testCases := []struct {
+		name              string
+		leavingUserID     uint64
+		members           []domains.ChatMember
+		expectedNewOwner  uint64 // Zero, if ownership should not be passed
+		expectChatDeleted bool
+	}{
+		{
+			name:          "owner leaves, ownership passed to oldest admin",
+			leavingUserID: ownerID,
+			members: []domains.ChatMember{
+				member(ownerID, domains.ChatMemberRoleOwner),
+				member(11, domains.ChatMemberRoleMember),
+				member(12, domains.ChatMemberRoleAdmin),
+				member(13, domains.ChatMemberRoleAdmin),
+			},
+			expectedNewOwner: 12,
+		},
+		{
+			name:          "owner leaves, ownership passed to oldest member",
+			leavingUserID: ownerID,
+			members: []domains.ChatMember{
+				member(ownerID, domains.ChatMemberRoleOwner),
+				member(11, domains.ChatMemberRoleMember),
+				member(12, domains.ChatMemberRoleMember),
+			},
+			expectedNewOwner: 11,
+		},
+		{
+			name:          "member leaves",
+			leavingUserID: 11,
+			members: []domains.ChatMember{
+				member(ownerID, domains.ChatMemberRoleOwner),
+				member(11, domains.ChatMemberRoleMember),
+			},
+		},
+		{
+			name:          "last member leaves",
+			leavingUserID: ownerID,
+			members: []domains.ChatMember{
+				member(ownerID, domains.ChatMemberRoleOwner),
+			},
+			expectChatDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByIDForUpdate(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+
+			for _, m := range tc.members {
+				if m.UserID == tc.leavingUserID {
+					chatsRepository.EXPECT().
+						GetChatMember(gomock.Any(), chatID, tc.leavingUserID).
+						Return(&m, nil)
+				}
+			}
+
+			chatsRepository.EXPECT().
+				GetChatsMembers(gomock.Any(), []uint64{chatID}).
+				Return(tc.members, nil)
+
+			if tc.expectChatDeleted {
+				chatsRepository.EXPECT().DeleteChat(gomock.Any(), chatID).Return(nil)
+			} else {
+				chatsRepository.EXPECT().
+					DeleteChatMember(gomock.Any(), chatID, tc.leavingUserID).
+					Return(nil)
+				chatsRepository.EXPECT().
+					DeleteThreadParticipants(gomock.Any(), chatID, tc.leavingUserID).
+					Return(nil)
+				chatsRepository.EXPECT().
+					DeleteChatDraft(gomock.Any(), chatID, tc.leavingUserID).
+					Return(true, nil)
+			}
+
+			if tc.expectedNewOwner != 0 {
+				chatsRepository.EXPECT().
+					UpdateChatMemberRole(
+						gomock.Any(),
+						chatID,
+						tc.expectedNewOwner,
+						domains.ChatMemberRoleOwner,
+					).
+					Return(nil)
+			}
+
+			service := services.NewChatsService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+			)
+
+			require.NoError(t, service.LeaveGroupChat(context.Background(), chatID, tc.leavingUserID))
+		})
+	}
+}
+
+func TestChatsService_ChangeGroupChatMemberRole(t *testing.T) {
+	t.Parallel()
+
+	const chatID uint64 = 1
+
+	testCases := []struct {
+		name          string
+		actorRole     domains.ChatMemberRole
+		memberRole    domains.ChatMemberRole
+		role          domains.ChatMemberRole
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name:         "owner promotes member",
+			actorRole:    domains.ChatMemberRoleOwner,
+			memberRole:   domains.ChatMemberRoleMember,
+			role:         domains.ChatMemberRoleAdmin,
+			expectUpdate: true,
+		},
+		{
+			name:       "owner promotes admin",
+			actorRole:  domains.ChatMemberRoleOwner,
+			memberRole: domains.ChatMemberRoleAdmin,
+			role:       domains.ChatMemberRoleAdmin,
+		},
+		{
+			name:          "admin promotes member",
+			actorRole:     domains.ChatMemberRoleAdmin,
+			memberRole:    domains.ChatMemberRoleMember,
+			role:          domains.ChatMemberRoleAdmin,
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByIDForUpdate(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, uint64(10)).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: 10, Role: tc.actorRole}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, uint64(11)).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: 11, Role: tc.memberRole}, nil).
+				MaxTimes(1)
+
+			if tc.expectUpdate {
+				chatsRepository.EXPECT().
+					UpdateChatMemberRole(gomock.Any(), chatID, uint64(11), tc.role).
+					Return(nil)
+			}
+
+			if tc.expectedError == nil {
+				chatsRepository.EXPECT().
+					GetChatsMembers(gomock.Any(), []uint64{chatID}).
+					Return(nil, nil)
+			}
+
+			service := services.NewChatsService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+			)
+
+			_, err := service.ChangeGroupChatMemberRole(
+				context.Background(),
+				domains.ChangeChatMemberRoleDTO{
+					ChangeChatMemberDTO: domains.ChangeChatMemberDTO{
+						ChatID:  chatID,
+						ActorID: 10,
+						UserID:  11,
+					},
+					Role: tc.role,
+				},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestChatsService_JoinChatByInvite(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID       uint64 = 1
+		inviteID     uint64 = 2
+		ownerID      uint64 = 10
+		userID       uint64 = 11
+		token               = "token"
+		membersLimit        = 3
+	)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	maxUses := uint64(5)
+
+	owner := domains.ChatMember{ChatID: chatID, UserID: ownerID, Role: domains.ChatMemberRoleOwner}
+	joined := domains.ChatMember{ChatID: chatID, UserID: userID, Role: domains.ChatMemberRoleMember}
+
+	testCases := []struct {
+		name            string
+		invite          *domains.ChatInvite // Nil, if invite does not exist
+		members         []domains.ChatMember
+		expectedJoining bool
+		expectedErr     error
+	}{
+		{
+			name:            "active invite",
+			invite:          &domains.ChatInvite{ExpiresAt: &future, MaxUses: &maxUses, UsesCount: 4},
+			members:         []domains.ChatMember{owner},
+			expectedJoining: true,
+		},
+		{
+			name:    "already a member",
+			invite:  &domains.ChatInvite{},
+			members: []domains.ChatMember{owner, joined},
+		},
+		{
+			name:        "unknown token",
+			expectedErr: customerrors.ErrChatInviteNotFound,
+		},
+		{
+			name:        "revoked invite",
+			invite:      &domains.ChatInvite{RevokedAt: &past},
+			expectedErr: customerrors.ErrChatInviteExpired,
+		},
+		{
+			name:        "expired invite",
+			invite:      &domains.ChatInvite{ExpiresAt: &past},
+			expectedErr: customerrors.ErrChatInviteExpired,
+		},
+		{
+			name:        "used up invite",
+			invite:      &domains.ChatInvite{MaxUses: &maxUses, UsesCount: maxUses},
+			expectedErr: customerrors.ErrChatInviteExpired,
+		},
+		{
+			name:   "members limit exceeded",
+			invite: &domains.ChatInvite{},
+			members: []domains.ChatMember{
+				owner,
+				{ChatID: chatID, UserID: 12, Role: domains.ChatMemberRoleMember},
+				{ChatID: chatID, UserID: 13, Role: domains.ChatMemberRoleMember},
+			},
+			expectedErr: customerrors.ErrChatMembersLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			if tc.invite == nil {
+				chatsRepository.EXPECT().
+					GetChatInviteByToken(gomock.Any(), token).
+					Return(nil, sql.ErrNoRows)
+			} else {
+				invite := *tc.invite
+				invite.ID = inviteID
+				invite.ChatID = chatID
+				invite.Token = token
+
+				chatsRepository.EXPECT().
+					GetChatInviteByToken(gomock.Any(), token).
+					Return(&invite, nil)
+				chatsRepository.EXPECT().
+					GetChatByIDForUpdate(gomock.Any(), chatID).
+					Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+				chatsRepository.EXPECT().
+					GetChatInviteByID(gomock.Any(), inviteID).
+					Return(&invite, nil)
+			}
+
+			isMember := slices.ContainsFunc(
+				tc.members,
+				func(member domains.ChatMember) bool {
+					return member.UserID == userID
+				},
+			)
+
+			switch {
+			case isMember:
+				chatsRepository.EXPECT().
+					GetChatMember(gomock.Any(), chatID, userID).
+					Return(&joined, nil)
+				chatsRepository.EXPECT().
+					GetChatsMembers(gomock.Any(), []uint64{chatID}).
+					Return(tc.members, nil)
+			case tc.members != nil:
+				chatsRepository.EXPECT().
+					GetChatMember(gomock.Any(), chatID, userID).
+					Return(nil, sql.ErrNoRows)
+				chatsRepository.EXPECT().
+					GetChatsMembersCounts(gomock.Any(), []uint64{chatID}).
+					Return(map[uint64]uint64{chatID: uint64(len(tc.members))}, nil)
+			}
+
+			if tc.expectedJoining {
+				chatsRepository.EXPECT().
+					AddChatMembers(gomock.Any(), chatID, domains.ChatMemberRoleMember, userID).
+					Return(nil)
+				chatsRepository.EXPECT().IncrementChatInviteUses(gomock.Any(), inviteID).Return(nil)
+				chatsRepository.EXPECT().CreateChatInviteUse(gomock.Any(), inviteID, userID).Return(nil)
+				chatsRepository.EXPECT().
+					GetChatsMembers(gomock.Any(), []uint64{chatID}).
+					Return([]domains.ChatMember{owner, joined}, nil)
+			}
+
+			service := services.NewChatsService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+			)
+
+			chat, err := service.JoinChatByInvite(
+				context.Background(),
+				domains.JoinChatByInviteDTO{
+					Token:        token,
+					UserID:       userID,
+					MembersLimit: membersLimit,
+				},
+			)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, chat.GetMember(userID))
+		})
+	}
+}
+
+func TestChatsService_GetUserChats(t *testing.T) {
+	t.Parallel()
+
+	const userID uint64 = 10
+
+	ctrl := gomock.NewController(t)
+	unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+	chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+	unitOfWork.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+				return fn(ctx, nil)
+			},
+		)
+
+	chatsRepository.EXPECT().
+		GetUserChats(gomock.Any(), userID).
+		Return(
+			[]domains.Chat{
+				{ID: 1, Type: domains.ChatTypeDirect},
+				{ID: 2, Type: domains.ChatTypeGroup},
+				{ID: 3, Type: domains.ChatTypeChannel},
+			},
+			nil,
+		)
+	chatsRepository.EXPECT().
+		GetChatsMembers(gomock.Any(), []uint64{1, 2}).
+		Return(
+			[]domains.ChatMember{
+				{ChatID: 1, UserID: userID, UnreadCount: 3},
+				{ChatID: 2, UserID: userID},
+			},
+			nil,
+		)
+	chatsRepository.EXPECT().
+		GetChatsMembersCounts(gomock.Any(), []uint64{3}).
+		Return(map[uint64]uint64{3: 2}, nil)
+	chatsRepository.EXPECT().
+		GetUnreadThreadsCounts(gomock.Any(), userID, []uint64{1, 2, 3}).
+		Return(map[uint64]uint64{2: 1}, nil)
+	chatsRepository.EXPECT().
+		GetUserChatDrafts(gomock.Any(), userID, []uint64{1, 2, 3}).
+		Return([]domains.ChatDraft{{ChatID: 2, UserID: userID, Text: "See you"}}, nil)
+	chatsRepository.EXPECT().
+		GetChannelsUnreadCounts(gomock.Any(), userID, []uint64{3}).
+		Return(map[uint64]uint64{3: 5}, nil)
+
+	service := services.NewChatsService(
+		unitOfWork,
+		func(_ pg.Transaction) interfaces.ChatsRepository {
+			return chatsRepository
+		},
+	)
+
+	chats, err := service.GetUserChats(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, chats, 3)
+
+	require.Nil(t, chats[0].Draft)
+	require.Equal(t, uint64(3), chats[0].UnreadCount)
+
+	require.NotNil(t, chats[1].Draft)
+	require.Equal(t, "See you", chats[1].Draft.Text)
+	require.Equal(t, uint64(1), chats[1].UnreadThreadsCount)
+
+	// Subscribers of channel are counted, but not loaded:
+	require.Nil(t, chats[2].Members)
+	require.Equal(t, uint64(2), chats[2].SubscribersCount)
+	require.Equal(t, uint64(5), chats[2].UnreadCount)
+}
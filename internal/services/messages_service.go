@@ -0,0 +1,2535 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+)
+
+type MessagesService struct {
+	uow                          interfaces.UnitOfWork
+	newMessagesRepositoryFunc    func(tx pg.Transaction) interfaces.MessagesRepository
+	newChatsRepositoryFunc       func(tx pg.Transaction) interfaces.ChatsRepository
+	newAttachmentsRepositoryFunc func(tx pg.Transaction) interfaces.AttachmentsRepository
+	newUsersRepositoryFunc       func(tx pg.Transaction) interfaces.UsersRepository
+}
+
+func NewMessagesService(
+	uow interfaces.UnitOfWork,
+	newMessagesRepositoryFunc func(tx pg.Transaction) interfaces.MessagesRepository,
+	newChatsRepositoryFunc func(tx pg.Transaction) interfaces.ChatsRepository,
+	newAttachmentsRepositoryFunc func(tx pg.Transaction) interfaces.AttachmentsRepository,
+	newUsersRepositoryFunc func(tx pg.Transaction) interfaces.UsersRepository,
+) *MessagesService {
+	return &MessagesService{
+		uow:                          uow,
+		newMessagesRepositoryFunc:    newMessagesRepositoryFunc,
+		newChatsRepositoryFunc:       newChatsRepositoryFunc,
+		newAttachmentsRepositoryFunc: newAttachmentsRepositoryFunc,
+		newUsersRepositoryFunc:       newUsersRepositoryFunc,
+	}
+}
+
+// CreateMessage sends message to chat history or, if thread root is provided, to thread of root message.
+// Thread reply joins its author to the thread and is counted as unread only by thread participants.
+// Provided attachments must be uploaded by author to the same chat and not be sent before. Mentioned
+// members of chat are saved along with message. Preview of link, which was not previewed before, is fetched
+// in background.
+func (s *MessagesService) CreateMessage(
+	ctx context.Context,
+	messageData domains.CreateMessageDTO,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			message, err = s.createMessage(ctx, tx, messageData)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// createMessage sends message within provided transaction.
+func (s *MessagesService) createMessage(
+	ctx context.Context,
+	tx pg.Transaction,
+	messageData domains.CreateMessageDTO,
+) (message *domains.Message, err error) {
+	messagesRepository := s.newMessagesRepositoryFunc(tx)
+	chatsRepository := s.newChatsRepositoryFunc(tx)
+
+	chat, err := getPostableChat(ctx, chatsRepository, messageData.ChatID, messageData.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if messageData.Poll != nil && chat.Type != domains.ChatTypeGroup {
+		return nil, fmt.Errorf("%w: polls are available only in group chats", customerrors.ErrNotGroupChat)
+	}
+
+	var threadRoot *domains.Message
+	if messageData.ThreadRootID != nil {
+		// Root is locked to serialize concurrent changes of thread counters:
+		threadRoot, err = lockThreadRoot(
+			ctx,
+			messagesRepository,
+			messageData.ChatID,
+			*messageData.ThreadRootID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if messageData.ReplyToMessageID != nil {
+		err = checkReplyToMessage(
+			ctx,
+			messagesRepository,
+			messageData.ChatID,
+			*messageData.ReplyToMessageID,
+			messageData.ThreadRootID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+	if len(messageData.AttachmentIDs) > 0 {
+		err = checkMessageAttachments(ctx, attachmentsRepository, messageData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	messageData.ExpiresAt = chat.MessageExpiresAt(time.Now().UTC())
+
+	messageID, err := messagesRepository.CreateMessage(ctx, messageData)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messageData.AttachmentIDs) > 0 {
+		err = attachmentsRepository.SetAttachmentsMessage(ctx, messageID, messageData.AttachmentIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if messageData.Poll != nil {
+		if _, err = messagesRepository.CreatePoll(ctx, messageID, *messageData.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	if messageData.LinkURL != nil {
+		if err = messagesRepository.SetMessageLinkPreview(ctx, messageID, *messageData.LinkURL); err != nil {
+			return nil, err
+		}
+	}
+
+	// Chat is touched first, so its lock serializes concurrent changes of members counters:
+	if err = chatsRepository.TouchChat(ctx, messageData.ChatID); err != nil {
+		return nil, err
+	}
+
+	if message, err = messagesRepository.GetMessageByID(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	messages := []domains.Message{*message}
+	if err = attachMessagesAttachments(ctx, attachmentsRepository, messages); err != nil {
+		return nil, err
+	}
+
+	if err = attachMessagesPolls(ctx, messagesRepository, messageData.AuthorID, messages); err != nil {
+		return nil, err
+	}
+
+	// Preview of link, which was previewed before, is taken from cache and is ready right away:
+	if err = attachMessagesLinkPreviews(ctx, messagesRepository, messages); err != nil {
+		return nil, err
+	}
+
+	message = &messages[0]
+
+	if messageData.HasMentions() {
+		err = createMentions(
+			ctx,
+			messagesRepository,
+			chatsRepository,
+			s.newUsersRepositoryFunc(tx),
+			messageData,
+			messageID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if threadRoot != nil {
+		if err = addThreadReply(ctx, messagesRepository, threadRoot, message); err != nil {
+			return nil, err
+		}
+
+		return message, nil
+	}
+
+	if err = incrementUnreadCounters(ctx, chatsRepository, chat, messageData.AuthorID, 1); err != nil {
+		return nil, err
+	}
+
+	// Author has definitely read the chat up to his own message:
+	err = chatsRepository.UpdateLastReadMessage(
+		ctx,
+		messageData.ChatID,
+		messageData.AuthorID,
+		messageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (s *MessagesService) GetMessageByID(
+	ctx context.Context,
+	id uint64,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			if message, err = messagesRepository.GetMessageByID(ctx, id); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+	}
+
+	return message, nil
+}
+
+// GetChatMessageByID provides message of chat, which is visible to User. Deleted for everyone messages and
+// messages, which were deleted by User only for himself, are not provided.
+func (s *MessagesService) GetChatMessageByID(
+	ctx context.Context,
+	chatID, userID, id uint64,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			messages, err := messagesRepository.GetChatMessagesByIDs(ctx, chatID, userID, []uint64{id})
+			if err != nil {
+				return err
+			}
+
+			if len(messages) == 0 {
+				return customerrors.ErrMessageNotFound
+			}
+
+			message = &messages[0]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetChatMessages returns page of chat history, which is visible to provided User.
+func (s *MessagesService) GetChatMessages(
+	ctx context.Context,
+	chatID, userID uint64,
+	cursor domains.MessagesCursor,
+) (page *domains.MessagesPage, err error) {
+	limit := cursor.Limit
+
+	// Requesting one extra message to find out, whether there are more messages after current page:
+	cursor.Limit++
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			messages, err := messagesRepository.GetChatMessages(ctx, chatID, userID, cursor)
+			if err != nil {
+				return err
+			}
+
+			page = &domains.MessagesPage{
+				Messages: messages,
+			}
+
+			if uint64(len(messages)) > limit {
+				page.HasMore = true
+
+				// Extra message is the furthest one from the cursor:
+				if cursor.After != nil {
+					page.Messages = messages[:limit]
+				} else {
+					page.Messages = messages[1:]
+				}
+			}
+
+			if err = attachMessagesThreads(ctx, messagesRepository, userID, page.Messages); err != nil {
+				return err
+			}
+
+			err = attachMessagesAttachments(ctx, s.newAttachmentsRepositoryFunc(tx), page.Messages)
+			if err != nil {
+				return err
+			}
+
+			err = hideForwardSources(ctx, s.newChatsRepositoryFunc(tx), userID, page.Messages)
+			if err != nil {
+				return err
+			}
+
+			if err = attachMessagesPolls(ctx, messagesRepository, userID, page.Messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesLinkPreviews(ctx, messagesRepository, page.Messages); err != nil {
+				return err
+			}
+
+			return attachMessagesReactions(ctx, messagesRepository, userID, page.Messages)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// UpdateMessage edits text of message. Previous text is kept as message revision. Only author can edit
+// message.
+func (s *MessagesService) UpdateMessage(
+	ctx context.Context,
+	messageData domains.UpdateMessageDTO,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			message, _, err = lockChatMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				messageData.ChatID,
+				messageData.ID,
+				messageData.ActorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if message.DeletedAt != nil {
+				return customerrors.ErrMessageNotFound
+			}
+
+			// System messages are posted on behalf of actor, but are not written by him:
+			if message.AuthorID != messageData.ActorID || message.Type != domains.MessageTypeRegular {
+				return customerrors.ErrMessagePermissionDenied
+			}
+
+			if message.Text == messageData.Text {
+				return nil
+			}
+
+			err = messagesRepository.CreateMessageRevision(ctx, message.ID, message.Text)
+			if err != nil {
+				return err
+			}
+
+			err = messagesRepository.UpdateMessageText(ctx, message.ID, messageData.Text)
+			if err != nil {
+				return err
+			}
+
+			// Preview of previous link is replaced or removed along with the link:
+			if messageData.LinkURL != nil {
+				err = messagesRepository.SetMessageLinkPreview(ctx, message.ID, *messageData.LinkURL)
+			} else {
+				err = messagesRepository.DeleteMessageLinkPreview(ctx, message.ID)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if message, err = messagesRepository.GetMessageByID(ctx, message.ID); err != nil {
+				return err
+			}
+
+			messages := []domains.Message{*message}
+			if err = attachMessagesAttachments(ctx, s.newAttachmentsRepositoryFunc(tx), messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesLinkPreviews(ctx, messagesRepository, messages); err != nil {
+				return err
+			}
+
+			message = &messages[0]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// DeleteMessage deletes message for actor only or for everyone. Deleted for everyone message is turned
+// into tombstone, its revisions, poll and link preview are erased, it is unpinned and unread messages
+// counters of chat members, who have not read it yet, are recalculated. Only author or chat
+// admins can delete message for everyone.
+func (s *MessagesService) DeleteMessage(
+	ctx context.Context,
+	messageData domains.DeleteMessageDTO,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			var actor *domains.ChatMember
+
+			message, actor, err = lockChatMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				messageData.ChatID,
+				messageData.ID,
+				messageData.ActorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if !messageData.ForEveryone {
+				return messagesRepository.HideMessage(ctx, message.ID, messageData.ActorID)
+			}
+
+			if message.DeletedAt != nil {
+				return customerrors.ErrMessageNotFound
+			}
+
+			if message.AuthorID != messageData.ActorID && !actor.Role.CanModerateMessages() {
+				return customerrors.ErrMessagePermissionDenied
+			}
+
+			if err = messagesRepository.DeleteMessage(ctx, message.ID); err != nil {
+				return err
+			}
+
+			if err = messagesRepository.DeleteMessageRevisions(ctx, message.ID); err != nil {
+				return err
+			}
+
+			if err = messagesRepository.DeleteMessageReactions(ctx, message.ID); err != nil {
+				return err
+			}
+
+			if message.Type == domains.MessageTypePoll {
+				if err = messagesRepository.DeleteMessagePoll(ctx, message.ID); err != nil {
+					return err
+				}
+			}
+
+			if message.Type == domains.MessageTypeRegular {
+				if err = messagesRepository.DeleteMessageLinkPreview(ctx, message.ID); err != nil {
+					return err
+				}
+			}
+
+			if _, err = messagesRepository.DeletePin(ctx, message.ID); err != nil {
+				return err
+			}
+
+			if message.ThreadRootID == nil {
+				if err = recountUnreadCounters(ctx, chatsRepository, message.ChatID, message.ID); err != nil {
+					return err
+				}
+			}
+
+			if message, err = messagesRepository.GetMessageByID(ctx, message.ID); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// ReadChatMessages moves read pointer of User in chat to provided message and recalculates unread
+// messages counter. Read pointer never moves backwards.
+func (s *MessagesService) ReadChatMessages(
+	ctx context.Context,
+	chatID, userID, messageID uint64,
+) (member *domains.ChatMember, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			// Membership is locked to calculate counter after concurrent messages creation is committed:
+			member, err = chatsRepository.GetChatMemberForUpdate(ctx, chatID, userID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrNotChatMember, err)
+			case err != nil:
+				return err
+			}
+
+			message, err := messagesRepository.GetMessageByID(ctx, messageID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+			case err != nil:
+				return err
+			case message.ChatID != chatID:
+				return customerrors.ErrMessageNotFound
+			}
+
+			if member.LastReadMessageID != nil && *member.LastReadMessageID >= messageID {
+				return nil
+			}
+
+			err = chatsRepository.UpdateLastReadMessage(ctx, chatID, userID, messageID)
+			if err != nil {
+				return err
+			}
+
+			if member, err = chatsRepository.GetChatMember(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// AddMessageReaction adds reaction of User on message and reports, whether it was not left before.
+func (s *MessagesService) AddMessageReaction(
+	ctx context.Context,
+	reactionData domains.MessageReactionDTO,
+) (added bool, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			// Message is locked to not add reactions to message, which is being deleted for everyone:
+			message, _, err := lockChatMessage(
+				ctx,
+				messagesRepository,
+				s.newChatsRepositoryFunc(tx),
+				reactionData.ChatID,
+				reactionData.MessageID,
+				reactionData.UserID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if message.DeletedAt != nil {
+				return customerrors.ErrMessageNotFound
+			}
+
+			added, err = messagesRepository.AddMessageReaction(ctx, reactionData)
+
+			return err
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return added, nil
+}
+
+// RemoveMessageReaction removes reaction of User from message and reports, whether it was left before.
+func (s *MessagesService) RemoveMessageReaction(
+	ctx context.Context,
+	reactionData domains.MessageReactionDTO,
+) (removed bool, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			message, _, err := lockChatMessage(
+				ctx,
+				messagesRepository,
+				s.newChatsRepositoryFunc(tx),
+				reactionData.ChatID,
+				reactionData.MessageID,
+				reactionData.UserID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if message.DeletedAt != nil {
+				return customerrors.ErrMessageNotFound
+			}
+
+			removed, err = messagesRepository.DeleteMessageReaction(ctx, reactionData)
+
+			return err
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return removed, nil
+}
+
+// GetThreadMessages returns page of replies in thread of root message, which are visible to provided User,
+// along with the root message itself.
+func (s *MessagesService) GetThreadMessages(
+	ctx context.Context,
+	chatID, threadRootID, userID uint64,
+	cursor domains.MessagesCursor,
+) (page *domains.ThreadPage, err error) {
+	limit := cursor.Limit
+
+	// Requesting one extra message to find out, whether there are more messages after current page:
+	cursor.Limit++
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			root, err := getThreadRoot(ctx, messagesRepository, chatID, threadRootID)
+			if err != nil {
+				return err
+			}
+
+			messages, err := messagesRepository.GetThreadMessages(ctx, threadRootID, userID, cursor)
+			if err != nil {
+				return err
+			}
+
+			page = &domains.ThreadPage{
+				Root:     *root,
+				Messages: messages,
+			}
+
+			if uint64(len(messages)) > limit {
+				page.HasMore = true
+
+				// Extra message is the furthest one from the cursor:
+				if cursor.After != nil {
+					page.Messages = messages[:limit]
+				} else {
+					page.Messages = messages[1:]
+				}
+			}
+
+			roots := []domains.Message{page.Root}
+			if err = attachMessagesThreads(ctx, messagesRepository, userID, roots); err != nil {
+				return err
+			}
+
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			// Root and replies are attached together to not query attachments twice:
+			messages = append(roots, page.Messages...)
+			if err = attachMessagesAttachments(ctx, attachmentsRepository, messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesReactions(ctx, messagesRepository, userID, messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesPolls(ctx, messagesRepository, userID, messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesLinkPreviews(ctx, messagesRepository, messages); err != nil {
+				return err
+			}
+
+			if err = hideForwardSources(ctx, s.newChatsRepositoryFunc(tx), userID, messages); err != nil {
+				return err
+			}
+
+			page.Root = messages[0]
+			page.Messages = messages[1:]
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ReadThreadMessages moves read pointer of thread participant to provided reply and recalculates unread
+// replies counter. Read pointer never moves backwards.
+func (s *MessagesService) ReadThreadMessages(
+	ctx context.Context,
+	chatID, threadRootID, userID, messageID uint64,
+) (participant *domains.ThreadParticipant, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			if _, err = getChatMember(ctx, s.newChatsRepositoryFunc(tx), chatID, userID); err != nil {
+				return err
+			}
+
+			// Participation is locked to calculate counter after concurrent replies creation is committed:
+			participant, err = messagesRepository.GetThreadParticipantForUpdate(ctx, threadRootID, userID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrNotThreadParticipant, err)
+			case err != nil:
+				return err
+			case participant.ChatID != chatID:
+				return customerrors.ErrMessageNotFound
+			}
+
+			message, err := messagesRepository.GetMessageByID(ctx, messageID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+			case err != nil:
+				return err
+			case message.ThreadRootID == nil || *message.ThreadRootID != threadRootID:
+				return customerrors.ErrMessageNotFound
+			}
+
+			if participant.LastReadMessageID != nil && *participant.LastReadMessageID >= messageID {
+				return nil
+			}
+
+			err = messagesRepository.UpdateThreadLastReadMessage(ctx, threadRootID, userID, messageID)
+			if err != nil {
+				return err
+			}
+
+			if participant, err = messagesRepository.GetThreadParticipant(ctx, threadRootID, userID); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// SearchMessages performs full-text search of messages in chats of User. Results are ordered by relevance.
+func (s *MessagesService) SearchMessages(
+	ctx context.Context,
+	searchData domains.SearchMessagesDTO,
+) (page *domains.MessagesSearchPage, err error) {
+	limit := searchData.Limit
+
+	// Requesting one extra result to find out, whether there are more results after current page:
+	searchData.Limit++
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			results, err := messagesRepository.SearchMessages(ctx, searchData)
+			if err != nil {
+				return err
+			}
+
+			page = &domains.MessagesSearchPage{
+				Results: results,
+			}
+
+			if uint64(len(results)) > limit {
+				page.HasMore = true
+				page.Results = results[:limit]
+			}
+
+			messages := make([]domains.Message, len(page.Results))
+			for i, result := range page.Results {
+				messages[i] = result.Message
+			}
+
+			err = hideForwardSources(ctx, s.newChatsRepositoryFunc(tx), searchData.UserID, messages)
+			if err != nil {
+				return err
+			}
+
+			for i := range page.Results {
+				page.Results[i].Message = messages[i]
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// GetMessageMentions returns mentions of Users in message.
+func (s *MessagesService) GetMessageMentions(
+	ctx context.Context,
+	messageID uint64,
+) (mentions []domains.Mention, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			mentions, err = messagesRepository.GetMessageMentions(ctx, messageID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// GetUserMentions returns feed of mentions of User from newer to older.
+func (s *MessagesService) GetUserMentions(
+	ctx context.Context,
+	userID uint64,
+	cursor domains.MentionsCursor,
+) (page *domains.MentionsPage, err error) {
+	limit := cursor.Limit
+
+	// Requesting one extra mention to find out, whether there are more mentions after current page:
+	cursor.Limit++
+
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			mentions, err := messagesRepository.GetUserMentions(ctx, userID, cursor)
+			if err != nil {
+				return err
+			}
+
+			page = &domains.MentionsPage{
+				Mentions: mentions,
+			}
+
+			if uint64(len(mentions)) > limit {
+				page.HasMore = true
+				page.Mentions = mentions[:limit]
+			}
+
+			messages := make([]domains.Message, len(page.Mentions))
+			for i, mention := range page.Mentions {
+				messages[i] = *mention.Message
+			}
+
+			if err = hideForwardSources(ctx, s.newChatsRepositoryFunc(tx), userID, messages); err != nil {
+				return err
+			}
+
+			for i := range page.Mentions {
+				page.Mentions[i].Message = &messages[i]
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ForwardMessages creates copies of provided messages of source chat in target chat on behalf of actor.
+// Copies keep attribution to original messages and refer to the same content of attachments and the same
+// previews of links. Either all messages are forwarded or none of them.
+func (s *MessagesService) ForwardMessages(
+	ctx context.Context,
+	forwardData domains.ForwardMessagesDTO,
+) (messages []domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			chat, err := getPostableChat(ctx, chatsRepository, forwardData.ChatID, forwardData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			// Only messages, which actor can see, can be forwarded:
+			_, err = getChatMember(ctx, chatsRepository, forwardData.FromChatID, forwardData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			originals, err := messagesRepository.GetChatMessagesByIDs(
+				ctx,
+				forwardData.FromChatID,
+				forwardData.ActorID,
+				forwardData.MessageIDs,
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(originals) != len(forwardData.MessageIDs) {
+				return customerrors.ErrMessageNotFound
+			}
+
+			attachments, err := attachmentsRepository.GetMessagesAttachments(ctx, forwardData.MessageIDs)
+			if err != nil {
+				return err
+			}
+
+			attachmentsByMessage := make(map[uint64][]domains.Attachment)
+			for _, attachment := range attachments {
+				attachmentsByMessage[*attachment.MessageID] = append(
+					attachmentsByMessage[*attachment.MessageID],
+					attachment,
+				)
+			}
+
+			// Copies are created in order of originals to keep their order in target chat:
+			copyIDs := make([]uint64, len(originals))
+			for i, original := range originals {
+				if original.Type != domains.MessageTypeRegular {
+					return fmt.Errorf("%w: only regular messages can be forwarded", customerrors.ErrInvalidForward)
+				}
+
+				copyData := domains.CreateMessageDTO{
+					ChatID:                 forwardData.ChatID,
+					AuthorID:               forwardData.ActorID,
+					Type:                   domains.MessageTypeRegular,
+					Text:                   original.Text,
+					ForwardedFromMessageID: &original.ID,
+					ForwardedFromChatID:    &original.ChatID,
+					ForwardedFromAuthorID:  &original.AuthorID,
+					ExpiresAt:              chat.MessageExpiresAt(time.Now().UTC()),
+				}
+
+				// Copy of forwarded message is attributed to the very first original:
+				if original.IsForwarded() {
+					copyData.ForwardedFromMessageID = original.ForwardedFromMessageID
+					copyData.ForwardedFromChatID = original.ForwardedFromChatID
+					copyData.ForwardedFromAuthorID = original.ForwardedFromAuthorID
+				}
+
+				if copyIDs[i], err = messagesRepository.CreateMessage(ctx, copyData); err != nil {
+					return err
+				}
+
+				if err = messagesRepository.CopyMessageLinkPreview(ctx, original.ID, copyIDs[i]); err != nil {
+					return err
+				}
+
+				for _, attachment := range attachmentsByMessage[original.ID] {
+					attachmentCopyID, err := attachmentsRepository.CopyAttachment(
+						ctx,
+						attachment.ID,
+						forwardData.ChatID,
+						copyIDs[i],
+					)
+					if err != nil {
+						return err
+					}
+
+					err = attachmentsRepository.CopyAttachmentThumbnails(ctx, attachment.ID, attachmentCopyID)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			// Chat is touched after copies are created in the same way as on message creation:
+			if err = chatsRepository.TouchChat(ctx, forwardData.ChatID); err != nil {
+				return err
+			}
+
+			err = incrementUnreadCounters(ctx, chatsRepository, chat, forwardData.ActorID, uint64(len(copyIDs)))
+			if err != nil {
+				return err
+			}
+
+			err = chatsRepository.UpdateLastReadMessage(
+				ctx,
+				forwardData.ChatID,
+				forwardData.ActorID,
+				copyIDs[len(copyIDs)-1],
+			)
+			if err != nil {
+				return err
+			}
+
+			messages, err = messagesRepository.GetChatMessagesByIDs(
+				ctx,
+				forwardData.ChatID,
+				forwardData.ActorID,
+				copyIDs,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err = attachMessagesAttachments(ctx, attachmentsRepository, messages); err != nil {
+				return err
+			}
+
+			return attachMessagesLinkPreviews(ctx, messagesRepository, messages)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// PinMessage pins message in chat and posts system message about it to chat history. Only admins can pin
+// messages of group chat or channel, while any member can pin messages of direct chat.
+func (s *MessagesService) PinMessage(
+	ctx context.Context,
+	pinData domains.PinMessageDTO,
+) (pin *domains.Pin, systemMessage *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			// Chat is locked to serialize concurrent pinning, so limit of pins can not be exceeded:
+			message, chat, err := lockPinnedMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				pinData.ChatID,
+				pinData.MessageID,
+				pinData.ActorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if message.Type == domains.MessageTypeSystem {
+				return customerrors.ErrMessagePermissionDenied
+			}
+
+			added, err := messagesRepository.CreatePin(ctx, message.ChatID, message.ID, pinData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if !added {
+				return customerrors.ErrMessageAlreadyPinned
+			}
+
+			pinsCount, err := messagesRepository.CountChatPins(ctx, message.ChatID)
+			if err != nil {
+				return err
+			}
+
+			if pinsCount > pinData.PinsLimit {
+				return fmt.Errorf(
+					"%w: chat can not have more than %d pinned messages",
+					customerrors.ErrPinsLimitExceeded,
+					pinData.PinsLimit,
+				)
+			}
+
+			if pin, err = messagesRepository.GetMessagePin(ctx, message.ID); err != nil {
+				return err
+			}
+
+			messages := []domains.Message{*message}
+			if err = attachMessagesAttachments(ctx, s.newAttachmentsRepositoryFunc(tx), messages); err != nil {
+				return err
+			}
+
+			pin.Message = &messages[0]
+
+			systemMessage, err = createSystemMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				chat,
+				pinData.ActorID,
+				domains.SystemEvent{
+					Type:      domains.SystemEventTypeMessagePinned,
+					MessageID: &message.ID,
+				},
+			)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pin, systemMessage, nil
+}
+
+// UnpinMessage unpins message in chat and posts system message about it to chat history. Rights to unpin
+// messages are the same as rights to pin them.
+func (s *MessagesService) UnpinMessage(
+	ctx context.Context,
+	pinData domains.UnpinMessageDTO,
+) (systemMessage *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			message, chat, err := lockPinnedMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				pinData.ChatID,
+				pinData.MessageID,
+				pinData.ActorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			deleted, err := messagesRepository.DeletePin(ctx, message.ID)
+			if err != nil {
+				return err
+			}
+
+			if !deleted {
+				return customerrors.ErrMessageNotPinned
+			}
+
+			systemMessage, err = createSystemMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				chat,
+				pinData.ActorID,
+				domains.SystemEvent{
+					Type:      domains.SystemEventTypeMessageUnpinned,
+					MessageID: &message.ID,
+				},
+			)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return systemMessage, nil
+}
+
+// GetChatPins returns pinned messages of chat, which are visible to provided User, from the most recently
+// pinned.
+func (s *MessagesService) GetChatPins(
+	ctx context.Context,
+	chatID, userID uint64,
+) (pins []domains.Pin, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			if pins, err = messagesRepository.GetChatPins(ctx, chatID, userID); err != nil {
+				return err
+			}
+
+			messages := make([]domains.Message, len(pins))
+			for i, pin := range pins {
+				messages[i] = *pin.Message
+			}
+
+			err = attachMessagesAttachments(ctx, s.newAttachmentsRepositoryFunc(tx), messages)
+			if err != nil {
+				return err
+			}
+
+			if err = attachMessagesReactions(ctx, messagesRepository, userID, messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesPolls(ctx, messagesRepository, userID, messages); err != nil {
+				return err
+			}
+
+			if err = attachMessagesLinkPreviews(ctx, messagesRepository, messages); err != nil {
+				return err
+			}
+
+			if err = hideForwardSources(ctx, s.newChatsRepositoryFunc(tx), userID, messages); err != nil {
+				return err
+			}
+
+			for i := range pins {
+				pins[i].Message = &messages[i]
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+// ScheduleMessage saves message, which will be sent to chat history later. Author must be able to post to
+// chat at the moment of scheduling, while permissions and quoted message are checked again on sending.
+func (s *MessagesService) ScheduleMessage(
+	ctx context.Context,
+	messageData domains.ScheduleMessageDTO,
+) (message *domains.ScheduledMessage, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			_, err = getPostableChat(ctx, s.newChatsRepositoryFunc(tx), messageData.ChatID, messageData.AuthorID)
+			if err != nil {
+				return err
+			}
+
+			if messageData.ReplyToMessageID != nil {
+				err = checkReplyToMessage(
+					ctx,
+					messagesRepository,
+					messageData.ChatID,
+					*messageData.ReplyToMessageID,
+					nil,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			count, err := messagesRepository.CountUserScheduledMessages(
+				ctx,
+				messageData.ChatID,
+				messageData.AuthorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			if count >= messageData.Limit {
+				return customerrors.ErrScheduledMessagesLimitExceeded
+			}
+
+			id, err := messagesRepository.CreateScheduledMessage(ctx, messageData)
+			if err != nil {
+				return err
+			}
+
+			message, err = messagesRepository.GetScheduledMessageByID(ctx, id)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// GetUserScheduledMessages returns scheduled messages of User in chat in order of sending.
+func (s *MessagesService) GetUserScheduledMessages(
+	ctx context.Context,
+	chatID, userID uint64,
+) (messages []domains.ScheduledMessage, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messages, err = s.newMessagesRepositoryFunc(tx).GetUserScheduledMessages(ctx, chatID, userID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// UpdateScheduledMessage sets new text and sending time of scheduled message. Only author can edit scheduled
+// message. Failed message is scheduled again.
+func (s *MessagesService) UpdateScheduledMessage(
+	ctx context.Context,
+	messageData domains.UpdateScheduledMessageDTO,
+) (message *domains.ScheduledMessage, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			_, err = getUserScheduledMessage(
+				ctx,
+				messagesRepository,
+				messageData.ChatID,
+				messageData.ID,
+				messageData.ActorID,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = messagesRepository.UpdateScheduledMessage(
+				ctx,
+				messageData.ID,
+				messageData.Text,
+				messageData.SendAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			// Message could have been sent by dispatcher, while it was being edited:
+			message, err = getUserScheduledMessage(
+				ctx,
+				messagesRepository,
+				messageData.ChatID,
+				messageData.ID,
+				messageData.ActorID,
+			)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// DeleteScheduledMessage cancels sending of scheduled message. Only author can cancel scheduled message.
+func (s *MessagesService) DeleteScheduledMessage(
+	ctx context.Context,
+	chatID, id, actorID uint64,
+) error {
+	return s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			_, err := getUserScheduledMessage(ctx, messagesRepository, chatID, id, actorID)
+			if err != nil {
+				return err
+			}
+
+			return messagesRepository.DeleteScheduledMessage(ctx, id)
+		},
+	)
+}
+
+// GetDueScheduledMessages returns scheduled messages, which should have been sent by the provided moment.
+// Messages are not locked, so the same messages can be provided to several dispatchers.
+func (s *MessagesService) GetDueScheduledMessages(
+	ctx context.Context,
+	now time.Time,
+	limit uint64,
+) (messages []domains.ScheduledMessage, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messages, err = s.newMessagesRepositoryFunc(tx).GetDueScheduledMessages(ctx, now, limit)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// SendScheduledMessage sends scheduled message to chat history and removes it from schedule in one
+// transaction. Scheduled message is locked without waiting, so message, which is being sent by another
+// dispatcher, as well as message, which was changed or cancelled after it was provided, is not sent and
+// ErrScheduledMessageNotFound is returned.
+func (s *MessagesService) SendScheduledMessage(
+	ctx context.Context,
+	scheduledMessage domains.ScheduledMessage,
+	messageData domains.CreateMessageDTO,
+) (message *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			locked, err := messagesRepository.LockPendingScheduledMessage(ctx, scheduledMessage.ID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrScheduledMessageNotFound, err)
+			case err != nil:
+				return err
+			case !locked.UpdatedAt.Equal(scheduledMessage.UpdatedAt):
+				return fmt.Errorf("%w: message was changed", customerrors.ErrScheduledMessageNotFound)
+			}
+
+			if message, err = s.createMessage(ctx, tx, messageData); err != nil {
+				return err
+			}
+
+			return messagesRepository.DeleteScheduledMessage(ctx, scheduledMessage.ID)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// FailScheduledMessage marks scheduled message, which can not be sent, as failed, so it is not sent again
+// until author edits it. Message, which was edited after it was provided, is left pending.
+func (s *MessagesService) FailScheduledMessage(
+	ctx context.Context,
+	scheduledMessage domains.ScheduledMessage,
+) error {
+	return s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			return s.newMessagesRepositoryFunc(tx).FailScheduledMessage(
+				ctx,
+				scheduledMessage.ID,
+				scheduledMessage.UpdatedAt,
+			)
+		},
+	)
+}
+
+// SetChatMessageTTL changes lifetime of messages, which are sent to chat afterwards, and posts system message
+// about it to chat history. Already sent messages keep their lifetime. Only admins can change lifetime of
+// messages of group chat or channel, while any member can change it for direct chat. System message is not
+// posted, if lifetime is not changed.
+func (s *MessagesService) SetChatMessageTTL(
+	ctx context.Context,
+	ttlData domains.SetChatMessageTTLDTO,
+) (systemMessage *domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+
+			// Chat is locked to serialize concurrent changes of lifetime:
+			chat, err := chatsRepository.GetChatByIDForUpdate(ctx, ttlData.ChatID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+			case err != nil:
+				return err
+			}
+
+			actor, err := getChatMember(ctx, chatsRepository, ttlData.ChatID, ttlData.ActorID)
+			if err != nil {
+				return err
+			}
+
+			if chat.Type != domains.ChatTypeDirect && !actor.Role.CanChangeMessageTTL() {
+				return customerrors.ErrChatPermissionDenied
+			}
+
+			if chat.MessageTTL == ttlData.MessageTTL {
+				return nil
+			}
+
+			if err = chatsRepository.UpdateChatMessageTTL(ctx, chat.ID, ttlData.MessageTTL); err != nil {
+				return err
+			}
+
+			// System message itself disappears according to new lifetime:
+			chat.MessageTTL = ttlData.MessageTTL
+
+			systemMessage, err = createSystemMessage(
+				ctx,
+				messagesRepository,
+				chatsRepository,
+				chat,
+				ttlData.ActorID,
+				domains.SystemEvent{
+					Type:       domains.SystemEventTypeMessageTTLChanged,
+					MessageTTL: &ttlData.MessageTTL,
+				},
+			)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return systemMessage, nil
+}
+
+// PurgeExpiredMessages deletes batch of messages, which expired before provided time, together with replies
+// in their threads, attachments and all other data of messages. Unread counters of chats members and thread
+// participants are recalculated without deleted messages. Content of attachments is shared between
+// forwarded copies, so only storage keys, which are no longer referenced, are provided for deletion from
+// storage. Messages, which are being purged concurrently, are skipped.
+func (s *MessagesService) PurgeExpiredMessages(
+	ctx context.Context,
+	expiredBefore time.Time,
+	limit uint64,
+) (purge *domains.MessagesPurge, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+			chatsRepository := s.newChatsRepositoryFunc(tx)
+			attachmentsRepository := s.newAttachmentsRepositoryFunc(tx)
+
+			messages, err := messagesRepository.LockExpiredMessages(ctx, expiredBefore, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(messages) == 0 {
+				return nil
+			}
+
+			messageIDs := make([]uint64, len(messages))
+			for i, message := range messages {
+				messageIDs[i] = message.ID
+			}
+
+			// Counters of threads, which roots survive, are recalculated after their replies are deleted:
+			var threadRootIDs []uint64
+
+			// Unread counters of chats are recalculated for members, who have not read the earliest deleted
+			// message of chat:
+			chatsFromMessageIDs := make(map[uint64]uint64)
+
+			for _, message := range messages {
+				if message.ThreadRootID != nil &&
+					!slices.Contains(messageIDs, *message.ThreadRootID) &&
+					!slices.Contains(threadRootIDs, *message.ThreadRootID) {
+					threadRootIDs = append(threadRootIDs, *message.ThreadRootID)
+				}
+
+				// Messages are ordered by ID, so the first message of chat is the earliest one:
+				if _, ok := chatsFromMessageIDs[message.ChatID]; !ok && message.ThreadRootID == nil {
+					chatsFromMessageIDs[message.ChatID] = message.ID
+				}
+			}
+
+			storageKeys, err := getMessagesStorageKeys(ctx, attachmentsRepository, messageIDs)
+			if err != nil {
+				return err
+			}
+
+			if err = messagesRepository.DeleteMessages(ctx, messageIDs); err != nil {
+				return err
+			}
+
+			if len(threadRootIDs) > 0 {
+				if err = messagesRepository.RecountThreadReplies(ctx, threadRootIDs); err != nil {
+					return err
+				}
+
+				if err = messagesRepository.RecountThreadUnreadCounters(ctx, threadRootIDs); err != nil {
+					return err
+				}
+			}
+
+			// Chats are processed in order of IDs to avoid deadlocks with concurrent purges:
+			for _, chatID := range slices.Sorted(maps.Keys(chatsFromMessageIDs)) {
+				err = recountUnreadCounters(ctx, chatsRepository, chatID, chatsFromMessageIDs[chatID])
+				if err != nil {
+					return err
+				}
+			}
+
+			purge = &domains.MessagesPurge{Messages: messages}
+			if len(storageKeys) == 0 {
+				return nil
+			}
+
+			usedKeys, err := attachmentsRepository.GetUsedStorageKeys(ctx, storageKeys)
+			if err != nil {
+				return err
+			}
+
+			for _, storageKey := range storageKeys {
+				if !slices.Contains(usedKeys, storageKey) {
+					purge.StorageKeys = append(purge.StorageKeys, storageKey)
+				}
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return purge, nil
+}
+
+// VotePoll saves votes of User for options of poll and returns updated poll. User can vote in poll only once,
+// so vote has to be retracted to change it.
+func (s *MessagesService) VotePoll(
+	ctx context.Context,
+	voteData domains.PollVoteDTO,
+) (poll *domains.Poll, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			// Message is locked to serialize concurrent votes of the User:
+			poll, err = lockMessagePoll(ctx, messagesRepository, s.newChatsRepositoryFunc(tx), voteData)
+			if err != nil {
+				return err
+			}
+
+			if len(voteData.OptionIDs) > 1 && !poll.MultipleChoice {
+				return fmt.Errorf("%w: only one option can be chosen", customerrors.ErrValidationFailed)
+			}
+
+			for _, optionID := range voteData.OptionIDs {
+				if poll.GetOption(optionID) == nil {
+					return fmt.Errorf("%w: poll option not found", customerrors.ErrValidationFailed)
+				}
+			}
+
+			if len(poll.VotedOptionIDs()) > 0 {
+				return customerrors.ErrPollAlreadyVoted
+			}
+
+			err = messagesRepository.CreatePollVotes(ctx, poll.ID, voteData.UserID, voteData.OptionIDs)
+			if err != nil {
+				return err
+			}
+
+			poll, err = getMessagePoll(ctx, messagesRepository, poll.MessageID, voteData.UserID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
+
+// RetractPollVote deletes all votes of User in poll, returns updated poll and reports, whether User has voted.
+func (s *MessagesService) RetractPollVote(
+	ctx context.Context,
+	voteData domains.PollVoteDTO,
+) (poll *domains.Poll, retracted bool, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			poll, err = lockMessagePoll(ctx, messagesRepository, s.newChatsRepositoryFunc(tx), voteData)
+			if err != nil {
+				return err
+			}
+
+			if retracted, err = messagesRepository.DeletePollVotes(ctx, poll.ID, voteData.UserID); err != nil {
+				return err
+			}
+
+			if !retracted {
+				return nil
+			}
+
+			poll, err = getMessagePoll(ctx, messagesRepository, poll.MessageID, voteData.UserID)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return poll, retracted, nil
+}
+
+// ClaimLinkPreviews takes batch of previews of links, which metadata is waiting for fetching. Previews, which
+// have been processed longer than claimTimeout, are taken again.
+func (s *MessagesService) ClaimLinkPreviews(
+	ctx context.Context,
+	limit uint64,
+	claimTimeout time.Duration,
+) (previews []domains.LinkPreview, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			previews, err = messagesRepository.ClaimLinkPreviews(ctx, limit, time.Now().Add(-claimTimeout))
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return previews, nil
+}
+
+// SaveLinkPreview saves result of fetching of link metadata. Messages, which contain the link, are returned
+// only for ready preview, which was not saved by another worker after claim timeout, so each ready preview
+// is delivered once.
+func (s *MessagesService) SaveLinkPreview(
+	ctx context.Context,
+	previewData domains.UpdateLinkPreviewDTO,
+) (messages []domains.Message, err error) {
+	err = s.uow.Do(
+		ctx,
+		func(ctx context.Context, tx pg.Transaction) error {
+			messagesRepository := s.newMessagesRepositoryFunc(tx)
+
+			updated, err := messagesRepository.UpdateLinkPreview(ctx, previewData)
+			if err != nil || !updated || previewData.Status != domains.LinkPreviewStatusReady {
+				return err
+			}
+
+			messages, err = messagesRepository.GetLinkPreviewMessages(ctx, previewData.LinkPreviewID)
+			if err != nil {
+				return err
+			}
+
+			return attachMessagesLinkPreviews(ctx, messagesRepository, messages)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// addThreadReply updates counters of thread after reply was sent to it. Author of root message and author of
+// reply join the thread, so they get unread state for further replies.
+func addThreadReply(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	root, reply *domains.Message,
+) error {
+	if err := messagesRepository.AddThreadReply(ctx, root.ID, reply.CreatedAt); err != nil {
+		return err
+	}
+
+	err := messagesRepository.AddThreadParticipants(ctx, root.ChatID, root.ID, root.AuthorID, reply.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if err = messagesRepository.IncrementThreadUnreadCounters(ctx, root.ID, reply.AuthorID); err != nil {
+		return err
+	}
+
+	// Author has definitely read the thread up to his own reply:
+	return messagesRepository.UpdateThreadLastReadMessage(ctx, root.ID, reply.AuthorID, reply.ID)
+}
+
+// getThreadRoot provides message of chat, which can have thread. Replies in threads can not have own threads.
+func getThreadRoot(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatID, threadRootID uint64,
+) (*domains.Message, error) {
+	root, err := messagesRepository.GetMessageByID(ctx, threadRootID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+	case err != nil:
+		return nil, err
+	case root.ChatID != chatID:
+		return nil, customerrors.ErrMessageNotFound
+	case root.ThreadRootID != nil:
+		return nil, fmt.Errorf("%w: reply in thread can not have own thread", customerrors.ErrInvalidReply)
+	}
+
+	return root, nil
+}
+
+// lockThreadRoot locks message of chat, which is going to be replied in thread, till the end of transaction.
+func lockThreadRoot(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatID, threadRootID uint64,
+) (*domains.Message, error) {
+	root, err := messagesRepository.GetMessageByIDForUpdate(ctx, threadRootID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+	case err != nil:
+		return nil, err
+	case root.ChatID != chatID || root.DeletedAt != nil:
+		return nil, customerrors.ErrMessageNotFound
+	case root.ThreadRootID != nil:
+		return nil, fmt.Errorf("%w: reply in thread can not have own thread", customerrors.ErrInvalidReply)
+	}
+
+	return root, nil
+}
+
+// checkReplyToMessage checks, that quoted message can be quoted in chat history or in provided thread.
+// Messages can be quoted only within the same history or thread.
+func checkReplyToMessage(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatID, replyToMessageID uint64,
+	threadRootID *uint64,
+) error {
+	message, err := messagesRepository.GetMessageByID(ctx, replyToMessageID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%w: quoted message not found", customerrors.ErrInvalidReply)
+	case err != nil:
+		return err
+	case message.ChatID != chatID || message.DeletedAt != nil:
+		return fmt.Errorf("%w: quoted message not found", customerrors.ErrInvalidReply)
+	}
+
+	switch {
+	case threadRootID == nil && message.ThreadRootID == nil:
+		return nil
+	case threadRootID != nil && message.ID == *threadRootID:
+		return nil
+	case threadRootID != nil && message.ThreadRootID != nil && *message.ThreadRootID == *threadRootID:
+		return nil
+	default:
+		return fmt.Errorf(
+			"%w: quoted message belongs to another history or thread",
+			customerrors.ErrInvalidReply,
+		)
+	}
+}
+
+// checkMessageAttachments checks, that attachments can be sent with message, and locks them till the end of
+// transaction.
+func checkMessageAttachments(
+	ctx context.Context,
+	attachmentsRepository interfaces.AttachmentsRepository,
+	messageData domains.CreateMessageDTO,
+) error {
+	attachments, err := attachmentsRepository.GetAttachmentsByIDsForUpdate(ctx, messageData.AttachmentIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(attachments) != len(messageData.AttachmentIDs) {
+		return fmt.Errorf("%w: attachment not found", customerrors.ErrInvalidAttachment)
+	}
+
+	for _, attachment := range attachments {
+		switch {
+		case attachment.ChatID != messageData.ChatID || attachment.UploaderID != messageData.AuthorID:
+			return fmt.Errorf("%w: attachment not found", customerrors.ErrInvalidAttachment)
+		case attachment.MessageID != nil:
+			return fmt.Errorf("%w: attachment has already been sent", customerrors.ErrInvalidAttachment)
+		}
+	}
+
+	return nil
+}
+
+// attachMessagesAttachments sets attachments to provided messages. Attachments of messages, which were
+// deleted for everyone, are not provided.
+func attachMessagesAttachments(
+	ctx context.Context,
+	attachmentsRepository interfaces.AttachmentsRepository,
+	messages []domains.Message,
+) error {
+	var messagesIDs []uint64
+
+	for _, message := range messages {
+		if message.DeletedAt == nil {
+			messagesIDs = append(messagesIDs, message.ID)
+		}
+	}
+
+	if len(messagesIDs) == 0 {
+		return nil
+	}
+
+	attachments, err := attachmentsRepository.GetMessagesAttachments(ctx, messagesIDs)
+	if err != nil {
+		return err
+	}
+
+	attachmentsByMessage := make(map[uint64][]domains.Attachment)
+
+	if err = attachAttachmentsThumbnails(ctx, attachmentsRepository, attachments); err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if attachment.MessageID != nil {
+			attachmentsByMessage[*attachment.MessageID] = append(
+				attachmentsByMessage[*attachment.MessageID],
+				attachment,
+			)
+		}
+	}
+
+	for i := range messages {
+		messages[i].Attachments = attachmentsByMessage[messages[i].ID]
+	}
+
+	return nil
+}
+
+// attachMessagesThreads sets number of unread replies to root messages of threads, which User joined.
+func attachMessagesThreads(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	userID uint64,
+	messages []domains.Message,
+) error {
+	var threadRootIDs []uint64
+
+	for _, message := range messages {
+		if message.ThreadReplyCount > 0 {
+			threadRootIDs = append(threadRootIDs, message.ID)
+		}
+	}
+
+	if len(threadRootIDs) == 0 {
+		return nil
+	}
+
+	participants, err := messagesRepository.GetUserThreadParticipants(ctx, threadRootIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	unreadCounts := make(map[uint64]uint64, len(participants))
+	for _, participant := range participants {
+		unreadCounts[participant.ThreadRootID] = participant.UnreadCount
+	}
+
+	for i := range messages {
+		if unreadCount, ok := unreadCounts[messages[i].ID]; ok {
+			messages[i].ThreadUnreadCount = &unreadCount
+		}
+	}
+
+	return nil
+}
+
+// attachMessagesReactions sets aggregated reactions to provided messages from the point of view of User.
+func attachMessagesReactions(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	userID uint64,
+	messages []domains.Message,
+) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	messagesIDs := make([]uint64, len(messages))
+	for i, message := range messages {
+		messagesIDs[i] = message.ID
+	}
+
+	reactions, err := messagesRepository.GetMessagesReactions(ctx, messagesIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	reactionsByMessage := make(map[uint64][]domains.MessageReaction)
+	for _, reaction := range reactions {
+		reactionsByMessage[reaction.MessageID] = append(reactionsByMessage[reaction.MessageID], reaction)
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactionsByMessage[messages[i].ID]
+	}
+
+	return nil
+}
+
+// attachMessagesPolls sets polls with aggregated votes to provided messages from the point of view of User.
+// Voters are provided only for public polls.
+func attachMessagesPolls(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	userID uint64,
+	messages []domains.Message,
+) error {
+	var messagesIDs []uint64
+
+	for _, message := range messages {
+		if message.Type == domains.MessageTypePoll && message.DeletedAt == nil {
+			messagesIDs = append(messagesIDs, message.ID)
+		}
+	}
+
+	if len(messagesIDs) == 0 {
+		return nil
+	}
+
+	polls, err := messagesRepository.GetMessagesPolls(ctx, messagesIDs)
+	if err != nil || len(polls) == 0 {
+		return err
+	}
+
+	pollsIDs := make([]uint64, len(polls))
+
+	var publicPollsIDs []uint64
+
+	for i, poll := range polls {
+		pollsIDs[i] = poll.ID
+
+		if !poll.Anonymous {
+			publicPollsIDs = append(publicPollsIDs, poll.ID)
+		}
+	}
+
+	options, err := messagesRepository.GetPollsOptions(ctx, pollsIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	votersByOption := make(map[uint64][]uint64)
+
+	if len(publicPollsIDs) > 0 {
+		votes, err := messagesRepository.GetPollsVotes(ctx, publicPollsIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, vote := range votes {
+			votersByOption[vote.OptionID] = append(votersByOption[vote.OptionID], vote.UserID)
+		}
+	}
+
+	optionsByPoll := make(map[uint64][]domains.PollOption, len(polls))
+	for _, option := range options {
+		option.VoterIDs = votersByOption[option.ID]
+		optionsByPoll[option.PollID] = append(optionsByPoll[option.PollID], option)
+	}
+
+	pollsByMessage := make(map[uint64]*domains.Poll, len(polls))
+	for i := range polls {
+		polls[i].Options = optionsByPoll[polls[i].ID]
+		pollsByMessage[polls[i].MessageID] = &polls[i]
+	}
+
+	for i := range messages {
+		messages[i].Poll = pollsByMessage[messages[i].ID]
+	}
+
+	return nil
+}
+
+// attachMessagesLinkPreviews sets ready previews of links to provided messages. Previews of messages, which
+// were deleted for everyone, are not provided.
+func attachMessagesLinkPreviews(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	messages []domains.Message,
+) error {
+	var messagesIDs []uint64
+
+	for _, message := range messages {
+		if message.Type == domains.MessageTypeRegular && message.DeletedAt == nil {
+			messagesIDs = append(messagesIDs, message.ID)
+		}
+	}
+
+	if len(messagesIDs) == 0 {
+		return nil
+	}
+
+	previews, err := messagesRepository.GetMessagesLinkPreviews(ctx, messagesIDs)
+	if err != nil {
+		return err
+	}
+
+	previewsByMessage := make(map[uint64]*domains.LinkPreview, len(previews))
+	for i := range previews {
+		previewsByMessage[previews[i].MessageID] = &previews[i].LinkPreview
+	}
+
+	for i := range messages {
+		messages[i].LinkPreview = previewsByMessage[messages[i].ID]
+	}
+
+	return nil
+}
+
+// getMessagePoll returns poll of message with aggregated votes from the point of view of User.
+func getMessagePoll(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	messageID, userID uint64,
+) (*domains.Poll, error) {
+	messages := []domains.Message{{ID: messageID, Type: domains.MessageTypePoll}}
+	if err := attachMessagesPolls(ctx, messagesRepository, userID, messages); err != nil {
+		return nil, err
+	}
+
+	if messages[0].Poll == nil {
+		return nil, customerrors.ErrPollNotFound
+	}
+
+	return messages[0].Poll, nil
+}
+
+// lockMessagePoll locks message of poll, which User votes in, till the end of transaction and returns poll
+// from the point of view of the User. Poll must accept votes.
+func lockMessagePoll(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatsRepository interfaces.ChatsRepository,
+	voteData domains.PollVoteDTO,
+) (*domains.Poll, error) {
+	message, _, err := lockChatMessage(
+		ctx,
+		messagesRepository,
+		chatsRepository,
+		voteData.ChatID,
+		voteData.MessageID,
+		voteData.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.DeletedAt != nil {
+		return nil, customerrors.ErrMessageNotFound
+	}
+
+	if message.Type != domains.MessageTypePoll {
+		return nil, customerrors.ErrPollNotFound
+	}
+
+	poll, err := getMessagePoll(ctx, messagesRepository, message.ID, voteData.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.IsClosed(time.Now()) {
+		return nil, customerrors.ErrPollClosed
+	}
+
+	return poll, nil
+}
+
+// createMentions saves mentions of chat members in message. Users are resolved by their usernames, and Users,
+// who are not members of chat, as well as author of message, are not mentioned.
+func createMentions(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatsRepository interfaces.ChatsRepository,
+	usersRepository interfaces.UsersRepository,
+	messageData domains.CreateMessageDTO,
+	messageID uint64,
+) error {
+	members, err := chatsRepository.GetChatsMembers(ctx, []uint64{messageData.ChatID})
+	if err != nil {
+		return err
+	}
+
+	mentionTypes := make(map[uint64]domains.MentionType, len(members))
+
+	// Personal mentions take precedence over @here and @all, so they are collected last:
+	if messageData.MentionAll {
+		for _, member := range members {
+			mentionTypes[member.UserID] = domains.MentionTypeAll
+		}
+	}
+
+	for _, userID := range messageData.HereUserIDs {
+		mentionTypes[userID] = domains.MentionTypeHere
+	}
+
+	for _, username := range messageData.MentionedUsernames {
+		user, err := usersRepository.GetUserByUsername(ctx, username)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			continue
+		case err != nil:
+			return err
+		}
+
+		mentionTypes[user.ID] = domains.MentionTypeUser
+	}
+
+	// Mentions are created in order of members to keep them deterministic:
+	var mentions []domains.CreateMentionDTO
+
+	for _, member := range members {
+		mentionType, ok := mentionTypes[member.UserID]
+		if !ok || member.UserID == messageData.AuthorID {
+			continue
+		}
+
+		mentions = append(
+			mentions,
+			domains.CreateMentionDTO{
+				MessageID: messageID,
+				ChatID:    messageData.ChatID,
+				UserID:    member.UserID,
+				Type:      mentionType,
+			},
+		)
+	}
+
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	return messagesRepository.CreateMentions(ctx, mentions...)
+}
+
+// lockChatMessage locks message of chat for changes till the end of transaction and provides it along with
+// membership of actor, who performs changes.
+func lockChatMessage(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, messageID, actorID uint64,
+) (*domains.Message, *domains.ChatMember, error) {
+	actor, err := getChatMember(ctx, chatsRepository, chatID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message, err := messagesRepository.GetMessageByIDForUpdate(ctx, messageID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil, fmt.Errorf("%w: %w", customerrors.ErrMessageNotFound, err)
+	case err != nil:
+		return nil, nil, err
+	case message.ChatID != chatID:
+		return nil, nil, customerrors.ErrMessageNotFound
+	}
+
+	return message, actor, nil
+}
+
+// lockPinnedMessage locks chat and its message, which is being pinned or unpinned, till the end of
+// transaction. Actor must have rights to pin messages of the chat.
+func lockPinnedMessage(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, messageID, actorID uint64,
+) (*domains.Message, *domains.Chat, error) {
+	message, actor, err := lockChatMessage(ctx, messagesRepository, chatsRepository, chatID, messageID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Chat is locked after message in the same order as on message creation to avoid deadlocks:
+	chat, err := chatsRepository.GetChatByIDForUpdate(ctx, chatID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil, fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+	case err != nil:
+		return nil, nil, err
+	}
+
+	if chat.Type != domains.ChatTypeDirect && !actor.Role.CanPinMessages() {
+		return nil, nil, customerrors.ErrChatPermissionDenied
+	}
+
+	if message.DeletedAt != nil {
+		return nil, nil, customerrors.ErrMessageNotFound
+	}
+
+	return message, chat, nil
+}
+
+// createSystemMessage posts system message about change of chat, made by actor, to chat history. System
+// message is counted as unread by all members except actor.
+func createSystemMessage(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatsRepository interfaces.ChatsRepository,
+	chat *domains.Chat,
+	actorID uint64,
+	event domains.SystemEvent,
+) (*domains.Message, error) {
+	messageID, err := messagesRepository.CreateMessage(
+		ctx,
+		domains.CreateMessageDTO{
+			ChatID:      chat.ID,
+			AuthorID:    actorID,
+			Type:        domains.MessageTypeSystem,
+			SystemEvent: &event,
+			ExpiresAt:   chat.MessageExpiresAt(time.Now().UTC()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = chatsRepository.TouchChat(ctx, chat.ID); err != nil {
+		return nil, err
+	}
+
+	if err = incrementUnreadCounters(ctx, chatsRepository, chat, actorID, 1); err != nil {
+		return nil, err
+	}
+
+	if err = chatsRepository.UpdateLastReadMessage(ctx, chat.ID, actorID, messageID); err != nil {
+		return nil, err
+	}
+
+	return messagesRepository.GetMessageByID(ctx, messageID)
+}
+
+// getMessagesStorageKeys returns unique storage keys of attachments of provided messages and thumbnails of
+// these attachments.
+func getMessagesStorageKeys(
+	ctx context.Context,
+	attachmentsRepository interfaces.AttachmentsRepository,
+	messageIDs []uint64,
+) ([]string, error) {
+	attachments, err := attachmentsRepository.GetMessagesAttachments(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	storageKeys := make([]string, 0, len(attachments))
+	attachmentIDs := make([]uint64, len(attachments))
+
+	for i, attachment := range attachments {
+		attachmentIDs[i] = attachment.ID
+		if !slices.Contains(storageKeys, attachment.StorageKey) {
+			storageKeys = append(storageKeys, attachment.StorageKey)
+		}
+	}
+
+	thumbnails, err := attachmentsRepository.GetAttachmentsThumbnails(ctx, attachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thumbnail := range thumbnails {
+		if !slices.Contains(storageKeys, thumbnail.StorageKey) {
+			storageKeys = append(storageKeys, thumbnail.StorageKey)
+		}
+	}
+
+	return storageKeys, nil
+}
+
+// getPostableChat provides chat, which author, who must be its member, is going to post to. Only owner and
+// admins can post to channels, while subscribers can only read and react.
+func getPostableChat(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, authorID uint64,
+) (*domains.Chat, error) {
+	chat, err := chatsRepository.GetChatByID(ctx, chatID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrChatNotFound, err)
+	case err != nil:
+		return nil, err
+	}
+
+	author, err := getChatMember(ctx, chatsRepository, chatID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat.Type == domains.ChatTypeChannel && !author.Role.CanPostToChannel() {
+		return nil, customerrors.ErrChatPermissionDenied
+	}
+
+	return chat, nil
+}
+
+// incrementUnreadCounters counts new messages as unread by all members of chat except provided User. Unread
+// counters of channels subscribers are calculated on reading instead, so posting to channel with thousands
+// of subscribers does not touch each of them.
+func incrementUnreadCounters(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chat *domains.Chat,
+	exceptUserID, delta uint64,
+) error {
+	if chat.Type == domains.ChatTypeChannel {
+		return nil
+	}
+
+	return chatsRepository.IncrementUnreadCounters(ctx, chat.ID, exceptUserID, delta)
+}
+
+// recountUnreadCounters recalculates unread messages counters of chat members, who have not read provided
+// message yet. Counters of channels subscribers are not stored, so they are not recalculated.
+func recountUnreadCounters(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	chatID, fromMessageID uint64,
+) error {
+	chat, err := chatsRepository.GetChatByID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	if chat.Type == domains.ChatTypeChannel {
+		return nil
+	}
+
+	return chatsRepository.RecountUnreadCounters(ctx, chatID, fromMessageID)
+}
+
+// getUserScheduledMessage provides scheduled message of chat, which belongs to provided User. Existence of
+// scheduled messages of other Users is not revealed.
+func getUserScheduledMessage(
+	ctx context.Context,
+	messagesRepository interfaces.MessagesRepository,
+	chatID, id, userID uint64,
+) (*domains.ScheduledMessage, error) {
+	message, err := messagesRepository.GetScheduledMessageByID(ctx, id)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrScheduledMessageNotFound, err)
+	case err != nil:
+		return nil, err
+	case message.ChatID != chatID || message.AuthorID != userID:
+		return nil, customerrors.ErrScheduledMessageNotFound
+	}
+
+	return message, nil
+}
+
+// hideForwardSources hides chat and message of originals of forwarded messages from User, who is not a member
+// of original chat. Author of original is always provided.
+func hideForwardSources(
+	ctx context.Context,
+	chatsRepository interfaces.ChatsRepository,
+	userID uint64,
+	messages []domains.Message,
+) error {
+	visibleChats := make(map[uint64]bool)
+
+	for i := range messages {
+		chatID := messages[i].ForwardedFromChatID
+		if chatID == nil {
+			continue
+		}
+
+		visible, ok := visibleChats[*chatID]
+		if !ok {
+			_, err := chatsRepository.GetChatMember(ctx, *chatID, userID)
+
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				visible = false
+			case err != nil:
+				return err
+			default:
+				visible = true
+			}
+
+			visibleChats[*chatID] = visible
+		}
+
+		if !visible {
+			messages[i].ForwardedFromChatID = nil
+			messages[i].ForwardedFromMessageID = nil
+		}
+	}
+
+	return nil
+}
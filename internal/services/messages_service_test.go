@@ -0,0 +1,2136 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/kfc/internal/services"
+	mockrepositories "github.com/DKhorkov/kfc/mocks/repositories"
+	mockunitofwork "github.com/DKhorkov/kfc/mocks/uow"
+	pg "github.com/DKhorkov/libs/db/postgresql"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMessagesService_GetChatMessages(t *testing.T) {
+	t.Parallel()
+
+	buildMessages := func(ids ...uint64) []domains.Message {
+		messages := make([]domains.Message, len(ids))
+		for i, id := range ids {
+			messages[i] = domains.Message{ID: id, ChatID: 1}
+		}
+
+		return messages
+	}
+
+	reaction := domains.MessageReaction{MessageID: 8, Emoji: "👍", Count: 2, ReactedByMe: true}
+
+	withReaction := func(messages []domains.Message) []domains.Message {
+		for i := range messages {
+			if messages[i].ID == reaction.MessageID {
+				messages[i].Reactions = []domains.MessageReaction{reaction}
+			}
+		}
+
+		return messages
+	}
+
+	forwarded := func(messages []domains.Message, fromChatID uint64, visible bool) []domains.Message {
+		for i := range messages {
+			messages[i].ForwardedFromAuthorID = pointers.New[uint64](3)
+
+			if visible {
+				messages[i].ForwardedFromChatID = pointers.New(fromChatID)
+				messages[i].ForwardedFromMessageID = pointers.New(messages[i].ID + 100)
+			}
+		}
+
+		return messages
+	}
+
+	testCases := []struct {
+		name                string
+		cursor              domains.MessagesCursor
+		repoMessages        []domains.Message
+		memberOfChats       map[uint64]bool
+		expectedMessagesIDs []uint64
+		expectedMessages    []domains.Message
+		expectedHasMore     bool
+	}{
+		{
+			name:                "latest messages without more",
+			cursor:              domains.MessagesCursor{Limit: 3},
+			repoMessages:        buildMessages(1, 2),
+			expectedMessagesIDs: []uint64{1, 2},
+			expectedMessages:    buildMessages(1, 2),
+			expectedHasMore:     false,
+		},
+		{
+			name:                "older messages with more",
+			cursor:              domains.MessagesCursor{Before: pointers.New[uint64](10), Limit: 2},
+			repoMessages:        buildMessages(7, 8, 9),
+			expectedMessagesIDs: []uint64{8, 9},
+			expectedMessages:    withReaction(buildMessages(8, 9)),
+			expectedHasMore:     true,
+		},
+		{
+			name:                "newer messages with more",
+			cursor:              domains.MessagesCursor{After: pointers.New[uint64](3), Limit: 2},
+			repoMessages:        buildMessages(4, 5, 6),
+			expectedMessagesIDs: []uint64{4, 5},
+			expectedMessages:    buildMessages(4, 5),
+			expectedHasMore:     true,
+		},
+		{
+			name:   "forwarded messages",
+			cursor: domains.MessagesCursor{Limit: 3},
+			repoMessages: append(
+				forwarded(buildMessages(1), 20, true),
+				forwarded(buildMessages(2, 3), 30, true)...,
+			),
+			memberOfChats:       map[uint64]bool{20: true, 30: false},
+			expectedMessagesIDs: []uint64{1, 2, 3},
+			expectedMessages: append(
+				forwarded(buildMessages(1), 20, true),
+				forwarded(buildMessages(2, 3), 30, false)...,
+			),
+			expectedHasMore: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			for chatID, isMember := range tc.memberOfChats {
+				if isMember {
+					chatsRepository.EXPECT().
+						GetChatMember(gomock.Any(), chatID, uint64(2)).
+						Return(&domains.ChatMember{ChatID: chatID, UserID: 2}, nil)
+				} else {
+					chatsRepository.EXPECT().
+						GetChatMember(gomock.Any(), chatID, uint64(2)).
+						Return(nil, sql.ErrNoRows)
+				}
+			}
+
+			expectedCursor := tc.cursor
+			expectedCursor.Limit++
+			messagesRepository.EXPECT().
+				GetChatMessages(gomock.Any(), uint64(1), uint64(2), expectedCursor).
+				Return(tc.repoMessages, nil)
+			messagesRepository.EXPECT().
+				GetMessagesReactions(gomock.Any(), tc.expectedMessagesIDs, uint64(2)).
+				Return([]domains.MessageReaction{reaction}, nil)
+			attachmentsRepository.EXPECT().
+				GetMessagesAttachments(gomock.Any(), tc.expectedMessagesIDs).
+				Return(nil, nil)
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			page, err := service.GetChatMessages(context.Background(), 1, 2, tc.cursor)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMessages, page.Messages)
+			require.Equal(t, tc.expectedHasMore, page.HasMore)
+		})
+	}
+}
+
+func TestMessagesService_DeleteMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID    uint64 = 1
+		messageID uint64 = 5
+		authorID  uint64 = 10
+		actorID   uint64 = 11
+	)
+
+	testCases := []struct {
+		name          string
+		actorID       uint64
+		actorRole     domains.ChatMemberRole
+		chatType      domains.ChatType
+		forEveryone   bool
+		expectHide    bool
+		expectDelete  bool
+		expectedError error
+	}{
+		{
+			name:         "author deletes for everyone",
+			actorID:      authorID,
+			actorRole:    domains.ChatMemberRoleMember,
+			chatType:     domains.ChatTypeGroup,
+			forEveryone:  true,
+			expectDelete: true,
+		},
+		{
+			name:         "admin deletes message of other member for everyone",
+			actorID:      actorID,
+			actorRole:    domains.ChatMemberRoleAdmin,
+			chatType:     domains.ChatTypeGroup,
+			forEveryone:  true,
+			expectDelete: true,
+		},
+		{
+			name:         "admin deletes channel post for everyone",
+			actorID:      actorID,
+			actorRole:    domains.ChatMemberRoleAdmin,
+			chatType:     domains.ChatTypeChannel,
+			forEveryone:  true,
+			expectDelete: true,
+		},
+		{
+			name:          "member deletes message of other member for everyone",
+			actorID:       actorID,
+			actorRole:     domains.ChatMemberRoleMember,
+			forEveryone:   true,
+			expectedError: customerrors.ErrMessagePermissionDenied,
+		},
+		{
+			name:       "member deletes message of other member for himself",
+			actorID:    actorID,
+			actorRole:  domains.ChatMemberRoleMember,
+			expectHide: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			message := &domains.Message{ID: messageID, ChatID: chatID, AuthorID: authorID}
+
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, tc.actorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: tc.actorID, Role: tc.actorRole}, nil)
+			messagesRepository.EXPECT().
+				GetMessageByIDForUpdate(gomock.Any(), messageID).
+				Return(message, nil)
+
+			if tc.expectHide {
+				messagesRepository.EXPECT().HideMessage(gomock.Any(), messageID, tc.actorID).Return(nil)
+			}
+
+			if tc.expectDelete {
+				messagesRepository.EXPECT().DeleteMessage(gomock.Any(), messageID).Return(nil)
+				messagesRepository.EXPECT().DeleteMessageRevisions(gomock.Any(), messageID).Return(nil)
+				messagesRepository.EXPECT().DeleteMessageReactions(gomock.Any(), messageID).Return(nil)
+				messagesRepository.EXPECT().DeletePin(gomock.Any(), messageID).Return(false, nil)
+				chatsRepository.EXPECT().
+					GetChatByID(gomock.Any(), chatID).
+					Return(&domains.Chat{ID: chatID, Type: tc.chatType}, nil)
+
+				if tc.chatType != domains.ChatTypeChannel {
+					chatsRepository.EXPECT().RecountUnreadCounters(gomock.Any(), chatID, messageID).Return(nil)
+				}
+
+				messagesRepository.EXPECT().GetMessageByID(gomock.Any(), messageID).Return(message, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			_, err := service.DeleteMessage(
+				context.Background(),
+				domains.DeleteMessageDTO{
+					ID:          messageID,
+					ChatID:      chatID,
+					ActorID:     tc.actorID,
+					ForEveryone: tc.forEveryone,
+				},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestMessagesService_ReadChatMessages(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID    uint64 = 1
+		userID    uint64 = 10
+		messageID uint64 = 5
+	)
+
+	testCases := []struct {
+		name              string
+		lastReadMessageID *uint64
+		expectUpdate      bool
+	}{
+		{
+			name:         "nothing was read",
+			expectUpdate: true,
+		},
+		{
+			name:              "older message was read",
+			lastReadMessageID: pointers.New[uint64](3),
+			expectUpdate:      true,
+		},
+		{
+			name:              "newer message was read",
+			lastReadMessageID: pointers.New[uint64](7),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatMemberForUpdate(gomock.Any(), chatID, userID).
+				Return(
+					&domains.ChatMember{
+						ChatID:            chatID,
+						UserID:            userID,
+						LastReadMessageID: tc.lastReadMessageID,
+					},
+					nil,
+				)
+			messagesRepository.EXPECT().
+				GetMessageByID(gomock.Any(), messageID).
+				Return(&domains.Message{ID: messageID, ChatID: chatID}, nil)
+
+			if tc.expectUpdate {
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, userID, messageID).
+					Return(nil)
+				chatsRepository.EXPECT().
+					GetChatMember(gomock.Any(), chatID, userID).
+					Return(
+						&domains.ChatMember{
+							ChatID:            chatID,
+							UserID:            userID,
+							LastReadMessageID: pointers.New(messageID),
+						},
+						nil,
+					)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			member, err := service.ReadChatMessages(context.Background(), chatID, userID, messageID)
+			require.NoError(t, err)
+
+			expectedLastReadMessageID := tc.lastReadMessageID
+			if tc.expectUpdate {
+				expectedLastReadMessageID = pointers.New(messageID)
+			}
+
+			require.Equal(t, expectedLastReadMessageID, member.LastReadMessageID)
+		})
+	}
+}
+
+func TestMessagesService_AddMessageReaction(t *testing.T) {
+	t.Parallel()
+
+	reaction := domains.MessageReactionDTO{ChatID: 1, MessageID: 5, UserID: 10, Emoji: "👍"}
+
+	testCases := []struct {
+		name          string
+		message       *domains.Message
+		alreadyLeft   bool
+		expectAdd     bool
+		expectedAdded bool
+		expectedError error
+	}{
+		{
+			name:          "new reaction",
+			message:       &domains.Message{ID: 5, ChatID: 1},
+			expectAdd:     true,
+			expectedAdded: true,
+		},
+		{
+			name:        "already left reaction",
+			message:     &domains.Message{ID: 5, ChatID: 1},
+			alreadyLeft: true,
+			expectAdd:   true,
+		},
+		{
+			name:          "message of other chat",
+			message:       &domains.Message{ID: 5, ChatID: 2},
+			expectedError: customerrors.ErrMessageNotFound,
+		},
+		{
+			name:          "message deleted for everyone",
+			message:       &domains.Message{ID: 5, ChatID: 1, DeletedAt: pointers.New(time.Now())},
+			expectedError: customerrors.ErrMessageNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), reaction.ChatID, reaction.UserID).
+				Return(&domains.ChatMember{ChatID: reaction.ChatID, UserID: reaction.UserID}, nil)
+			messagesRepository.EXPECT().
+				GetMessageByIDForUpdate(gomock.Any(), reaction.MessageID).
+				Return(tc.message, nil)
+
+			if tc.expectAdd {
+				messagesRepository.EXPECT().
+					AddMessageReaction(gomock.Any(), reaction).
+					Return(!tc.alreadyLeft, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			added, err := service.AddMessageReaction(context.Background(), reaction)
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedAdded, added)
+		})
+	}
+}
+
+func TestMessagesService_VotePoll(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID    uint64 = 1
+		messageID uint64 = 5
+		pollID    uint64 = 7
+		userID    uint64 = 10
+	)
+
+	pollMessage := &domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypePoll}
+	options := []domains.PollOption{
+		{ID: 101, PollID: pollID, Text: "Pizza"},
+		{ID: 102, PollID: pollID, Text: "Sushi"},
+	}
+
+	testCases := []struct {
+		name          string
+		message       *domains.Message
+		poll          domains.Poll
+		voted         bool // Whether User has already voted for the first option
+		optionIDs     []uint64
+		expectVote    bool
+		expectedError error
+	}{
+		{
+			name:       "vote in public single choice poll",
+			message:    pollMessage,
+			poll:       domains.Poll{ID: pollID, MessageID: messageID},
+			optionIDs:  []uint64{102},
+			expectVote: true,
+		},
+		{
+			name:       "vote for several options in anonymous multiple choice poll",
+			message:    pollMessage,
+			poll:       domains.Poll{ID: pollID, MessageID: messageID, MultipleChoice: true, Anonymous: true},
+			optionIDs:  []uint64{101, 102},
+			expectVote: true,
+		},
+		{
+			name:          "vote for several options in single choice poll",
+			message:       pollMessage,
+			poll:          domains.Poll{ID: pollID, MessageID: messageID, Anonymous: true},
+			optionIDs:     []uint64{101, 102},
+			expectedError: customerrors.ErrValidationFailed,
+		},
+		{
+			name:          "vote for option of other poll",
+			message:       pollMessage,
+			poll:          domains.Poll{ID: pollID, MessageID: messageID, Anonymous: true},
+			optionIDs:     []uint64{201},
+			expectedError: customerrors.ErrValidationFailed,
+		},
+		{
+			name:          "repeated vote",
+			message:       pollMessage,
+			poll:          domains.Poll{ID: pollID, MessageID: messageID, Anonymous: true},
+			voted:         true,
+			optionIDs:     []uint64{102},
+			expectedError: customerrors.ErrPollAlreadyVoted,
+		},
+		{
+			name:    "vote in closed poll",
+			message: pollMessage,
+			poll: domains.Poll{
+				ID:        pollID,
+				MessageID: messageID,
+				Anonymous: true,
+				ClosesAt:  pointers.New(time.Now().Add(-time.Minute)),
+			},
+			optionIDs:     []uint64{101},
+			expectedError: customerrors.ErrPollClosed,
+		},
+		{
+			name:          "vote in regular message",
+			message:       &domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			optionIDs:     []uint64{101},
+			expectedError: customerrors.ErrPollNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, userID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: userID}, nil)
+			messagesRepository.EXPECT().
+				GetMessageByIDForUpdate(gomock.Any(), messageID).
+				Return(tc.message, nil)
+
+			// expectPollLoad expects loading of poll with provided votes of the User:
+			expectPollLoad := func(votedOptionIDs []uint64) {
+				pollOptions := slices.Clone(options)
+
+				var votes []domains.PollVote
+
+				for i := range pollOptions {
+					if slices.Contains(votedOptionIDs, pollOptions[i].ID) {
+						pollOptions[i].VotesCount = 1
+						pollOptions[i].VotedByMe = true
+						votes = append(
+							votes,
+							domains.PollVote{PollID: pollID, OptionID: pollOptions[i].ID, UserID: userID},
+						)
+					}
+				}
+
+				messagesRepository.EXPECT().
+					GetMessagesPolls(gomock.Any(), []uint64{messageID}).
+					Return([]domains.Poll{tc.poll}, nil)
+				messagesRepository.EXPECT().
+					GetPollsOptions(gomock.Any(), []uint64{pollID}, userID).
+					Return(pollOptions, nil)
+
+				if !tc.poll.Anonymous {
+					messagesRepository.EXPECT().
+						GetPollsVotes(gomock.Any(), []uint64{pollID}).
+						Return(votes, nil)
+				}
+			}
+
+			if tc.message.Type == domains.MessageTypePoll {
+				if tc.voted {
+					expectPollLoad([]uint64{options[0].ID})
+				} else {
+					expectPollLoad(nil)
+				}
+			}
+
+			if tc.expectVote {
+				messagesRepository.EXPECT().
+					CreatePollVotes(gomock.Any(), pollID, userID, tc.optionIDs).
+					Return(nil)
+				expectPollLoad(tc.optionIDs)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			poll, err := service.VotePoll(
+				context.Background(),
+				domains.PollVoteDTO{
+					ChatID:    chatID,
+					MessageID: messageID,
+					UserID:    userID,
+					OptionIDs: tc.optionIDs,
+				},
+			)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.optionIDs, poll.VotedOptionIDs())
+
+			for _, option := range poll.Options {
+				switch {
+				case !slices.Contains(tc.optionIDs, option.ID):
+					require.Empty(t, option.VoterIDs)
+				case tc.poll.Anonymous:
+					require.Empty(t, option.VoterIDs)
+				default:
+					require.Equal(t, []uint64{userID}, option.VoterIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestMessagesService_SaveLinkPreview(t *testing.T) {
+	t.Parallel()
+
+	const linkPreviewID uint64 = 3
+
+	preview := domains.LinkPreview{
+		ID:     linkPreviewID,
+		URL:    "https://example.com",
+		Status: domains.LinkPreviewStatusReady,
+		Title:  pointers.New("Example"),
+	}
+
+	repoMessages := []domains.Message{
+		{ID: 5, ChatID: 1, Type: domains.MessageTypeRegular},
+		{ID: 8, ChatID: 2, Type: domains.MessageTypeRegular},
+	}
+
+	testCases := []struct {
+		name             string
+		status           domains.LinkPreviewStatus
+		updated          bool
+		expectedMessages []domains.Message
+	}{
+		{
+			name:    "ready preview",
+			status:  domains.LinkPreviewStatusReady,
+			updated: true,
+			expectedMessages: []domains.Message{
+				{ID: 5, ChatID: 1, Type: domains.MessageTypeRegular, LinkPreview: &preview},
+				{ID: 8, ChatID: 2, Type: domains.MessageTypeRegular, LinkPreview: &preview},
+			},
+		},
+		{
+			name:    "failed preview",
+			status:  domains.LinkPreviewStatusFailed,
+			updated: true,
+		},
+		{
+			name:   "preview saved by another worker",
+			status: domains.LinkPreviewStatusReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			previewData := domains.UpdateLinkPreviewDTO{LinkPreviewID: linkPreviewID, Status: tc.status}
+			if tc.status == domains.LinkPreviewStatusReady {
+				previewData.Title = preview.Title
+			}
+
+			messagesRepository.EXPECT().
+				UpdateLinkPreview(gomock.Any(), previewData).
+				Return(tc.updated, nil)
+
+			if tc.expectedMessages != nil {
+				messagesRepository.EXPECT().
+					GetLinkPreviewMessages(gomock.Any(), linkPreviewID).
+					Return(slices.Clone(repoMessages), nil)
+				messagesRepository.EXPECT().
+					GetMessagesLinkPreviews(gomock.Any(), []uint64{5, 8}).
+					Return(
+						[]domains.MessageLinkPreview{
+							{MessageID: 5, LinkPreview: preview},
+							{MessageID: 8, LinkPreview: preview},
+						},
+						nil,
+					)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				nil,
+				nil,
+				nil,
+			)
+
+			messages, err := service.SaveLinkPreview(context.Background(), previewData)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMessages, messages)
+		})
+	}
+}
+
+func TestMessagesService_CreateThreadMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID       uint64 = 1
+		authorID     uint64 = 10
+		rootAuthorID uint64 = 11
+		rootID       uint64 = 5
+		replyID      uint64 = 7
+	)
+
+	now := time.Now()
+
+	testCases := []struct {
+		name           string
+		root           *domains.Message
+		replyToMessage *domains.Message
+		expectCreate   bool
+		expectedError  error
+	}{
+		{
+			name:         "reply to thread",
+			root:         &domains.Message{ID: rootID, ChatID: chatID, AuthorID: rootAuthorID},
+			expectCreate: true,
+		},
+		{
+			name: "quote reply of the same thread",
+			root: &domains.Message{ID: rootID, ChatID: chatID, AuthorID: rootAuthorID},
+			replyToMessage: &domains.Message{
+				ID:           6,
+				ChatID:       chatID,
+				ThreadRootID: pointers.New(rootID),
+			},
+			expectCreate: true,
+		},
+		{
+			name:           "quote message of chat history",
+			root:           &domains.Message{ID: rootID, ChatID: chatID, AuthorID: rootAuthorID},
+			replyToMessage: &domains.Message{ID: 4, ChatID: chatID},
+			expectedError:  customerrors.ErrInvalidReply,
+		},
+		{
+			name:          "root of other chat",
+			root:          &domains.Message{ID: rootID, ChatID: 2, AuthorID: rootAuthorID},
+			expectedError: customerrors.ErrMessageNotFound,
+		},
+		{
+			name: "root is reply in other thread",
+			root: &domains.Message{
+				ID:           rootID,
+				ChatID:       chatID,
+				AuthorID:     rootAuthorID,
+				ThreadRootID: pointers.New[uint64](3),
+			},
+			expectedError: customerrors.ErrInvalidReply,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, authorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: authorID}, nil)
+
+			messageData := domains.CreateMessageDTO{
+				ChatID:       chatID,
+				AuthorID:     authorID,
+				Text:         "Hello!",
+				ThreadRootID: pointers.New(rootID),
+			}
+
+			messagesRepository.EXPECT().
+				GetMessageByIDForUpdate(gomock.Any(), rootID).
+				Return(tc.root, nil)
+
+			if tc.replyToMessage != nil {
+				messageData.ReplyToMessageID = pointers.New(tc.replyToMessage.ID)
+
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), tc.replyToMessage.ID).
+					Return(tc.replyToMessage, nil)
+			}
+
+			if tc.expectCreate {
+				messagesRepository.EXPECT().
+					CreateMessage(gomock.Any(), messageData).
+					Return(replyID, nil)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), replyID).
+					Return(
+						&domains.Message{
+							ID:           replyID,
+							ChatID:       chatID,
+							AuthorID:     authorID,
+							CreatedAt:    now,
+							ThreadRootID: pointers.New(rootID),
+						},
+						nil,
+					)
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), []uint64{replyID}).
+					Return(nil, nil)
+				messagesRepository.EXPECT().AddThreadReply(gomock.Any(), rootID, now).Return(nil)
+				messagesRepository.EXPECT().
+					AddThreadParticipants(gomock.Any(), chatID, rootID, rootAuthorID, authorID).
+					Return(nil)
+				messagesRepository.EXPECT().
+					IncrementThreadUnreadCounters(gomock.Any(), rootID, authorID).
+					Return(nil)
+				messagesRepository.EXPECT().
+					UpdateThreadLastReadMessage(gomock.Any(), rootID, authorID, replyID).
+					Return(nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			message, err := service.CreateMessage(context.Background(), messageData)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectCreate {
+				require.Equal(t, replyID, message.ID)
+			}
+		})
+	}
+}
+
+func TestMessagesService_CreateMessageWithAttachments(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID    uint64 = 1
+		authorID  uint64 = 10
+		messageID uint64 = 7
+	)
+
+	attachmentIDs := []uint64{3, 4}
+
+	buildAttachments := func(modify func(attachment *domains.Attachment)) []domains.Attachment {
+		attachments := make([]domains.Attachment, len(attachmentIDs))
+		for i, id := range attachmentIDs {
+			attachments[i] = domains.Attachment{ID: id, ChatID: chatID, UploaderID: authorID}
+			if modify != nil {
+				modify(&attachments[i])
+			}
+		}
+
+		return attachments
+	}
+
+	testCases := []struct {
+		name          string
+		attachments   []domains.Attachment
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:         "uploaded attachments",
+			attachments:  buildAttachments(nil),
+			expectCreate: true,
+		},
+		{
+			name:          "attachment not found",
+			attachments:   buildAttachments(nil)[:1],
+			expectedError: customerrors.ErrInvalidAttachment,
+		},
+		{
+			name: "attachment of other chat",
+			attachments: buildAttachments(func(attachment *domains.Attachment) {
+				attachment.ChatID = 2
+			}),
+			expectedError: customerrors.ErrInvalidAttachment,
+		},
+		{
+			name: "attachment of other uploader",
+			attachments: buildAttachments(func(attachment *domains.Attachment) {
+				attachment.UploaderID = 11
+			}),
+			expectedError: customerrors.ErrInvalidAttachment,
+		},
+		{
+			name: "attachment has already been sent",
+			attachments: buildAttachments(func(attachment *domains.Attachment) {
+				attachment.MessageID = pointers.New[uint64](5)
+			}),
+			expectedError: customerrors.ErrInvalidAttachment,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, authorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: authorID}, nil)
+
+			messageData := domains.CreateMessageDTO{
+				ChatID:        chatID,
+				AuthorID:      authorID,
+				AttachmentIDs: attachmentIDs,
+			}
+
+			attachmentsRepository.EXPECT().
+				GetAttachmentsByIDsForUpdate(gomock.Any(), attachmentIDs).
+				Return(tc.attachments, nil)
+
+			if tc.expectCreate {
+				sentAttachments := buildAttachments(func(attachment *domains.Attachment) {
+					attachment.MessageID = pointers.New(messageID)
+				})
+
+				messagesRepository.EXPECT().CreateMessage(gomock.Any(), messageData).Return(messageID, nil)
+				attachmentsRepository.EXPECT().
+					SetAttachmentsMessage(gomock.Any(), messageID, attachmentIDs).
+					Return(nil)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				chatsRepository.EXPECT().IncrementUnreadCounters(gomock.Any(), chatID, authorID, uint64(1)).Return(nil)
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, authorID, messageID).
+					Return(nil)
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), messageID).
+					Return(&domains.Message{ID: messageID, ChatID: chatID, AuthorID: authorID}, nil)
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), []uint64{messageID}).
+					Return(sentAttachments, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			message, err := service.CreateMessage(context.Background(), messageData)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectCreate {
+				require.Len(t, message.Attachments, len(attachmentIDs))
+			}
+		})
+	}
+}
+
+func TestMessagesService_CreateMessageWithMentions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID    uint64 = 1
+		authorID  uint64 = 10
+		messageID uint64 = 20
+	)
+
+	members := []domains.ChatMember{
+		{ChatID: chatID, UserID: authorID},
+		{ChatID: chatID, UserID: 11},
+		{ChatID: chatID, UserID: 12},
+		{ChatID: chatID, UserID: 13},
+	}
+
+	users := map[string]uint64{
+		"author":   authorID,
+		"alice":    11,
+		"stranger": 99, // Not a member of chat
+	}
+
+	buildMention := func(userID uint64, mentionType domains.MentionType) domains.CreateMentionDTO {
+		return domains.CreateMentionDTO{MessageID: messageID, ChatID: chatID, UserID: userID, Type: mentionType}
+	}
+
+	testCases := []struct {
+		name             string
+		usernames        []string
+		mentionAll       bool
+		hereUserIDs      []uint64
+		expectedMentions []domains.CreateMentionDTO
+	}{
+		{
+			name:      "only members are mentioned by username",
+			usernames: []string{"alice", "ghost", "stranger", "author"},
+			expectedMentions: []domains.CreateMentionDTO{
+				buildMention(11, domains.MentionTypeUser),
+			},
+		},
+		{
+			name:       "personal mention takes precedence over all",
+			usernames:  []string{"alice"},
+			mentionAll: true,
+			expectedMentions: []domains.CreateMentionDTO{
+				buildMention(11, domains.MentionTypeUser),
+				buildMention(12, domains.MentionTypeAll),
+				buildMention(13, domains.MentionTypeAll),
+			},
+		},
+		{
+			name:        "here mentions only online members",
+			hereUserIDs: []uint64{13, 99},
+			expectedMentions: []domains.CreateMentionDTO{
+				buildMention(13, domains.MentionTypeHere),
+			},
+		},
+		{
+			name:      "nobody to mention",
+			usernames: []string{"ghost"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+			usersRepository := mockrepositories.NewMockUsersRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, authorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: authorID}, nil)
+
+			messageData := domains.CreateMessageDTO{
+				ChatID:             chatID,
+				AuthorID:           authorID,
+				Text:               "hello",
+				MentionedUsernames: tc.usernames,
+				MentionAll:         tc.mentionAll,
+				HereUserIDs:        tc.hereUserIDs,
+			}
+
+			messagesRepository.EXPECT().CreateMessage(gomock.Any(), messageData).Return(messageID, nil)
+			chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+			messagesRepository.EXPECT().
+				GetMessageByID(gomock.Any(), messageID).
+				Return(&domains.Message{ID: messageID, ChatID: chatID, AuthorID: authorID}, nil)
+			attachmentsRepository.EXPECT().
+				GetMessagesAttachments(gomock.Any(), []uint64{messageID}).
+				Return(nil, nil)
+			chatsRepository.EXPECT().GetChatsMembers(gomock.Any(), []uint64{chatID}).Return(members, nil)
+
+			for _, username := range tc.usernames {
+				userID, ok := users[username]
+				if !ok {
+					usersRepository.EXPECT().GetUserByUsername(gomock.Any(), username).Return(nil, sql.ErrNoRows)
+
+					continue
+				}
+
+				usersRepository.EXPECT().
+					GetUserByUsername(gomock.Any(), username).
+					Return(&domains.User{ID: userID, Username: username}, nil)
+			}
+
+			if len(tc.expectedMentions) > 0 {
+				messagesRepository.EXPECT().CreateMentions(gomock.Any(), tc.expectedMentions).Return(nil)
+			}
+
+			chatsRepository.EXPECT().IncrementUnreadCounters(gomock.Any(), chatID, authorID, uint64(1)).Return(nil)
+			chatsRepository.EXPECT().
+				UpdateLastReadMessage(gomock.Any(), chatID, authorID, messageID).
+				Return(nil)
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				func(_ pg.Transaction) interfaces.UsersRepository {
+					return usersRepository
+				},
+			)
+
+			_, err := service.CreateMessage(context.Background(), messageData)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMessagesService_CreateChannelMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID   uint64 = 1
+		authorID uint64 = 10
+	)
+
+	testCases := []struct {
+		name          string
+		role          domains.ChatMemberRole
+		expectedError error
+	}{
+		{
+			name:          "subscriber can not post",
+			role:          domains.ChatMemberRoleMember,
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeChannel}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, authorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: authorID, Role: tc.role}, nil)
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			_, err := service.CreateMessage(
+				context.Background(),
+				domains.CreateMessageDTO{ChatID: chatID, AuthorID: authorID, Text: "News!"},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestMessagesService_SearchMessages(t *testing.T) {
+	t.Parallel()
+
+	buildResults := func(ids ...uint64) []domains.MessageSearchResult {
+		results := make([]domains.MessageSearchResult, len(ids))
+		for i, id := range ids {
+			results[i] = domains.MessageSearchResult{
+				Message: domains.Message{ID: id, ChatID: 1},
+				Snippet: "<mark>found</mark>",
+				Rank:    float64(len(ids) - i),
+			}
+		}
+
+		return results
+	}
+
+	testCases := []struct {
+		name            string
+		repoResults     []domains.MessageSearchResult
+		expectedResults []domains.MessageSearchResult
+		expectedHasMore bool
+	}{
+		{
+			name:            "nothing found",
+			repoResults:     nil,
+			expectedResults: nil,
+			expectedHasMore: false,
+		},
+		{
+			name:            "last page",
+			repoResults:     buildResults(5, 3),
+			expectedResults: buildResults(5, 3),
+			expectedHasMore: false,
+		},
+		{
+			name:            "page with more",
+			repoResults:     buildResults(9, 4, 7),
+			expectedResults: buildResults(9, 4, 7)[:2],
+			expectedHasMore: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			searchData := domains.SearchMessagesDTO{UserID: 2, Query: "found", Limit: 2, Offset: 4}
+
+			expectedSearchData := searchData
+			expectedSearchData.Limit++
+			messagesRepository.EXPECT().
+				SearchMessages(gomock.Any(), expectedSearchData).
+				Return(tc.repoResults, nil)
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			page, err := service.SearchMessages(context.Background(), searchData)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResults, page.Results)
+			require.Equal(t, tc.expectedHasMore, page.HasMore)
+		})
+	}
+}
+
+func TestMessagesService_PinMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID          uint64 = 1
+		messageID       uint64 = 5
+		systemMessageID uint64 = 6
+		actorID         uint64 = 10
+		pinsLimit              = 2
+	)
+
+	testCases := []struct {
+		name          string
+		chatType      domains.ChatType
+		actorRole     domains.ChatMemberRole
+		message       domains.Message
+		alreadyPinned bool
+		pinsCount     int
+		expectPin     bool
+		expectCount   bool
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:         "admin pins message of group chat",
+			chatType:     domains.ChatTypeGroup,
+			actorRole:    domains.ChatMemberRoleAdmin,
+			message:      domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			pinsCount:    pinsLimit,
+			expectPin:    true,
+			expectCount:  true,
+			expectCreate: true,
+		},
+		{
+			name:         "member pins message of direct chat",
+			chatType:     domains.ChatTypeDirect,
+			actorRole:    domains.ChatMemberRoleMember,
+			message:      domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			pinsCount:    1,
+			expectPin:    true,
+			expectCount:  true,
+			expectCreate: true,
+		},
+		{
+			name:          "member pins message of group chat",
+			chatType:      domains.ChatTypeGroup,
+			actorRole:     domains.ChatMemberRoleMember,
+			message:       domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+		{
+			name:          "admin pins system message",
+			chatType:      domains.ChatTypeGroup,
+			actorRole:     domains.ChatMemberRoleAdmin,
+			message:       domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeSystem},
+			expectedError: customerrors.ErrMessagePermissionDenied,
+		},
+		{
+			name:      "admin pins deleted message",
+			chatType:  domains.ChatTypeGroup,
+			actorRole: domains.ChatMemberRoleAdmin,
+			message: domains.Message{
+				ID:        messageID,
+				ChatID:    chatID,
+				Type:      domains.MessageTypeRegular,
+				DeletedAt: pointers.New(time.Now()),
+			},
+			expectedError: customerrors.ErrMessageNotFound,
+		},
+		{
+			name:          "admin pins already pinned message",
+			chatType:      domains.ChatTypeGroup,
+			actorRole:     domains.ChatMemberRoleAdmin,
+			message:       domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			alreadyPinned: true,
+			expectPin:     true,
+			expectedError: customerrors.ErrMessageAlreadyPinned,
+		},
+		{
+			name:          "admin exceeds pins limit",
+			chatType:      domains.ChatTypeGroup,
+			actorRole:     domains.ChatMemberRoleAdmin,
+			message:       domains.Message{ID: messageID, ChatID: chatID, Type: domains.MessageTypeRegular},
+			pinsCount:     pinsLimit + 1,
+			expectPin:     true,
+			expectCount:   true,
+			expectedError: customerrors.ErrPinsLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, actorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: actorID, Role: tc.actorRole}, nil)
+			messagesRepository.EXPECT().
+				GetMessageByIDForUpdate(gomock.Any(), messageID).
+				Return(&tc.message, nil)
+			chatsRepository.EXPECT().
+				GetChatByIDForUpdate(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: tc.chatType}, nil)
+
+			if tc.expectPin {
+				messagesRepository.EXPECT().
+					CreatePin(gomock.Any(), chatID, messageID, actorID).
+					Return(!tc.alreadyPinned, nil)
+			}
+
+			if tc.expectCount {
+				messagesRepository.EXPECT().CountChatPins(gomock.Any(), chatID).Return(tc.pinsCount, nil)
+			}
+
+			if tc.expectCreate {
+				messagesRepository.EXPECT().
+					GetMessagePin(gomock.Any(), messageID).
+					Return(&domains.Pin{ChatID: chatID, MessageID: messageID, PinnedBy: actorID}, nil)
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), []uint64{messageID}).
+					Return(nil, nil)
+				messagesRepository.EXPECT().
+					CreateMessage(
+						gomock.Any(),
+						domains.CreateMessageDTO{
+							ChatID:   chatID,
+							AuthorID: actorID,
+							Type:     domains.MessageTypeSystem,
+							SystemEvent: &domains.SystemEvent{
+								Type:      domains.SystemEventTypeMessagePinned,
+								MessageID: pointers.New(messageID),
+							},
+						},
+					).
+					Return(systemMessageID, nil)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				chatsRepository.EXPECT().IncrementUnreadCounters(gomock.Any(), chatID, actorID, uint64(1)).Return(nil)
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, actorID, systemMessageID).
+					Return(nil)
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), systemMessageID).
+					Return(&domains.Message{ID: systemMessageID, ChatID: chatID, Type: domains.MessageTypeSystem}, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			pin, systemMessage, err := service.PinMessage(
+				context.Background(),
+				domains.PinMessageDTO{
+					ChatID:    chatID,
+					MessageID: messageID,
+					ActorID:   actorID,
+					PinsLimit: pinsLimit,
+				},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectedError == nil {
+				require.Equal(t, messageID, pin.Message.ID)
+				require.Equal(t, systemMessageID, systemMessage.ID)
+			}
+		})
+	}
+}
+
+func TestMessagesService_ForwardMessages(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID     uint64 = 1
+		fromChatID uint64 = 2
+		actorID    uint64 = 10
+		authorID   uint64 = 11
+	)
+
+	forwardedOriginal := domains.Message{
+		ID:                     6,
+		ChatID:                 fromChatID,
+		AuthorID:               authorID,
+		Type:                   domains.MessageTypeRegular,
+		Text:                   "forwarded before",
+		ForwardedFromMessageID: pointers.New[uint64](3),
+		ForwardedFromChatID:    pointers.New[uint64](4),
+		ForwardedFromAuthorID:  pointers.New[uint64](12),
+	}
+
+	testCases := []struct {
+		name          string
+		messageIDs    []uint64
+		originals     []domains.Message
+		attachments   []domains.Attachment
+		expectedCopy  []domains.CreateMessageDTO
+		expectedError error
+	}{
+		{
+			name:       "messages with attachments are forwarded",
+			messageIDs: []uint64{5, 6},
+			originals: []domains.Message{
+				{ID: 5, ChatID: fromChatID, AuthorID: authorID, Type: domains.MessageTypeRegular, Text: "hello"},
+				forwardedOriginal,
+			},
+			attachments: []domains.Attachment{
+				{ID: 50, ChatID: fromChatID, MessageID: pointers.New[uint64](5)},
+			},
+			expectedCopy: []domains.CreateMessageDTO{
+				{
+					ChatID:                 chatID,
+					AuthorID:               actorID,
+					Type:                   domains.MessageTypeRegular,
+					Text:                   "hello",
+					ForwardedFromMessageID: pointers.New[uint64](5),
+					ForwardedFromChatID:    pointers.New(fromChatID),
+					ForwardedFromAuthorID:  pointers.New(authorID),
+				},
+				{
+					ChatID:                 chatID,
+					AuthorID:               actorID,
+					Type:                   domains.MessageTypeRegular,
+					Text:                   "forwarded before",
+					ForwardedFromMessageID: pointers.New[uint64](3),
+					ForwardedFromChatID:    pointers.New[uint64](4),
+					ForwardedFromAuthorID:  pointers.New[uint64](12),
+				},
+			},
+		},
+		{
+			name:       "invisible message is not forwarded",
+			messageIDs: []uint64{5, 7},
+			originals: []domains.Message{
+				{ID: 5, ChatID: fromChatID, AuthorID: authorID, Type: domains.MessageTypeRegular, Text: "hello"},
+			},
+			expectedError: customerrors.ErrMessageNotFound,
+		},
+		{
+			name:       "system message is not forwarded",
+			messageIDs: []uint64{8},
+			originals: []domains.Message{
+				{ID: 8, ChatID: fromChatID, AuthorID: authorID, Type: domains.MessageTypeSystem},
+			},
+			expectedError: customerrors.ErrInvalidForward,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chatsRepository.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, actorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: actorID}, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), fromChatID, actorID).
+				Return(&domains.ChatMember{ChatID: fromChatID, UserID: actorID}, nil)
+			messagesRepository.EXPECT().
+				GetChatMessagesByIDs(gomock.Any(), fromChatID, actorID, tc.messageIDs).
+				Return(tc.originals, nil)
+
+			if len(tc.originals) == len(tc.messageIDs) {
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), tc.messageIDs).
+					Return(tc.attachments, nil)
+			}
+
+			copyIDs := make([]uint64, len(tc.expectedCopy))
+			copies := make([]domains.Message, len(tc.expectedCopy))
+
+			for i, copyData := range tc.expectedCopy {
+				copyIDs[i] = uint64(100 + i)
+				copies[i] = domains.Message{
+					ID:     copyIDs[i],
+					ChatID: chatID,
+					Type:   domains.MessageTypeRegular,
+					Text:   copyData.Text,
+				}
+
+				messagesRepository.EXPECT().CreateMessage(gomock.Any(), copyData).Return(copyIDs[i], nil)
+				messagesRepository.EXPECT().
+					CopyMessageLinkPreview(gomock.Any(), tc.messageIDs[i], copyIDs[i]).
+					Return(nil)
+			}
+
+			if tc.expectedError == nil {
+				attachmentsRepository.EXPECT().
+					CopyAttachment(gomock.Any(), uint64(50), chatID, copyIDs[0]).
+					Return(uint64(500), nil)
+				attachmentsRepository.EXPECT().
+					CopyAttachmentThumbnails(gomock.Any(), uint64(50), uint64(500)).
+					Return(nil)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				chatsRepository.EXPECT().
+					IncrementUnreadCounters(gomock.Any(), chatID, actorID, uint64(len(copyIDs))).
+					Return(nil)
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, actorID, copyIDs[len(copyIDs)-1]).
+					Return(nil)
+				messagesRepository.EXPECT().
+					GetChatMessagesByIDs(gomock.Any(), chatID, actorID, copyIDs).
+					Return(copies, nil)
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), copyIDs).
+					Return(nil, nil)
+				messagesRepository.EXPECT().
+					GetMessagesLinkPreviews(gomock.Any(), copyIDs).
+					Return(nil, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			messages, err := service.ForwardMessages(
+				context.Background(),
+				domains.ForwardMessagesDTO{
+					ChatID:     chatID,
+					FromChatID: fromChatID,
+					MessageIDs: tc.messageIDs,
+					ActorID:    actorID,
+				},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectedError == nil {
+				require.Equal(t, copies, messages)
+			}
+		})
+	}
+}
+
+func TestMessagesService_SendScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID             uint64 = 1
+		authorID           uint64 = 10
+		scheduledMessageID uint64 = 3
+		messageID          uint64 = 7
+	)
+
+	updatedAt := time.Now()
+	scheduledMessage := domains.ScheduledMessage{
+		ID:        scheduledMessageID,
+		ChatID:    chatID,
+		AuthorID:  authorID,
+		Text:      "Good morning!",
+		UpdatedAt: updatedAt,
+	}
+	messageData := domains.CreateMessageDTO{
+		ChatID:   chatID,
+		AuthorID: authorID,
+		Type:     domains.MessageTypeRegular,
+		Text:     scheduledMessage.Text,
+	}
+
+	testCases := []struct {
+		name          string
+		locked        *domains.ScheduledMessage
+		lockErr       error
+		expectSend    bool
+		expectedError error
+	}{
+		{
+			name:       "due message",
+			locked:     &scheduledMessage,
+			expectSend: true,
+		},
+		{
+			name:          "message is locked by another dispatcher",
+			lockErr:       sql.ErrNoRows,
+			expectedError: customerrors.ErrScheduledMessageNotFound,
+		},
+		{
+			name: "message was changed",
+			locked: &domains.ScheduledMessage{
+				ID:        scheduledMessageID,
+				ChatID:    chatID,
+				AuthorID:  authorID,
+				Text:      "Good evening!",
+				UpdatedAt: updatedAt.Add(time.Minute),
+			},
+			expectedError: customerrors.ErrScheduledMessageNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			messagesRepository.EXPECT().
+				LockPendingScheduledMessage(gomock.Any(), scheduledMessageID).
+				Return(tc.locked, tc.lockErr)
+
+			if tc.expectSend {
+				chatsRepository.EXPECT().
+					GetChatByID(gomock.Any(), chatID).
+					Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+				chatsRepository.EXPECT().
+					GetChatMember(gomock.Any(), chatID, authorID).
+					Return(&domains.ChatMember{ChatID: chatID, UserID: authorID}, nil)
+				messagesRepository.EXPECT().CreateMessage(gomock.Any(), messageData).Return(messageID, nil)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), messageID).
+					Return(&domains.Message{ID: messageID, ChatID: chatID, AuthorID: authorID}, nil)
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), []uint64{messageID}).
+					Return(nil, nil)
+				chatsRepository.EXPECT().IncrementUnreadCounters(gomock.Any(), chatID, authorID, uint64(1)).Return(nil)
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, authorID, messageID).
+					Return(nil)
+				messagesRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), scheduledMessageID).Return(nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			message, err := service.SendScheduledMessage(context.Background(), scheduledMessage, messageData)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectSend {
+				require.Equal(t, messageID, message.ID)
+			}
+		})
+	}
+}
+
+func TestMessagesService_SetChatMessageTTL(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID        uint64 = 1
+		actorID       uint64 = 10
+		systemMessage uint64 = 7
+	)
+
+	testCases := []struct {
+		name          string
+		chat          domains.Chat
+		role          domains.ChatMemberRole
+		messageTTL    domains.MessageTTL
+		expectChange  bool
+		expectedError error
+	}{
+		{
+			name:         "member of direct chat turns timer on",
+			chat:         domains.Chat{ID: chatID, Type: domains.ChatTypeDirect},
+			role:         domains.ChatMemberRoleMember,
+			messageTTL:   domains.MessageTTLDay,
+			expectChange: true,
+		},
+		{
+			name:          "member of group chat",
+			chat:          domains.Chat{ID: chatID, Type: domains.ChatTypeGroup},
+			role:          domains.ChatMemberRoleMember,
+			messageTTL:    domains.MessageTTLWeek,
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+		{
+			name:       "timer is not changed",
+			chat:       domains.Chat{ID: chatID, Type: domains.ChatTypeGroup, MessageTTL: domains.MessageTTLWeek},
+			role:       domains.ChatMemberRoleAdmin,
+			messageTTL: domains.MessageTTLWeek,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			chat := tc.chat
+			chatsRepository.EXPECT().GetChatByIDForUpdate(gomock.Any(), chatID).Return(&chat, nil)
+			chatsRepository.EXPECT().
+				GetChatMember(gomock.Any(), chatID, actorID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: actorID, Role: tc.role}, nil)
+
+			if tc.expectChange {
+				chatsRepository.EXPECT().UpdateChatMessageTTL(gomock.Any(), chatID, tc.messageTTL).Return(nil)
+				messagesRepository.EXPECT().
+					CreateMessage(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, messageData domains.CreateMessageDTO) (uint64, error) {
+							require.Equal(t, domains.MessageTypeSystem, messageData.Type)
+							require.Equal(t, domains.SystemEventTypeMessageTTLChanged, messageData.SystemEvent.Type)
+							require.Equal(t, tc.messageTTL, *messageData.SystemEvent.MessageTTL)
+
+							// System message disappears according to new timer:
+							require.NotNil(t, messageData.ExpiresAt)
+
+							return systemMessage, nil
+						},
+					)
+				chatsRepository.EXPECT().TouchChat(gomock.Any(), chatID).Return(nil)
+				chatsRepository.EXPECT().IncrementUnreadCounters(gomock.Any(), chatID, actorID, uint64(1)).Return(nil)
+				chatsRepository.EXPECT().
+					UpdateLastReadMessage(gomock.Any(), chatID, actorID, systemMessage).
+					Return(nil)
+				messagesRepository.EXPECT().
+					GetMessageByID(gomock.Any(), systemMessage).
+					Return(&domains.Message{ID: systemMessage, ChatID: chatID}, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				nil,
+				nil,
+			)
+
+			message, err := service.SetChatMessageTTL(
+				context.Background(),
+				domains.SetChatMessageTTLDTO{
+					ChatID:     chatID,
+					ActorID:    actorID,
+					MessageTTL: tc.messageTTL,
+				},
+			)
+			require.ErrorIs(t, err, tc.expectedError)
+
+			if tc.expectChange {
+				require.Equal(t, systemMessage, message.ID)
+			} else {
+				require.Nil(t, message)
+			}
+		})
+	}
+}
+
+func TestMessagesService_PurgeExpiredMessages(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID          uint64 = 1
+		rootID          uint64 = 1
+		rootReplyID     uint64 = 2
+		survivingRootID uint64 = 4
+		replyID         uint64 = 5
+		limit           uint64 = 100
+	)
+
+	expiredBefore := time.Now().UTC()
+
+	testCases := []struct {
+		name          string
+		expired       []domains.Message
+		expectedPurge *domains.MessagesPurge
+	}{
+		{
+			name: "expired messages",
+			expired: []domains.Message{
+				{ID: rootID, ChatID: chatID},
+				{ID: rootReplyID, ChatID: chatID, ThreadRootID: pointers.New(rootID)},
+				{ID: replyID, ChatID: chatID, ThreadRootID: pointers.New(survivingRootID)},
+			},
+			expectedPurge: &domains.MessagesPurge{
+				Messages: []domains.Message{
+					{ID: rootID, ChatID: chatID},
+					{ID: rootReplyID, ChatID: chatID, ThreadRootID: pointers.New(rootID)},
+					{ID: replyID, ChatID: chatID, ThreadRootID: pointers.New(survivingRootID)},
+				},
+				// Content of forwarded copy is still referenced by original attachment:
+				StorageKeys: []string{"chats/1/photo", "chats/1/photo_small"},
+			},
+		},
+		{
+			name: "no expired messages",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			unitOfWork := mockunitofwork.NewMockUnitOfWork(ctrl)
+			messagesRepository := mockrepositories.NewMockMessagesRepository(ctrl)
+			chatsRepository := mockrepositories.NewMockChatsRepository(ctrl)
+			attachmentsRepository := mockrepositories.NewMockAttachmentsRepository(ctrl)
+
+			unitOfWork.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(
+					func(ctx context.Context, fn func(context.Context, pg.Transaction) error) error {
+						return fn(ctx, nil)
+					},
+				)
+
+			messagesRepository.EXPECT().
+				LockExpiredMessages(gomock.Any(), expiredBefore, limit).
+				Return(tc.expired, nil)
+
+			if len(tc.expired) > 0 {
+				messageIDs := []uint64{rootID, rootReplyID, replyID}
+
+				attachmentsRepository.EXPECT().
+					GetMessagesAttachments(gomock.Any(), messageIDs).
+					Return(
+						[]domains.Attachment{
+							{
+								ID:            11,
+								MessageID:     pointers.New(rootID),
+								StorageKey:    "chats/1/photo",
+								PreviewStatus: domains.AttachmentPreviewStatusReady,
+							},
+							{ID: 12, MessageID: pointers.New(replyID), StorageKey: "chats/2/document"},
+						},
+						nil,
+					)
+				attachmentsRepository.EXPECT().
+					GetAttachmentsThumbnails(gomock.Any(), []uint64{11, 12}).
+					Return([]domains.AttachmentThumbnail{{AttachmentID: 11, StorageKey: "chats/1/photo_small"}}, nil)
+				messagesRepository.EXPECT().DeleteMessages(gomock.Any(), messageIDs).Return(nil)
+				messagesRepository.EXPECT().
+					RecountThreadReplies(gomock.Any(), []uint64{survivingRootID}).
+					Return(nil)
+				messagesRepository.EXPECT().
+					RecountThreadUnreadCounters(gomock.Any(), []uint64{survivingRootID}).
+					Return(nil)
+				chatsRepository.EXPECT().
+					GetChatByID(gomock.Any(), chatID).
+					Return(&domains.Chat{ID: chatID, Type: domains.ChatTypeGroup}, nil)
+				chatsRepository.EXPECT().RecountUnreadCounters(gomock.Any(), chatID, rootID).Return(nil)
+				attachmentsRepository.EXPECT().
+					GetUsedStorageKeys(
+						gomock.Any(),
+						[]string{"chats/1/photo", "chats/2/document", "chats/1/photo_small"},
+					).
+					Return([]string{"chats/2/document"}, nil)
+			}
+
+			service := services.NewMessagesService(
+				unitOfWork,
+				func(_ pg.Transaction) interfaces.MessagesRepository {
+					return messagesRepository
+				},
+				func(_ pg.Transaction) interfaces.ChatsRepository {
+					return chatsRepository
+				},
+				func(_ pg.Transaction) interfaces.AttachmentsRepository {
+					return attachmentsRepository
+				},
+				nil,
+			)
+
+			purge, err := service.PurgeExpiredMessages(context.Background(), expiredBefore, limit)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPurge, purge)
+		})
+	}
+}
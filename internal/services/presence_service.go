@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/domains"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+type PresenceService struct {
+	newPresenceRepositoryFunc func() interfaces.PresenceRepository
+}
+
+func NewPresenceService(
+	newPresenceRepositoryFunc func() interfaces.PresenceRepository,
+) *PresenceService {
+	return &PresenceService{
+		newPresenceRepositoryFunc: newPresenceRepositoryFunc,
+	}
+}
+
+func (s *PresenceService) AddConnection(
+	ctx context.Context,
+	userID uint64,
+	ttl time.Duration,
+) (bool, error) {
+	presenceRepository := s.newPresenceRepositoryFunc()
+
+	connections, err := presenceRepository.AddConnection(ctx, userID, ttl)
+	if err != nil {
+		return false, err
+	}
+
+	return connections == 1, nil
+}
+
+func (s *PresenceService) ProlongConnections(
+	ctx context.Context,
+	userID uint64,
+	ttl time.Duration,
+) error {
+	presenceRepository := s.newPresenceRepositoryFunc()
+
+	return presenceRepository.ProlongConnections(ctx, userID, ttl, time.Now())
+}
+
+func (s *PresenceService) RemoveConnection(ctx context.Context, userID uint64) (bool, error) {
+	presenceRepository := s.newPresenceRepositoryFunc()
+
+	connections, err := presenceRepository.RemoveConnection(ctx, userID, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return connections == 0, nil
+}
+
+func (s *PresenceService) GetPresence(
+	ctx context.Context,
+	userID uint64,
+) (*domains.Presence, error) {
+	presenceRepository := s.newPresenceRepositoryFunc()
+
+	return presenceRepository.GetPresence(ctx, userID)
+}
+
+func (s *PresenceService) GetPresences(
+	ctx context.Context,
+	userIDs []uint64,
+) ([]domains.Presence, error) {
+	presenceRepository := s.newPresenceRepositoryFunc()
+
+	return presenceRepository.GetPresences(ctx, userIDs)
+}
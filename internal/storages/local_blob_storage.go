@@ -0,0 +1,104 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	localDirPermissions  = 0o750
+	localTempFilePattern = ".upload-*"
+)
+
+// LocalBlobStorage keeps content in directory on filesystem of current instance. Content is written to
+// temporary file first, so incomplete uploads are never visible by key.
+type LocalBlobStorage struct {
+	dir string
+}
+
+// NewLocalBlobStorage creates storage in provided directory. Directory is created, if it does not exist.
+func NewLocalBlobStorage(dir string) (*LocalBlobStorage, error) {
+	if err := os.MkdirAll(dir, localDirPermissions); err != nil {
+		return nil, err
+	}
+
+	return &LocalBlobStorage{dir: dir}, nil
+}
+
+func (s *LocalBlobStorage) Put(
+	_ context.Context,
+	key string,
+	content io.Reader,
+	_ string,
+) (err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), localDirPermissions); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), localTempFilePattern)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if _, err = io.Copy(file, content); err != nil {
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (s *LocalBlobStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+func (s *LocalBlobStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// GetDownloadURL returns empty URL, because content of local storage can not be downloaded directly.
+func (s *LocalBlobStorage) GetDownloadURL(_ context.Context, _, _, _ string) (string, error) {
+	return "", nil
+}
+
+// path converts key to path inside storage directory. Keys, which lead outside the directory, are rejected.
+func (s *LocalBlobStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid blob key: %s", key)
+	}
+
+	return filepath.Join(s.dir, key), nil
+}
@@ -0,0 +1,84 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStorage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		key     = "chats/1/file"
+		content = "Hello!"
+	)
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	storage, err := NewLocalBlobStorage(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Put(ctx, key, strings.NewReader(content), "text/plain"))
+
+	reader, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, content, string(data))
+
+	require.NoError(t, storage.Delete(ctx, key))
+	require.NoError(t, storage.Delete(ctx, key))
+
+	_, err = storage.Get(ctx, key)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLocalBlobStorage_FailedPut(t *testing.T) {
+	t.Parallel()
+
+	const key = "chats/1/file"
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	storage, err := NewLocalBlobStorage(dir)
+	require.NoError(t, err)
+
+	readErr := errors.New("connection reset")
+	content := io.MultiReader(strings.NewReader("Hel"), &failingReader{err: readErr})
+	require.ErrorIs(t, storage.Put(ctx, key, content, "text/plain"), readErr)
+
+	// Neither content, nor temporary file is left:
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.Dir(key)))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestLocalBlobStorage_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	storage, err := NewLocalBlobStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"../file", "/etc/passwd", ""} {
+		require.Error(t, storage.Put(context.Background(), key, strings.NewReader(""), "text/plain"))
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
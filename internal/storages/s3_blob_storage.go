@@ -0,0 +1,130 @@
+package storages
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	s3BucketAlreadyOwnedErrorCode = "BucketAlreadyOwnedByYou"
+)
+
+// S3BlobStorage keeps content in bucket of S3-compatible object storage. Content of unknown size is uploaded
+// by parts via multipart upload, so only one part is buffered in memory at once.
+type S3BlobStorage struct {
+	client *minio.Client
+	config config.AttachmentsS3Config
+}
+
+// NewS3BlobStorage connects to storage and creates bucket, if it does not exist.
+func NewS3BlobStorage(s3Config config.AttachmentsS3Config) (*S3BlobStorage, error) {
+	client, err := minio.New(
+		s3Config.Endpoint,
+		&minio.Options{
+			Creds:  credentials.NewStaticV4(s3Config.AccessKeyID, s3Config.SecretAccessKey, ""),
+			Secure: s3Config.UseSSL,
+			Region: s3Config.Region,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(context.Background(), s3Config.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		err = client.MakeBucket(
+			context.Background(),
+			s3Config.Bucket,
+			minio.MakeBucketOptions{Region: s3Config.Region},
+		)
+
+		// Bucket could have been created by other instance, which started at the same time:
+		if err != nil && minio.ToErrorResponse(err).Code != s3BucketAlreadyOwnedErrorCode {
+			return nil, err
+		}
+	}
+
+	return &S3BlobStorage{client: client, config: s3Config}, nil
+}
+
+// Put uploads content via multipart upload. Upload is aborted, if reading of content fails, so incomplete
+// content is never visible by key.
+func (s *S3BlobStorage) Put(
+	ctx context.Context,
+	key string,
+	content io.Reader,
+	contentType string,
+) error {
+	_, err := s.client.PutObject(
+		ctx,
+		s.config.Bucket,
+		key,
+		content,
+		-1, // Size is unknown, so content is uploaded by parts
+		minio.PutObjectOptions{
+			ContentType: contentType,
+			PartSize:    s.config.PartSize,
+		},
+	)
+
+	return err
+}
+
+func (s *S3BlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.config.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Object is fetched lazily, so its absence is checked before content is returned:
+	if _, err = object.Stat(); err != nil {
+		_ = object.Close()
+
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (s *S3BlobStorage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.config.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+// GetDownloadURL provides pre-signed URL, which forces storage to respond with provided filename and
+// content type.
+func (s *S3BlobStorage) GetDownloadURL(
+	ctx context.Context,
+	key string,
+	filename string,
+	contentType string,
+) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-type", contentType)
+	params.Set(
+		"response-content-disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": filename}),
+	)
+
+	downloadURL, err := s.client.PresignedGetObject(
+		ctx,
+		s.config.Bucket,
+		key,
+		s.config.PresignedURLTTL,
+		params,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return downloadURL.String(), nil
+}
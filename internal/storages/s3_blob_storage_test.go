@@ -0,0 +1,98 @@
+//go:build integration
+
+package storages
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/libs/loadenv"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests require S3-compatible storage, which is configured via environment. Local MinIO container from
+// build/package/local/docker-compose.yml is suitable for it.
+func newTestS3BlobStorage(t *testing.T) *S3BlobStorage {
+	t.Helper()
+
+	loadenv.Init("../../.env")
+
+	storage, err := NewS3BlobStorage(config.New().Attachments.S3)
+	require.NoError(t, err)
+
+	return storage
+}
+
+func TestS3BlobStorage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := newTestS3BlobStorage(t)
+
+	// Content is larger than part, so it is uploaded via multipart upload:
+	content := make([]byte, storage.config.PartSize+1024)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	const key = "tests/multipart"
+
+	require.NoError(t, storage.Put(ctx, key, bytes.NewReader(content), "application/octet-stream"))
+
+	t.Cleanup(func() {
+		require.NoError(t, storage.Delete(context.Background(), key))
+	})
+
+	object, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+
+	stored, err := io.ReadAll(object)
+	require.NoError(t, err)
+	require.NoError(t, object.Close())
+	require.Equal(t, content, stored)
+
+	downloadURL, err := storage.GetDownloadURL(ctx, key, "report.pdf", "application/pdf")
+	require.NoError(t, err)
+
+	response, err := http.Get(downloadURL) //nolint:noctx // Test request
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, response.Body.Close())
+	}()
+
+	downloaded, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "application/pdf", response.Header.Get("Content-Type"))
+	require.Equal(t, `attachment; filename=report.pdf`, response.Header.Get("Content-Disposition"))
+	require.Equal(t, content, downloaded)
+}
+
+func TestS3BlobStorage_FailedPut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := newTestS3BlobStorage(t)
+	readErr := errors.New("read failed")
+
+	const key = "tests/failed"
+
+	content := io.MultiReader(
+		bytes.NewReader(make([]byte, storage.config.PartSize+1024)),
+		&failingReader{err: readErr},
+	)
+
+	require.Error(t, storage.Put(ctx, key, content, "application/octet-stream"))
+
+	_, err := storage.Get(ctx, key)
+	require.Error(t, err)
+
+	// Absence of content is not an error for deletion:
+	require.NoError(t, storage.Delete(ctx, key))
+}
@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/libs/security"
+)
+
+// getUserIDFromAccessToken parses access token and returns ID of the User, who owns it.
+func getUserIDFromAccessToken(accessToken string, securityConfig security.Config) (uint64, error) {
+	accessTokenPayload, err := security.ParseJWT(accessToken, securityConfig.JWT.SecretKey)
+	if err != nil {
+		return 0, customerrors.ErrInvalidJWT
+	}
+
+	floatUserID, ok := accessTokenPayload.(float64)
+	if !ok {
+		return 0, customerrors.ErrInvalidJWT
+	}
+
+	return uint64(floatUserID), nil
+}
@@ -0,0 +1,270 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/security"
+)
+
+const (
+	// mimeSniffLength is a number of bytes, used by http.DetectContentType for detecting content type.
+	mimeSniffLength = 512
+
+	attachmentFilenameMaxLength = 255
+	defaultAttachmentFilename   = "file"
+)
+
+func NewAttachmentsUseCases(
+	attachmentsService interfaces.AttachmentsService,
+	chatsService interfaces.ChatsService,
+	messagesService interfaces.MessagesService,
+	eventsPublisher interfaces.EventsPublisher,
+	securityConfig security.Config,
+	attachmentsConfig config.AttachmentsConfig,
+) *AttachmentsUseCases {
+	return &AttachmentsUseCases{
+		attachmentsService: attachmentsService,
+		chatsService:       chatsService,
+		messagesService:    messagesService,
+		eventsPublisher:    eventsPublisher,
+		securityConfig:     securityConfig,
+		attachmentsConfig:  attachmentsConfig,
+	}
+}
+
+type AttachmentsUseCases struct {
+	attachmentsService interfaces.AttachmentsService
+	chatsService       interfaces.ChatsService
+	messagesService    interfaces.MessagesService
+	eventsPublisher    interfaces.EventsPublisher
+	securityConfig     security.Config
+	attachmentsConfig  config.AttachmentsConfig
+}
+
+// UploadAttachment uploads file to chat. MIME type is detected by content of file and not by its name or
+// type, provided by client.
+func (u *AttachmentsUseCases) UploadAttachment(
+	ctx context.Context,
+	attachmentData domains.RawUploadAttachmentDTO,
+) (*domains.Attachment, error) {
+	userID, err := getUserIDFromAccessToken(attachmentData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := u.chatsService.GetChatMember(ctx, attachmentData.ChatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attachments can be sent only with message, so they are not uploaded by subscribers of channel, who can not
+	// post to it:
+	chat, err := u.chatsService.GetChatByID(ctx, attachmentData.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat.Type == domains.ChatTypeChannel && !member.Role.CanPostToChannel() {
+		return nil, customerrors.ErrChatPermissionDenied
+	}
+
+	content := bufio.NewReaderSize(attachmentData.Content, mimeSniffLength)
+
+	// Peek returns io.EOF for files, which are shorter than sniff length:
+	head, err := content.Peek(mimeSniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	mimeType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrAttachmentTypeNotAllowed, err)
+	}
+
+	if !slices.Contains(u.attachmentsConfig.AllowedMIMETypes, mimeType) {
+		return nil, fmt.Errorf("%w: %s", customerrors.ErrAttachmentTypeNotAllowed, mimeType)
+	}
+
+	return u.attachmentsService.UploadAttachment(
+		ctx,
+		domains.UploadAttachmentDTO{
+			ChatID:     attachmentData.ChatID,
+			UploaderID: userID,
+			Filename:   sanitizeAttachmentFilename(attachmentData.Filename),
+			MIMEType:   mimeType,
+			MaxSize:    u.attachmentsConfig.MaxSize,
+			Content:    content,
+		},
+	)
+}
+
+// DownloadAttachment provides attachment of chat to its member. Attachment, which has not been sent yet, is
+// available only to its uploader, and attachment of message, which was deleted for everyone or by User only
+// for himself, is not available at all. Content is served by application only if storage does not support
+// direct downloads.
+func (u *AttachmentsUseCases) DownloadAttachment(
+	ctx context.Context,
+	accessToken string,
+	chatID, attachmentID uint64,
+) (*domains.AttachmentDownload, error) {
+	attachment, err := u.getAvailableAttachment(ctx, accessToken, chatID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachmentsService.DownloadAttachment(ctx, *attachment, "")
+}
+
+// DownloadAttachmentThumbnail provides thumbnail of image attachment with the same rules as for attachment
+// itself.
+func (u *AttachmentsUseCases) DownloadAttachmentThumbnail(
+	ctx context.Context,
+	accessToken string,
+	chatID, attachmentID uint64,
+	thumbnailName domains.ThumbnailName,
+) (*domains.AttachmentDownload, error) {
+	if !slices.Contains(domains.ThumbnailNames, thumbnailName) {
+		return nil, fmt.Errorf("%w: invalid thumbnail name", customerrors.ErrValidationFailed)
+	}
+
+	attachment, err := u.getAvailableAttachment(ctx, accessToken, chatID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachmentsService.DownloadAttachment(ctx, *attachment, thumbnailName)
+}
+
+// ProcessAttachmentsPreviews generates previews for batch of pending attachments and notifies about them.
+// Returns number of processed attachments, so caller could decide whether to continue immediately.
+func (u *AttachmentsUseCases) ProcessAttachmentsPreviews(ctx context.Context) (int, error) {
+	attachments, err := u.attachmentsService.ClaimAttachmentsForPreview(
+		ctx,
+		uint64(u.attachmentsConfig.Previews.BatchSize),
+		u.attachmentsConfig.Previews.ClaimTimeout,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Failure of one attachment should not prevent processing of others:
+	var errs []error
+
+	for _, attachment := range attachments {
+		processed, err := u.attachmentsService.GenerateAttachmentPreview(ctx, attachment)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("attachment %d: %w", attachment.ID, err))
+
+			continue
+		}
+
+		u.publishAttachmentUpdated(ctx, *processed)
+	}
+
+	return len(attachments), errors.Join(errs...)
+}
+
+// getAvailableAttachment provides attachment of chat, if it is available to current User.
+func (u *AttachmentsUseCases) getAvailableAttachment(
+	ctx context.Context,
+	accessToken string,
+	chatID, attachmentID uint64,
+) (*domains.Attachment, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = u.chatsService.GetChatMember(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := u.attachmentsService.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existence of other chats attachments should not be revealed:
+	if attachment.ChatID != chatID {
+		return nil, customerrors.ErrAttachmentNotFound
+	}
+
+	switch {
+	case attachment.MessageID == nil && attachment.UploaderID != userID:
+		return nil, customerrors.ErrAttachmentNotFound
+	case attachment.MessageID != nil:
+		// Attachments of messages, which were deleted for everyone or only for User, are not available:
+		_, err = u.messagesService.GetChatMessageByID(ctx, chatID, userID, *attachment.MessageID)
+
+		switch {
+		case errors.Is(err, customerrors.ErrMessageNotFound):
+			return nil, fmt.Errorf("%w: %w", customerrors.ErrAttachmentNotFound, err)
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	return attachment, nil
+}
+
+// publishAttachmentUpdated notifies about generated preview all members of chat, if attachment is already
+// sent, or only its uploader otherwise.
+func (u *AttachmentsUseCases) publishAttachmentUpdated(ctx context.Context, attachment domains.Attachment) {
+	recipients := []uint64{attachment.UploaderID}
+
+	if attachment.MessageID != nil {
+		var err error
+		if recipients, err = u.chatsService.GetChatMembersIDs(ctx, attachment.ChatID); err != nil {
+			return
+		}
+	}
+
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:       domains.EventTypeAttachmentUpdated,
+			ChatID:     attachment.ChatID,
+			Payload:    attachment,
+			Recipients: recipients,
+		},
+	)
+}
+
+// sanitizeAttachmentFilename leaves only base name of file without control characters and limits its length.
+func sanitizeAttachmentFilename(filename string) string {
+	filename = strings.Map(
+		func(r rune) rune {
+			if r < ' ' || r == 0x7f {
+				return -1
+			}
+
+			return r
+		},
+		strings.ToValidUTF8(filename, ""),
+	)
+
+	filename = strings.TrimSpace(filepath.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if filename == "" || filename == "." || filename == "/" {
+		return defaultAttachmentFilename
+	}
+
+	if utf8.RuneCountInString(filename) > attachmentFilenameMaxLength {
+		filename = string([]rune(filename)[:attachmentFilenameMaxLength])
+	}
+
+	return filename
+}
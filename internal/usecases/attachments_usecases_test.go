@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	mockservices "github.com/DKhorkov/kfc/mocks/services"
+	"github.com/DKhorkov/libs/pointers"
+	"github.com/DKhorkov/libs/security"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttachmentsUseCases_UploadAttachment(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID uint64 = 1
+		userID uint64 = 10
+	)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey: "secret",
+			Algorithm: "HS256",
+		},
+	}
+
+	accessToken, err := security.GenerateJWT(userID, securityConfig.JWT.SecretKey, time.Minute, "HS256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		chatType      domains.ChatType
+		role          domains.ChatMemberRole
+		expectedError error
+	}{
+		{
+			name:     "member of group chat",
+			chatType: domains.ChatTypeGroup,
+			role:     domains.ChatMemberRoleMember,
+		},
+		{
+			name:     "admin of channel",
+			chatType: domains.ChatTypeChannel,
+			role:     domains.ChatMemberRoleAdmin,
+		},
+		{
+			name:          "subscriber of channel",
+			chatType:      domains.ChatTypeChannel,
+			role:          domains.ChatMemberRoleMember,
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			chatsService := mockservices.NewMockChatsService(ctrl)
+			attachmentsService := mockservices.NewMockAttachmentsService(ctrl)
+
+			chatsService.EXPECT().
+				GetChatMember(gomock.Any(), chatID, userID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: userID, Role: tc.role}, nil)
+			chatsService.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: tc.chatType}, nil)
+
+			if tc.expectedError == nil {
+				attachmentsService.EXPECT().
+					UploadAttachment(gomock.Any(), gomock.Any()).
+					Return(&domains.Attachment{ChatID: chatID, UploaderID: userID}, nil)
+			}
+
+			useCases := NewAttachmentsUseCases(
+				attachmentsService,
+				chatsService,
+				nil,
+				nil,
+				securityConfig,
+				config.AttachmentsConfig{AllowedMIMETypes: []string{"text/plain"}},
+			)
+
+			attachment, err := useCases.UploadAttachment(
+				context.Background(),
+				domains.RawUploadAttachmentDTO{
+					AccessToken: accessToken,
+					ChatID:      chatID,
+					Filename:    "notes.txt",
+					Content:     strings.NewReader("Meeting notes"),
+				},
+			)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.Nil(t, attachment)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, attachment)
+		})
+	}
+}
+
+func TestAttachmentsUseCases_DownloadAttachment(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID       uint64 = 1
+		attachmentID uint64 = 5
+		messageID    uint64 = 7
+		userID       uint64 = 10
+		uploaderID   uint64 = 11
+	)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey: "secret",
+			Algorithm: "HS256",
+		},
+	}
+
+	accessToken, err := security.GenerateJWT(userID, securityConfig.JWT.SecretKey, time.Minute, "HS256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		attachment    domains.Attachment
+		messageErr    error
+		expectMessage bool
+		expectedError error
+	}{
+		{
+			name:       "own unsent attachment",
+			attachment: domains.Attachment{ID: attachmentID, ChatID: chatID, UploaderID: userID},
+		},
+		{
+			name:          "unsent attachment of other member",
+			attachment:    domains.Attachment{ID: attachmentID, ChatID: chatID, UploaderID: uploaderID},
+			expectedError: customerrors.ErrAttachmentNotFound,
+		},
+		{
+			name: "attachment of visible message",
+			attachment: domains.Attachment{
+				ID:         attachmentID,
+				ChatID:     chatID,
+				MessageID:  pointers.New(messageID),
+				UploaderID: uploaderID,
+			},
+			expectMessage: true,
+		},
+		{
+			name: "attachment of message deleted by User for himself",
+			attachment: domains.Attachment{
+				ID:         attachmentID,
+				ChatID:     chatID,
+				MessageID:  pointers.New(messageID),
+				UploaderID: uploaderID,
+			},
+			messageErr:    customerrors.ErrMessageNotFound,
+			expectMessage: true,
+			expectedError: customerrors.ErrAttachmentNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			chatsService := mockservices.NewMockChatsService(ctrl)
+			messagesService := mockservices.NewMockMessagesService(ctrl)
+			attachmentsService := mockservices.NewMockAttachmentsService(ctrl)
+
+			chatsService.EXPECT().
+				GetChatMember(gomock.Any(), chatID, userID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: userID}, nil)
+			attachmentsService.EXPECT().
+				GetAttachmentByID(gomock.Any(), attachmentID).
+				Return(&tc.attachment, nil)
+
+			if tc.expectMessage {
+				var message *domains.Message
+				if tc.messageErr == nil {
+					message = &domains.Message{ID: messageID, ChatID: chatID}
+				}
+
+				messagesService.EXPECT().
+					GetChatMessageByID(gomock.Any(), chatID, userID, messageID).
+					Return(message, tc.messageErr)
+			}
+
+			if tc.expectedError == nil {
+				attachmentsService.EXPECT().
+					DownloadAttachment(gomock.Any(), tc.attachment, domains.ThumbnailName("")).
+					Return(&domains.AttachmentDownload{}, nil)
+			}
+
+			useCases := NewAttachmentsUseCases(
+				attachmentsService,
+				chatsService,
+				messagesService,
+				nil,
+				securityConfig,
+				config.AttachmentsConfig{},
+			)
+
+			download, err := useCases.DownloadAttachment(context.Background(), accessToken, chatID, attachmentID)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.Nil(t, download)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, download)
+		})
+	}
+}
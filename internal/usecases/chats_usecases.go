@@ -0,0 +1,514 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DKhorkov/kfc/internal/common"
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/security"
+	"github.com/DKhorkov/libs/validation"
+)
+
+func NewChatsUseCases(
+	chatsService interfaces.ChatsService,
+	usersService interfaces.UsersService,
+	eventsPublisher interfaces.EventsPublisher,
+	securityConfig security.Config,
+	chatsConfig config.ChatsConfig,
+) *ChatsUseCases {
+	return &ChatsUseCases{
+		chatsService:    chatsService,
+		usersService:    usersService,
+		eventsPublisher: eventsPublisher,
+		securityConfig:  securityConfig,
+		chatsConfig:     chatsConfig,
+	}
+}
+
+type ChatsUseCases struct {
+	chatsService    interfaces.ChatsService
+	usersService    interfaces.UsersService
+	eventsPublisher interfaces.EventsPublisher
+	securityConfig  security.Config
+	chatsConfig     config.ChatsConfig
+}
+
+func (u *ChatsUseCases) CreateDirectChat(
+	ctx context.Context,
+	accessToken string,
+	interlocutorID uint64,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == interlocutorID {
+		return nil, fmt.Errorf(
+			"%w: can not create direct chat with yourself",
+			customerrors.ErrValidationFailed,
+		)
+	}
+
+	// Check if interlocutor exists:
+	if _, err = u.usersService.GetUserByID(ctx, interlocutorID); err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.GetOrCreateDirectChat(ctx, userID, interlocutorID)
+}
+
+func (u *ChatsUseCases) GetChats(ctx context.Context, accessToken string) ([]domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.GetUserChats(ctx, userID)
+}
+
+func (u *ChatsUseCases) GetChatByID(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := u.chatsService.GetChatByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat.Type == domains.ChatTypeChannel {
+		// Channel is provided without subscribers, so membership is checked separately:
+		if _, err = u.chatsService.GetChatMember(ctx, chatID, userID); err != nil {
+			return nil, err
+		}
+
+		if chat.UnreadCount, err = u.chatsService.GetChannelUnreadCount(ctx, chatID, userID); err != nil {
+			return nil, err
+		}
+	} else {
+		member := chat.GetMember(userID)
+		if member == nil {
+			return nil, customerrors.ErrNotChatMember
+		}
+
+		chat.UnreadCount = member.UnreadCount
+	}
+
+	if chat.UnreadThreadsCount, err = u.chatsService.GetUnreadThreadsCount(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	return chat, nil
+}
+
+func (u *ChatsUseCases) CreateGroupChat(
+	ctx context.Context,
+	chatData domains.RawCreateGroupChatDTO,
+) (*domains.Chat, error) {
+	title := strings.TrimSpace(chatData.Title)
+	if title == "" || utf8.RuneCountInString(title) > common.ChatTitleMaxLength {
+		return nil, fmt.Errorf("%w: invalid chat title", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(chatData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := uniqueUserIDs(chatData.MemberIDs, userID)
+
+	// Owner is also a member of the chat:
+	if len(memberIDs)+1 > u.chatsConfig.GroupMembersLimit {
+		return nil, fmt.Errorf(
+			"%w: chat can not have more than %d members",
+			customerrors.ErrChatMembersLimitExceeded,
+			u.chatsConfig.GroupMembersLimit,
+		)
+	}
+
+	if err = u.checkUsersExist(ctx, memberIDs); err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.CreateGroupChat(
+		ctx,
+		domains.CreateGroupChatDTO{
+			OwnerID:   userID,
+			Title:     title,
+			MemberIDs: memberIDs,
+		},
+	)
+}
+
+func (u *ChatsUseCases) CreateChannel(
+	ctx context.Context,
+	channelData domains.RawCreateChannelDTO,
+) (*domains.Chat, error) {
+	title := strings.TrimSpace(channelData.Title)
+	if title == "" || utf8.RuneCountInString(title) > common.ChatTitleMaxLength {
+		return nil, fmt.Errorf("%w: invalid channel title", customerrors.ErrValidationFailed)
+	}
+
+	var handle *string
+	if channelData.Handle != nil {
+		normalizedHandle := strings.ToLower(strings.TrimSpace(*channelData.Handle))
+		if !validation.ValidateValueByRule(normalizedHandle, u.chatsConfig.ChannelHandleRegExp) {
+			return nil, fmt.Errorf("%w: invalid channel handle", customerrors.ErrValidationFailed)
+		}
+
+		handle = &normalizedHandle
+	}
+
+	userID, err := getUserIDFromAccessToken(channelData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.CreateChannel(
+		ctx,
+		domains.CreateChannelDTO{
+			OwnerID: userID,
+			Title:   title,
+			Handle:  handle,
+		},
+	)
+}
+
+// GetChannels provides public channels, which can be found without authorization like Users.
+func (u *ChatsUseCases) GetChannels(
+	ctx context.Context,
+	filters *domains.ChannelsFilters,
+	pagination *domains.Pagination,
+) ([]domains.Chat, error) {
+	return u.chatsService.GetChannels(ctx, filters, pagination)
+}
+
+func (u *ChatsUseCases) SubscribeToChannel(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.SubscribeToChannel(ctx, chatID, userID)
+}
+
+func (u *ChatsUseCases) AddChatMembers(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+	userIDs []uint64,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	newMembersIDs := uniqueUserIDs(userIDs, userID)
+	if len(newMembersIDs) == 0 {
+		return nil, fmt.Errorf("%w: no users to add", customerrors.ErrValidationFailed)
+	}
+
+	if err = u.checkUsersExist(ctx, newMembersIDs); err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.AddGroupChatMembers(
+		ctx,
+		domains.AddChatMembersDTO{
+			ChatID:       chatID,
+			ActorID:      userID,
+			UserIDs:      newMembersIDs,
+			MembersLimit: u.chatsConfig.GroupMembersLimit,
+		},
+	)
+}
+
+func (u *ChatsUseCases) RemoveChatMember(
+	ctx context.Context,
+	accessToken string,
+	chatID, memberID uint64,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == memberID {
+		return nil, fmt.Errorf(
+			"%w: can not remove yourself from chat, leave it instead",
+			customerrors.ErrValidationFailed,
+		)
+	}
+
+	return u.chatsService.RemoveGroupChatMember(
+		ctx,
+		domains.ChangeChatMemberDTO{
+			ChatID:  chatID,
+			ActorID: userID,
+			UserID:  memberID,
+		},
+	)
+}
+
+func (u *ChatsUseCases) PromoteChatMember(
+	ctx context.Context,
+	accessToken string,
+	chatID, memberID uint64,
+) (*domains.Chat, error) {
+	return u.changeChatMemberRole(ctx, accessToken, chatID, memberID, domains.ChatMemberRoleAdmin)
+}
+
+func (u *ChatsUseCases) DemoteChatMember(
+	ctx context.Context,
+	accessToken string,
+	chatID, memberID uint64,
+) (*domains.Chat, error) {
+	return u.changeChatMemberRole(ctx, accessToken, chatID, memberID, domains.ChatMemberRoleMember)
+}
+
+func (u *ChatsUseCases) LeaveChat(ctx context.Context, accessToken string, chatID uint64) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	return u.chatsService.LeaveGroupChat(ctx, chatID, userID)
+}
+
+func (u *ChatsUseCases) CreateChatInvite(
+	ctx context.Context,
+	inviteData domains.RawCreateChatInviteDTO,
+) (*domains.ChatInvite, error) {
+	var expiresAt *time.Time
+	if inviteData.ExpiresAt != nil {
+		if !inviteData.ExpiresAt.After(time.Now()) {
+			return nil, fmt.Errorf(
+				"%w: invite expiration time must be in the future",
+				customerrors.ErrValidationFailed,
+			)
+		}
+
+		// Expiration time is stored without timezone:
+		utcExpiresAt := inviteData.ExpiresAt.UTC()
+		expiresAt = &utcExpiresAt
+	}
+
+	if inviteData.MaxUses != nil && (*inviteData.MaxUses == 0 || *inviteData.MaxUses > common.ChatInviteMaxUsesLimit) {
+		return nil, fmt.Errorf(
+			"%w: invite max uses must be from 1 to %d",
+			customerrors.ErrValidationFailed,
+			common.ChatInviteMaxUsesLimit,
+		)
+	}
+
+	userID, err := getUserIDFromAccessToken(inviteData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.CreateChatInvite(
+		ctx,
+		domains.CreateChatInviteDTO{
+			ChatID:    inviteData.ChatID,
+			ActorID:   userID,
+			ExpiresAt: expiresAt,
+			MaxUses:   inviteData.MaxUses,
+		},
+	)
+}
+
+func (u *ChatsUseCases) GetChatInvites(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) ([]domains.ChatInvite, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.GetChatInvites(ctx, chatID, userID)
+}
+
+func (u *ChatsUseCases) RevokeChatInvite(
+	ctx context.Context,
+	accessToken string,
+	chatID, inviteID uint64,
+) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	return u.chatsService.RevokeChatInvite(
+		ctx,
+		domains.RevokeChatInviteDTO{
+			ChatID:   chatID,
+			ActorID:  userID,
+			InviteID: inviteID,
+		},
+	)
+}
+
+func (u *ChatsUseCases) JoinChatByInvite(
+	ctx context.Context,
+	accessToken, token string,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.JoinChatByInvite(
+		ctx,
+		domains.JoinChatByInviteDTO{
+			Token:        token,
+			UserID:       userID,
+			MembersLimit: u.chatsConfig.GroupMembersLimit,
+		},
+	)
+}
+
+// SaveChatDraft saves draft of current User in chat and syncs it with other sessions of the User. Text of
+// draft is kept as is, because User may still be typing it.
+func (u *ChatsUseCases) SaveChatDraft(
+	ctx context.Context,
+	draftData domains.RawSaveChatDraftDTO,
+) (*domains.ChatDraft, error) {
+	if strings.TrimSpace(draftData.Text) == "" ||
+		utf8.RuneCountInString(draftData.Text) > common.MessageTextMaxLength {
+		return nil, fmt.Errorf("%w: invalid draft text", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(draftData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := u.chatsService.SaveChatDraft(
+		ctx,
+		domains.SaveChatDraftDTO{
+			ChatID: draftData.ChatID,
+			UserID: userID,
+			Text:   draftData.Text,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:       domains.EventTypeDraftUpdated,
+			ChatID:     draft.ChatID,
+			Payload:    draft,
+			Recipients: []uint64{userID},
+		},
+	)
+
+	return draft, nil
+}
+
+func (u *ChatsUseCases) GetChatDraft(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) (*domains.ChatDraft, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.GetChatDraft(ctx, chatID, userID)
+}
+
+// DeleteChatDraft deletes draft of current User in chat and removes it from other sessions of the User.
+func (u *ChatsUseCases) DeleteChatDraft(ctx context.Context, accessToken string, chatID uint64) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	if err = u.chatsService.DeleteChatDraft(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:       domains.EventTypeDraftDeleted,
+			ChatID:     chatID,
+			Payload:    domains.ChatDraftDeletion{ChatID: chatID},
+			Recipients: []uint64{userID},
+		},
+	)
+
+	return nil
+}
+
+func (u *ChatsUseCases) changeChatMemberRole(
+	ctx context.Context,
+	accessToken string,
+	chatID, memberID uint64,
+	role domains.ChatMemberRole,
+) (*domains.Chat, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.chatsService.ChangeGroupChatMemberRole(
+		ctx,
+		domains.ChangeChatMemberRoleDTO{
+			ChangeChatMemberDTO: domains.ChangeChatMemberDTO{
+				ChatID:  chatID,
+				ActorID: userID,
+				UserID:  memberID,
+			},
+			Role: role,
+		},
+	)
+}
+
+func (u *ChatsUseCases) checkUsersExist(ctx context.Context, userIDs []uint64) error {
+	for _, userID := range userIDs {
+		if _, err := u.usersService.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueUserIDs returns sorted unique User IDs without excluded one.
+func uniqueUserIDs(userIDs []uint64, excludedUserID uint64) []uint64 {
+	result := slices.DeleteFunc(
+		slices.Clone(userIDs),
+		func(userID uint64) bool {
+			return userID == excludedUserID
+		},
+	)
+
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/DKhorkov/kfc/internal/common"
+)
+
+// linkTrailingPunctuation is trimmed from the end of link, because it usually belongs to the sentence:
+const linkTrailingPunctuation = `.,:;!?'")]}`
+
+// linkRegExp matches HTTP and HTTPS links at the beginning of text or after a character, which can not be a
+// part of word, so links glued to words are not treated as links.
+var linkRegExp = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])(https?://[^\s<>"]+)`)
+
+// parseLinkURL finds the first link in message text, which can be previewed. Scheme and host of link are
+// lowercased and fragment is removed, so the same page is previewed once. Links with credentials are skipped
+// to not send them to third-party server.
+func parseLinkURL(text string) *string {
+	for _, match := range linkRegExp.FindAllStringSubmatch(text, -1) {
+		linkURL, err := url.Parse(trimLinkPunctuation(match[1]))
+		if err != nil || linkURL.Hostname() == "" || linkURL.User != nil {
+			continue
+		}
+
+		linkURL.Scheme = strings.ToLower(linkURL.Scheme)
+		linkURL.Host = strings.ToLower(linkURL.Host)
+		linkURL.Fragment = ""
+		linkURL.RawFragment = ""
+
+		normalized := linkURL.String()
+		if len(normalized) > common.LinkURLMaxLength {
+			continue
+		}
+
+		return &normalized
+	}
+
+	return nil
+}
+
+// trimLinkPunctuation removes punctuation from the end of link. Closing parenthesis is kept, if it is paired
+// within the link, as in links to Wikipedia articles.
+func trimLinkPunctuation(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		if !strings.ContainsRune(linkTrailingPunctuation, rune(last)) {
+			break
+		}
+
+		if last == ')' && strings.Count(link, "(") >= strings.Count(link, ")") {
+			break
+		}
+
+		link = link[:len(link)-1]
+	}
+
+	return link
+}
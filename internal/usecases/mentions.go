@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"regexp"
+
+	"github.com/DKhorkov/kfc/internal/common"
+)
+
+const (
+	mentionAllKeyword  = "all"
+	mentionHereKeyword = "here"
+)
+
+// mentionRegExp matches "@" at the beginning of text or after a character, which can not be a part of
+// username or e-mail, so addresses like "user@example.com" are not treated as mentions.
+var mentionRegExp = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([A-Za-z0-9]+)`)
+
+// parsedMentions contains mentions, found in message text.
+type parsedMentions struct {
+	usernames []string
+	all       bool
+	here      bool
+}
+
+func (m parsedMentions) isEmpty() bool {
+	return len(m.usernames) == 0 && !m.all && !m.here
+}
+
+// parseMentions finds unique @username mentions and @all and @here keywords in message text. Number of
+// usernames is limited to not resolve unbounded number of Users for a single message.
+func parseMentions(text string) parsedMentions {
+	var mentions parsedMentions
+
+	seen := make(map[string]struct{})
+
+	for _, match := range mentionRegExp.FindAllStringSubmatch(text, -1) {
+		username := match[1]
+
+		switch username {
+		case mentionAllKeyword:
+			mentions.all = true
+		case mentionHereKeyword:
+			mentions.here = true
+		default:
+			if _, ok := seen[username]; ok || len(mentions.usernames) >= common.MentionsPerMessageLimit {
+				continue
+			}
+
+			seen[username] = struct{}{}
+			mentions.usernames = append(mentions.usernames, username)
+		}
+	}
+
+	return mentions
+}
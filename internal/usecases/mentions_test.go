@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/kfc/internal/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	manyUsernames := make([]string, common.MentionsPerMessageLimit+5)
+	for i := range manyUsernames {
+		manyUsernames[i] = fmt.Sprintf("user%d", i)
+	}
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected parsedMentions
+	}{
+		{
+			name:     "no mentions",
+			text:     "just a message",
+			expected: parsedMentions{},
+		},
+		{
+			name: "usernames are unique",
+			text: "@alice1 meet @bob42, @alice1!",
+			expected: parsedMentions{
+				usernames: []string{"alice1", "bob42"},
+			},
+		},
+		{
+			name:     "e-mail is not a mention",
+			text:     "write to alice@example.com or (@support)",
+			expected: parsedMentions{usernames: []string{"support"}},
+		},
+		{
+			name:     "everyone keywords",
+			text:     "@all and @here, привет @ivanov",
+			expected: parsedMentions{usernames: []string{"ivanov"}, all: true, here: true},
+		},
+		{
+			name:     "number of usernames is limited",
+			text:     "@" + strings.Join(manyUsernames, " @"),
+			expected: parsedMentions{usernames: manyUsernames[:common.MentionsPerMessageLimit]},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, parseMentions(tc.text))
+		})
+	}
+}
@@ -0,0 +1,1331 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DKhorkov/kfc/internal/common"
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/security"
+)
+
+func NewMessagesUseCases(
+	messagesService interfaces.MessagesService,
+	chatsService interfaces.ChatsService,
+	presenceService interfaces.PresenceService,
+	attachmentsService interfaces.AttachmentsService,
+	eventsPublisher interfaces.EventsPublisher,
+	linkPreviewFetcher interfaces.LinkPreviewFetcher,
+	securityConfig security.Config,
+	validationConfig config.ValidationConfig,
+	realtimeConfig config.RealtimeConfig,
+	attachmentsConfig config.AttachmentsConfig,
+	chatsConfig config.ChatsConfig,
+) *MessagesUseCases {
+	return &MessagesUseCases{
+		messagesService:    messagesService,
+		chatsService:       chatsService,
+		presenceService:    presenceService,
+		attachmentsService: attachmentsService,
+		eventsPublisher:    eventsPublisher,
+		linkPreviewFetcher: linkPreviewFetcher,
+		securityConfig:     securityConfig,
+		validationConfig:   validationConfig,
+		realtimeConfig:     realtimeConfig,
+		attachmentsConfig:  attachmentsConfig,
+		chatsConfig:        chatsConfig,
+	}
+}
+
+type MessagesUseCases struct {
+	messagesService    interfaces.MessagesService
+	chatsService       interfaces.ChatsService
+	presenceService    interfaces.PresenceService
+	attachmentsService interfaces.AttachmentsService
+	eventsPublisher    interfaces.EventsPublisher
+	linkPreviewFetcher interfaces.LinkPreviewFetcher
+	securityConfig     security.Config
+	validationConfig   config.ValidationConfig
+	realtimeConfig     config.RealtimeConfig
+	attachmentsConfig  config.AttachmentsConfig
+	chatsConfig        config.ChatsConfig
+}
+
+func (u *MessagesUseCases) CreateMessage(
+	ctx context.Context,
+	messageData domains.RawCreateMessageDTO,
+) (*domains.Message, error) {
+	// Message with attachments is allowed to have no text:
+	text := strings.TrimSpace(messageData.Text)
+	if (text == "" && len(messageData.AttachmentIDs) == 0) ||
+		utf8.RuneCountInString(text) > common.MessageTextMaxLength {
+		return nil, fmt.Errorf("%w: invalid message text", customerrors.ErrValidationFailed)
+	}
+
+	if len(messageData.AttachmentIDs) > u.attachmentsConfig.MaxPerMessage {
+		return nil, fmt.Errorf("%w: too many attachments", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(messageData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = u.chatsService.GetChatMember(ctx, messageData.ChatID, userID); err != nil {
+		return nil, err
+	}
+
+	createMessageData := domains.CreateMessageDTO{
+		ChatID:           messageData.ChatID,
+		AuthorID:         userID,
+		Type:             domains.MessageTypeRegular,
+		Text:             text,
+		ReplyToMessageID: messageData.ReplyToMessageID,
+		ThreadRootID:     messageData.ThreadRootID,
+		AttachmentIDs:    messageData.AttachmentIDs,
+		LinkURL:          parseLinkURL(text),
+	}
+
+	mentions, err := u.applyMentions(ctx, &createMessageData)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := u.messagesService.CreateMessage(ctx, createMessageData)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishCreatedMessage(ctx, message, mentions)
+
+	return message, nil
+}
+
+// applyMentions sets Users, who are mentioned in text of message, to message data.
+func (u *MessagesUseCases) applyMentions(
+	ctx context.Context,
+	messageData *domains.CreateMessageDTO,
+) (parsedMentions, error) {
+	mentions := parseMentions(messageData.Text)
+	messageData.MentionedUsernames = mentions.usernames
+
+	if mentions.all || mentions.here {
+		if err := u.resolveEveryoneMentions(ctx, messageData, mentions); err != nil {
+			return mentions, err
+		}
+	}
+
+	return mentions, nil
+}
+
+// publishCreatedMessage notifies members of chat about new message and mentioned Users about their mentions.
+func (u *MessagesUseCases) publishCreatedMessage(
+	ctx context.Context,
+	message *domains.Message,
+	mentions parsedMentions,
+) {
+	u.publishChatEvent(ctx, domains.EventTypeMessageCreated, message.ChatID, message)
+
+	if !mentions.isEmpty() {
+		u.publishMentions(ctx, *message)
+	}
+}
+
+// GetMentions provides feed of mentions of current User from newer to older.
+func (u *MessagesUseCases) GetMentions(
+	ctx context.Context,
+	accessToken string,
+	cursor domains.MentionsCursor,
+) (*domains.MentionsPage, error) {
+	switch {
+	case cursor.Limit == 0:
+		cursor.Limit = common.MessagesDefaultLimit
+	case cursor.Limit > common.MessagesMaxLimit:
+		cursor.Limit = common.MessagesMaxLimit
+	}
+
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.GetUserMentions(ctx, userID, cursor)
+}
+
+// resolveEveryoneMentions applies @all and @here mentions, which are allowed only in group chats and only to
+// members, who can mention everyone. Otherwise they are left as plain text.
+func (u *MessagesUseCases) resolveEveryoneMentions(
+	ctx context.Context,
+	messageData *domains.CreateMessageDTO,
+	mentions parsedMentions,
+) error {
+	chat, err := u.chatsService.GetChatByID(ctx, messageData.ChatID)
+	if err != nil {
+		return err
+	}
+
+	author := chat.GetMember(messageData.AuthorID)
+	if chat.Type != domains.ChatTypeGroup || author == nil || !author.Role.CanMentionEveryone() {
+		return nil
+	}
+
+	// Online members are a subset of all members, so presence is not checked, if everyone is mentioned:
+	if mentions.all {
+		messageData.MentionAll = true
+
+		return nil
+	}
+
+	membersIDs := make([]uint64, 0, len(chat.Members))
+	for _, member := range chat.Members {
+		if member.UserID != messageData.AuthorID {
+			membersIDs = append(membersIDs, member.UserID)
+		}
+	}
+
+	// Presence is best-effort, so members with unknown presence are considered offline:
+	presences, err := u.presenceService.GetPresences(ctx, membersIDs)
+	if err != nil {
+		return nil
+	}
+
+	for _, presence := range presences {
+		if presence.Online {
+			messageData.HereUserIDs = append(messageData.HereUserIDs, presence.UserID)
+		}
+	}
+
+	return nil
+}
+
+// publishMentions notifies mentioned Users about message. Message is already saved, so failure of mentions
+// retrieval only leads to absence of real-time notification.
+func (u *MessagesUseCases) publishMentions(ctx context.Context, message domains.Message) {
+	mentions, err := u.messagesService.GetMessageMentions(ctx, message.ID)
+	if err != nil {
+		return
+	}
+
+	for _, mention := range mentions {
+		mention.Message = &message
+
+		u.eventsPublisher.Publish(
+			ctx,
+			domains.Event{
+				Type:       domains.EventTypeMentionCreated,
+				ChatID:     message.ChatID,
+				Payload:    mention,
+				Recipients: []uint64{mention.UserID},
+			},
+		)
+	}
+}
+
+func (u *MessagesUseCases) GetChatMessages(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+	cursor domains.MessagesCursor,
+) (*domains.MessagesPage, error) {
+	cursor, err := normalizeMessagesCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = u.chatsService.GetChatMember(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.GetChatMessages(ctx, chatID, userID, cursor)
+}
+
+func (u *MessagesUseCases) GetThreadMessages(
+	ctx context.Context,
+	accessToken string,
+	chatID, threadRootID uint64,
+	cursor domains.MessagesCursor,
+) (*domains.ThreadPage, error) {
+	cursor, err := normalizeMessagesCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = u.chatsService.GetChatMember(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.GetThreadMessages(ctx, chatID, threadRootID, userID, cursor)
+}
+
+func (u *MessagesUseCases) UpdateMessage(
+	ctx context.Context,
+	messageData domains.RawUpdateMessageDTO,
+) (*domains.Message, error) {
+	text := strings.TrimSpace(messageData.Text)
+	if text == "" || utf8.RuneCountInString(text) > common.MessageTextMaxLength {
+		return nil, fmt.Errorf("%w: invalid message text", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(messageData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := u.messagesService.UpdateMessage(
+		ctx,
+		domains.UpdateMessageDTO{
+			ID:      messageData.MessageID,
+			ChatID:  messageData.ChatID,
+			ActorID: userID,
+			Text:    text,
+			LinkURL: parseLinkURL(text),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishChatEvent(ctx, domains.EventTypeMessageUpdated, message.ChatID, message)
+
+	return message, nil
+}
+
+func (u *MessagesUseCases) DeleteMessage(
+	ctx context.Context,
+	messageData domains.RawDeleteMessageDTO,
+) error {
+	userID, err := getUserIDFromAccessToken(messageData.AccessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	message, err := u.messagesService.DeleteMessage(
+		ctx,
+		domains.DeleteMessageDTO{
+			ID:          messageData.MessageID,
+			ChatID:      messageData.ChatID,
+			ActorID:     userID,
+			ForEveryone: messageData.ForEveryone,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	deletion := domains.MessageDeletion{
+		MessageID:   message.ID,
+		ChatID:      message.ChatID,
+		ForEveryone: messageData.ForEveryone,
+	}
+
+	if messageData.ForEveryone {
+		u.publishChatEvent(ctx, domains.EventTypeMessageDeleted, message.ChatID, deletion)
+
+		return nil
+	}
+
+	// Message, deleted only for User, should disappear only in other sessions of the User:
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:       domains.EventTypeMessageDeleted,
+			ChatID:     message.ChatID,
+			Payload:    deletion,
+			Recipients: []uint64{userID},
+		},
+	)
+
+	return nil
+}
+
+// ReadChatMessages marks messages of chat up to provided one as read by current User and notifies other
+// members about it.
+func (u *MessagesUseCases) ReadChatMessages(
+	ctx context.Context,
+	accessToken string,
+	chatID, messageID uint64,
+) (*domains.ChatMember, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := u.messagesService.ReadChatMessages(ctx, chatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read pointer stays on later message, if it was already read, so there is nothing to notify about:
+	if member.LastReadMessageID != nil && *member.LastReadMessageID == messageID {
+		u.publishChatEvent(
+			ctx,
+			domains.EventTypeMessagesRead,
+			chatID,
+			domains.ReadReceipt{
+				ChatID:            chatID,
+				UserID:            userID,
+				LastReadMessageID: messageID,
+			},
+		)
+	}
+
+	return member, nil
+}
+
+// ReadThreadMessages marks replies in thread up to provided one as read by current User. Thread read state is
+// personal, so only other sessions of the User are notified about it.
+func (u *MessagesUseCases) ReadThreadMessages(
+	ctx context.Context,
+	accessToken string,
+	chatID, threadRootID, messageID uint64,
+) (*domains.ThreadParticipant, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := u.messagesService.ReadThreadMessages(ctx, chatID, threadRootID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if participant.LastReadMessageID != nil && *participant.LastReadMessageID == messageID {
+		u.eventsPublisher.Publish(
+			ctx,
+			domains.Event{
+				Type:       domains.EventTypeThreadRead,
+				ChatID:     chatID,
+				Payload:    participant,
+				Recipients: []uint64{userID},
+			},
+		)
+	}
+
+	return participant, nil
+}
+
+func (u *MessagesUseCases) AddMessageReaction(
+	ctx context.Context,
+	reactionData domains.RawMessageReactionDTO,
+) error {
+	return u.changeMessageReaction(ctx, reactionData, true)
+}
+
+func (u *MessagesUseCases) RemoveMessageReaction(
+	ctx context.Context,
+	reactionData domains.RawMessageReactionDTO,
+) error {
+	return u.changeMessageReaction(ctx, reactionData, false)
+}
+
+// changeMessageReaction adds or removes reaction of current User and notifies members of chat, if reaction
+// was actually changed.
+func (u *MessagesUseCases) changeMessageReaction(
+	ctx context.Context,
+	reactionData domains.RawMessageReactionDTO,
+	add bool,
+) error {
+	if !slices.Contains(u.validationConfig.AllowedReactions, reactionData.Emoji) {
+		return fmt.Errorf("%w: reaction is not allowed", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(reactionData.AccessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	reaction := domains.MessageReactionDTO{
+		ChatID:    reactionData.ChatID,
+		MessageID: reactionData.MessageID,
+		UserID:    userID,
+		Emoji:     reactionData.Emoji,
+	}
+
+	var (
+		changed   bool
+		eventType domains.EventType
+	)
+
+	if add {
+		changed, err = u.messagesService.AddMessageReaction(ctx, reaction)
+		eventType = domains.EventTypeReactionAdded
+	} else {
+		changed, err = u.messagesService.RemoveMessageReaction(ctx, reaction)
+		eventType = domains.EventTypeReactionRemoved
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		u.publishChatEvent(
+			ctx,
+			eventType,
+			reaction.ChatID,
+			domains.MessageReactionChange{
+				ChatID:    reaction.ChatID,
+				MessageID: reaction.MessageID,
+				UserID:    reaction.UserID,
+				Emoji:     reaction.Emoji,
+			},
+		)
+	}
+
+	return nil
+}
+
+// SendTypingIndicator notifies other members of chat, that current User is typing message. Indicator is
+// ephemeral event, so it is not stored anywhere and expires by itself. In channels only members, who can post,
+// can type.
+func (u *MessagesUseCases) SendTypingIndicator(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	chat, err := u.chatsService.GetChatByID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	member, err := u.chatsService.GetChatMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+
+	if chat.Type == domains.ChatTypeChannel && !member.Role.CanPostToChannel() {
+		return customerrors.ErrChatPermissionDenied
+	}
+
+	membersIDs, err := u.chatsService.GetChatMembersIDs(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]uint64, 0, len(membersIDs))
+	for _, memberID := range membersIDs {
+		if memberID != userID {
+			recipients = append(recipients, memberID)
+		}
+	}
+
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:   domains.EventTypeTyping,
+			ChatID: chatID,
+			Payload: domains.TypingIndicator{
+				ChatID:    chatID,
+				UserID:    userID,
+				ExpiresAt: time.Now().Add(u.realtimeConfig.TypingIndicatorTTL),
+			},
+			Recipients: recipients,
+			Ephemeral:  true,
+		},
+	)
+
+	return nil
+}
+
+// SearchMessages performs full-text search of messages in chats of current User. If chat is provided, User
+// should be its member.
+func (u *MessagesUseCases) SearchMessages(
+	ctx context.Context,
+	searchData domains.RawSearchMessagesDTO,
+) (*domains.MessagesSearchPage, error) {
+	query := strings.TrimSpace(searchData.Query)
+	if query == "" || utf8.RuneCountInString(query) > common.SearchQueryMaxLength {
+		return nil, fmt.Errorf("%w: invalid search query", customerrors.ErrValidationFailed)
+	}
+
+	if searchData.From != nil && searchData.To != nil && !searchData.From.Before(*searchData.To) {
+		return nil, fmt.Errorf("%w: invalid date range", customerrors.ErrValidationFailed)
+	}
+
+	switch {
+	case searchData.Limit == 0:
+		searchData.Limit = common.MessagesDefaultLimit
+	case searchData.Limit > common.MessagesMaxLimit:
+		searchData.Limit = common.MessagesMaxLimit
+	}
+
+	userID, err := getUserIDFromAccessToken(searchData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if searchData.ChatID != nil {
+		if _, err = u.chatsService.GetChatMember(ctx, *searchData.ChatID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.messagesService.SearchMessages(
+		ctx,
+		domains.SearchMessagesDTO{
+			UserID:   userID,
+			Query:    query,
+			ChatID:   searchData.ChatID,
+			AuthorID: searchData.AuthorID,
+			From:     searchData.From,
+			To:       searchData.To,
+			Limit:    searchData.Limit,
+			Offset:   searchData.Offset,
+		},
+	)
+}
+
+// ForwardMessages forwards messages of one chat to another on behalf of current User and notifies members of
+// target chat about copies. Source of copies is provided only to members of source chat.
+func (u *MessagesUseCases) ForwardMessages(
+	ctx context.Context,
+	forwardData domains.RawForwardMessagesDTO,
+) ([]domains.Message, error) {
+	var messageIDs []uint64
+
+	for _, messageID := range forwardData.MessageIDs {
+		if !slices.Contains(messageIDs, messageID) {
+			messageIDs = append(messageIDs, messageID)
+		}
+	}
+
+	if len(messageIDs) == 0 || len(messageIDs) > common.ForwardedMessagesLimit {
+		return nil, fmt.Errorf(
+			"%w: from 1 to %d messages can be forwarded at once",
+			customerrors.ErrValidationFailed,
+			common.ForwardedMessagesLimit,
+		)
+	}
+
+	userID, err := getUserIDFromAccessToken(forwardData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := u.messagesService.ForwardMessages(
+		ctx,
+		domains.ForwardMessagesDTO{
+			ChatID:     forwardData.ChatID,
+			FromChatID: forwardData.FromChatID,
+			MessageIDs: messageIDs,
+			ActorID:    userID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishForwardedMessages(ctx, forwardData.ChatID, forwardData.FromChatID, messages)
+
+	return messages, nil
+}
+
+// publishForwardedMessages notifies members of chat about forwarded messages. Members, who are not members of
+// source chat, get messages without chat and message of originals.
+func (u *MessagesUseCases) publishForwardedMessages(
+	ctx context.Context,
+	chatID, fromChatID uint64,
+	messages []domains.Message,
+) {
+	membersIDs, err := u.chatsService.GetChatMembersIDs(ctx, chatID)
+	if err != nil {
+		return
+	}
+
+	fromChatMembersIDs, err := u.chatsService.GetChatMembersIDs(ctx, fromChatID)
+	if err != nil {
+		return
+	}
+
+	fromChatMembers := make(map[uint64]struct{}, len(fromChatMembersIDs))
+	for _, memberID := range fromChatMembersIDs {
+		fromChatMembers[memberID] = struct{}{}
+	}
+
+	var sourceMembers, otherMembers []uint64
+
+	for _, memberID := range membersIDs {
+		if _, ok := fromChatMembers[memberID]; ok {
+			sourceMembers = append(sourceMembers, memberID)
+		} else {
+			otherMembers = append(otherMembers, memberID)
+		}
+	}
+
+	for _, message := range messages {
+		if len(sourceMembers) > 0 {
+			u.eventsPublisher.Publish(
+				ctx,
+				domains.Event{
+					Type:       domains.EventTypeMessageCreated,
+					ChatID:     chatID,
+					Payload:    message,
+					Recipients: sourceMembers,
+				},
+			)
+		}
+
+		if len(otherMembers) > 0 {
+			message.ForwardedFromChatID = nil
+			message.ForwardedFromMessageID = nil
+
+			u.eventsPublisher.Publish(
+				ctx,
+				domains.Event{
+					Type:       domains.EventTypeMessageCreated,
+					ChatID:     chatID,
+					Payload:    message,
+					Recipients: otherMembers,
+				},
+			)
+		}
+	}
+}
+
+// PinMessage pins message in chat on behalf of current User and notifies members of chat with system
+// message about it.
+func (u *MessagesUseCases) PinMessage(
+	ctx context.Context,
+	accessToken string,
+	chatID, messageID uint64,
+) (*domains.Pin, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, systemMessage, err := u.messagesService.PinMessage(
+		ctx,
+		domains.PinMessageDTO{
+			ChatID:    chatID,
+			MessageID: messageID,
+			ActorID:   userID,
+			PinsLimit: u.chatsConfig.PinsLimit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishChatEvent(ctx, domains.EventTypeMessageCreated, systemMessage.ChatID, systemMessage)
+
+	return pin, nil
+}
+
+// UnpinMessage unpins message in chat on behalf of current User and notifies members of chat with system
+// message about it.
+func (u *MessagesUseCases) UnpinMessage(
+	ctx context.Context,
+	accessToken string,
+	chatID, messageID uint64,
+) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	systemMessage, err := u.messagesService.UnpinMessage(
+		ctx,
+		domains.UnpinMessageDTO{
+			ChatID:    chatID,
+			MessageID: messageID,
+			ActorID:   userID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	u.publishChatEvent(ctx, domains.EventTypeMessageCreated, systemMessage.ChatID, systemMessage)
+
+	return nil
+}
+
+func (u *MessagesUseCases) GetChatPins(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) ([]domains.Pin, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = u.chatsService.GetChatMember(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.GetChatPins(ctx, chatID, userID)
+}
+
+// ScheduleMessage schedules message of current User to be sent to chat history at provided time.
+func (u *MessagesUseCases) ScheduleMessage(
+	ctx context.Context,
+	messageData domains.RawScheduleMessageDTO,
+) (*domains.ScheduledMessage, error) {
+	text, sendAt, err := u.validateScheduledMessage(messageData.Text, messageData.SendAt)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := getUserIDFromAccessToken(messageData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.ScheduleMessage(
+		ctx,
+		domains.ScheduleMessageDTO{
+			ChatID:           messageData.ChatID,
+			AuthorID:         userID,
+			Text:             text,
+			ReplyToMessageID: messageData.ReplyToMessageID,
+			SendAt:           sendAt,
+			Limit:            u.chatsConfig.ScheduledMessages.PerChatLimit,
+		},
+	)
+}
+
+// GetScheduledMessages provides scheduled messages of current User in chat in order of sending.
+func (u *MessagesUseCases) GetScheduledMessages(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+) ([]domains.ScheduledMessage, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.GetUserScheduledMessages(ctx, chatID, userID)
+}
+
+func (u *MessagesUseCases) UpdateScheduledMessage(
+	ctx context.Context,
+	messageData domains.RawUpdateScheduledMessageDTO,
+) (*domains.ScheduledMessage, error) {
+	text, sendAt, err := u.validateScheduledMessage(messageData.Text, messageData.SendAt)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := getUserIDFromAccessToken(messageData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.messagesService.UpdateScheduledMessage(
+		ctx,
+		domains.UpdateScheduledMessageDTO{
+			ID:      messageData.ScheduledMessageID,
+			ChatID:  messageData.ChatID,
+			ActorID: userID,
+			Text:    text,
+			SendAt:  sendAt,
+		},
+	)
+}
+
+func (u *MessagesUseCases) CancelScheduledMessage(
+	ctx context.Context,
+	accessToken string,
+	chatID, scheduledMessageID uint64,
+) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	return u.messagesService.DeleteScheduledMessage(ctx, chatID, scheduledMessageID, userID)
+}
+
+// SendScheduledMessages sends batch of due scheduled messages and returns number of messages, which were
+// sent or marked as failed. Messages, which are being sent by other instances of application, are skipped.
+func (u *MessagesUseCases) SendScheduledMessages(ctx context.Context) (int, error) {
+	scheduledMessages, err := u.messagesService.GetDueScheduledMessages(
+		ctx,
+		time.Now().UTC(),
+		uint64(u.chatsConfig.ScheduledMessages.BatchSize),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Failure of one message should not prevent sending of others:
+	var (
+		processed int
+		errs      []error
+	)
+
+	for _, scheduledMessage := range scheduledMessages {
+		sent, err := u.sendScheduledMessage(ctx, scheduledMessage)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scheduled message %d: %w", scheduledMessage.ID, err))
+
+			continue
+		}
+
+		if sent {
+			processed++
+		}
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// sendScheduledMessage sends scheduled message the same way as message, which is sent by its author directly,
+// and reports, whether message was processed. Message, which author is no longer able to send, is marked as
+// failed.
+func (u *MessagesUseCases) sendScheduledMessage(
+	ctx context.Context,
+	scheduledMessage domains.ScheduledMessage,
+) (bool, error) {
+	messageData := domains.CreateMessageDTO{
+		ChatID:           scheduledMessage.ChatID,
+		AuthorID:         scheduledMessage.AuthorID,
+		Type:             domains.MessageTypeRegular,
+		Text:             scheduledMessage.Text,
+		ReplyToMessageID: scheduledMessage.ReplyToMessageID,
+		LinkURL:          parseLinkURL(scheduledMessage.Text),
+	}
+
+	var message *domains.Message
+
+	mentions, err := u.applyMentions(ctx, &messageData)
+	if err == nil {
+		message, err = u.messagesService.SendScheduledMessage(ctx, scheduledMessage, messageData)
+	}
+
+	switch {
+	case errors.Is(err, customerrors.ErrScheduledMessageNotFound):
+		// Message is being sent by another instance, or it was changed or cancelled by author:
+		return false, nil
+	case errors.Is(err, customerrors.ErrChatNotFound),
+		errors.Is(err, customerrors.ErrNotChatMember),
+		errors.Is(err, customerrors.ErrChatPermissionDenied),
+		errors.Is(err, customerrors.ErrInvalidReply):
+		if err = u.messagesService.FailScheduledMessage(ctx, scheduledMessage); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+
+	u.publishCreatedMessage(ctx, message, mentions)
+
+	return true, nil
+}
+
+// validateScheduledMessage checks text and sending time of scheduled message and provides them in normalized
+// form. Sending time is stored in UTC.
+func (u *MessagesUseCases) validateScheduledMessage(text string, sendAt time.Time) (string, time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > common.MessageTextMaxLength {
+		return "", time.Time{}, fmt.Errorf("%w: invalid message text", customerrors.ErrValidationFailed)
+	}
+
+	now := time.Now().UTC()
+
+	sendAt = sendAt.UTC()
+	if !sendAt.After(now) || sendAt.After(now.Add(u.chatsConfig.ScheduledMessages.MaxDelay)) {
+		return "", time.Time{}, fmt.Errorf("%w: invalid sending time", customerrors.ErrValidationFailed)
+	}
+
+	return text, sendAt, nil
+}
+
+// SetChatMessageTTL changes lifetime of chat messages on behalf of current User and notifies members of chat
+// with system message about it.
+func (u *MessagesUseCases) SetChatMessageTTL(
+	ctx context.Context,
+	accessToken string,
+	chatID uint64,
+	messageTTL domains.MessageTTL,
+) error {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return err
+	}
+
+	if !messageTTL.IsValid() {
+		return fmt.Errorf("%w: unsupported messages lifetime", customerrors.ErrValidationFailed)
+	}
+
+	systemMessage, err := u.messagesService.SetChatMessageTTL(
+		ctx,
+		domains.SetChatMessageTTLDTO{
+			ChatID:     chatID,
+			ActorID:    userID,
+			MessageTTL: messageTTL,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Lifetime was not changed:
+	if systemMessage == nil {
+		return nil
+	}
+
+	u.publishChatEvent(ctx, domains.EventTypeMessageCreated, systemMessage.ChatID, systemMessage)
+
+	return nil
+}
+
+// PurgeExpiredMessages deletes batch of expired messages together with content of their attachments and
+// notifies members of chats about deletion.
+func (u *MessagesUseCases) PurgeExpiredMessages(ctx context.Context) (int, error) {
+	purge, err := u.messagesService.PurgeExpiredMessages(
+		ctx,
+		time.Now().UTC(),
+		uint64(u.chatsConfig.MessageRetention.BatchSize),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if purge == nil {
+		return 0, nil
+	}
+
+	// Messages are already deleted, so members are notified even if content could not be deleted:
+	err = u.attachmentsService.DeleteAttachmentsContent(ctx, purge.StorageKeys)
+
+	deletionsByChat := make(map[uint64][]any)
+	for _, message := range purge.Messages {
+		deletionsByChat[message.ChatID] = append(
+			deletionsByChat[message.ChatID],
+			domains.MessageDeletion{
+				MessageID:   message.ID,
+				ChatID:      message.ChatID,
+				ForEveryone: true,
+				Expired:     true,
+			},
+		)
+	}
+
+	for chatID, deletions := range deletionsByChat {
+		u.publishChatEvent(ctx, domains.EventTypeMessageDeleted, chatID, deletions...)
+	}
+
+	return len(purge.Messages), err
+}
+
+// CreatePoll sends poll of current User to group chat. Question of poll is used as text of its message.
+func (u *MessagesUseCases) CreatePoll(
+	ctx context.Context,
+	pollData domains.RawCreatePollDTO,
+) (*domains.Message, error) {
+	poll, err := validatePoll(pollData)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := getUserIDFromAccessToken(pollData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := u.messagesService.CreateMessage(
+		ctx,
+		domains.CreateMessageDTO{
+			ChatID:   pollData.ChatID,
+			AuthorID: userID,
+			Type:     domains.MessageTypePoll,
+			Text:     poll.Question,
+			Poll:     poll,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishChatEvent(ctx, domains.EventTypeMessageCreated, message.ChatID, message)
+
+	return message, nil
+}
+
+// VotePoll saves votes of current User in poll and notifies members of chat about new results of the poll.
+func (u *MessagesUseCases) VotePoll(
+	ctx context.Context,
+	voteData domains.RawPollVoteDTO,
+) (*domains.Poll, error) {
+	if len(voteData.OptionIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one option must be chosen", customerrors.ErrValidationFailed)
+	}
+
+	optionIDs := slices.Clone(voteData.OptionIDs)
+	slices.Sort(optionIDs)
+
+	if len(slices.Compact(optionIDs)) != len(voteData.OptionIDs) {
+		return nil, fmt.Errorf("%w: options must not be repeated", customerrors.ErrValidationFailed)
+	}
+
+	userID, err := getUserIDFromAccessToken(voteData.AccessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := u.messagesService.VotePoll(
+		ctx,
+		domains.PollVoteDTO{
+			ChatID:    voteData.ChatID,
+			MessageID: voteData.MessageID,
+			UserID:    userID,
+			OptionIDs: voteData.OptionIDs,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publishPollVote(ctx, voteData.ChatID, userID, poll)
+
+	return poll, nil
+}
+
+// RetractPollVote deletes votes of current User in poll and, if User has voted, notifies members of chat
+// about new results of the poll.
+func (u *MessagesUseCases) RetractPollVote(
+	ctx context.Context,
+	accessToken string,
+	chatID, messageID uint64,
+) (*domains.Poll, error) {
+	userID, err := getUserIDFromAccessToken(accessToken, u.securityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, retracted, err := u.messagesService.RetractPollVote(
+		ctx,
+		domains.PollVoteDTO{
+			ChatID:    chatID,
+			MessageID: messageID,
+			UserID:    userID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if retracted {
+		u.publishPollVote(ctx, chatID, userID, poll)
+	}
+
+	return poll, nil
+}
+
+// publishPollVote notifies sessions of voter about his choice and all members of chat about results of poll.
+// Results are published without choice of voter, so votes in anonymous poll are not disclosed.
+func (u *MessagesUseCases) publishPollVote(ctx context.Context, chatID, userID uint64, poll *domains.Poll) {
+	u.eventsPublisher.Publish(
+		ctx,
+		domains.Event{
+			Type:   domains.EventTypePollVoted,
+			ChatID: chatID,
+			Payload: domains.PollVoteChange{
+				ChatID:    chatID,
+				MessageID: poll.MessageID,
+				PollID:    poll.ID,
+				OptionIDs: poll.VotedOptionIDs(),
+			},
+			Recipients: []uint64{userID},
+		},
+	)
+
+	u.publishChatEvent(ctx, domains.EventTypePollUpdated, chatID, poll.Results())
+}
+
+// ProcessLinkPreviews fetches metadata for batch of pending links and notifies members of chats about messages,
+// which previews became ready. Returns number of processed links, so caller could decide whether to continue
+// immediately.
+func (u *MessagesUseCases) ProcessLinkPreviews(ctx context.Context) (int, error) {
+	previews, err := u.messagesService.ClaimLinkPreviews(
+		ctx,
+		uint64(u.chatsConfig.LinkPreviews.BatchSize),
+		u.chatsConfig.LinkPreviews.ClaimTimeout,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Failure of one link should not prevent processing of others:
+	var errs []error
+
+	for _, preview := range previews {
+		messages, err := u.fetchLinkPreview(ctx, preview)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("link preview %d: %w", preview.ID, err))
+
+			continue
+		}
+
+		for _, message := range messages {
+			u.publishChatEvent(
+				ctx,
+				domains.EventTypeLinkPreviewReady,
+				message.ChatID,
+				domains.MessageLinkPreview{
+					MessageID:   message.ID,
+					LinkPreview: *message.LinkPreview,
+				},
+			)
+		}
+	}
+
+	return len(previews), errors.Join(errs...)
+}
+
+// fetchLinkPreview fetches metadata of claimed link and saves it. Link, which page is unavailable or forbidden,
+// is marked as failed and is not fetched again.
+func (u *MessagesUseCases) fetchLinkPreview(
+	ctx context.Context,
+	preview domains.LinkPreview,
+) ([]domains.Message, error) {
+	previewData := domains.UpdateLinkPreviewDTO{
+		LinkPreviewID: preview.ID,
+		Status:        domains.LinkPreviewStatusFailed,
+	}
+
+	metadata, err := u.linkPreviewFetcher.Fetch(ctx, preview.URL)
+
+	switch {
+	case ctx.Err() != nil:
+		// Fetching is interrupted by stop of worker, so link is fetched again after claim timeout:
+		return nil, ctx.Err()
+	case errors.Is(err, customerrors.ErrLinkPreviewUnavailable),
+		errors.Is(err, customerrors.ErrForbiddenLinkAddress):
+		// Link can not be previewed, so it is saved as failed to not fetch it again:
+	case err != nil:
+		return nil, err
+	default:
+		previewData.Status = domains.LinkPreviewStatusReady
+		previewData.Title = &metadata.Title
+		previewData.Description = metadata.Description
+		previewData.SiteName = metadata.SiteName
+		previewData.ImageURL = metadata.ImageURL
+	}
+
+	return u.messagesService.SaveLinkPreview(ctx, previewData)
+}
+
+// publishChatEvent publishes event with each of provided payloads to all members of chat. Message is already
+// saved, so failure of members retrieval only leads to absence of real-time notification.
+func (u *MessagesUseCases) publishChatEvent(
+	ctx context.Context,
+	eventType domains.EventType,
+	chatID uint64,
+	payloads ...any,
+) {
+	recipients, err := u.chatsService.GetChatMembersIDs(ctx, chatID)
+	if err != nil {
+		return
+	}
+
+	for _, payload := range payloads {
+		u.eventsPublisher.Publish(
+			ctx,
+			domains.Event{
+				Type:       eventType,
+				ChatID:     chatID,
+				Payload:    payload,
+				Recipients: recipients,
+			},
+		)
+	}
+}
+
+// normalizeMessagesCursor checks provided cursor and limits number of requested messages.
+func normalizeMessagesCursor(cursor domains.MessagesCursor) (domains.MessagesCursor, error) {
+	if cursor.Before != nil && cursor.After != nil {
+		return cursor, fmt.Errorf(
+			"%w: only one of before and after cursors can be provided",
+			customerrors.ErrValidationFailed,
+		)
+	}
+
+	switch {
+	case cursor.Limit == 0:
+		cursor.Limit = common.MessagesDefaultLimit
+	case cursor.Limit > common.MessagesMaxLimit:
+		cursor.Limit = common.MessagesMaxLimit
+	}
+
+	return cursor, nil
+}
+
+// validatePoll checks question, options and closing time of poll and provides them in normalized form.
+// Closing time is stored in UTC.
+func validatePoll(pollData domains.RawCreatePollDTO) (*domains.CreatePollDTO, error) {
+	question := strings.TrimSpace(pollData.Question)
+	if question == "" || utf8.RuneCountInString(question) > common.PollQuestionMaxLength {
+		return nil, fmt.Errorf("%w: invalid poll question", customerrors.ErrValidationFailed)
+	}
+
+	if len(pollData.Options) < common.PollOptionsMinCount || len(pollData.Options) > common.PollOptionsMaxCount {
+		return nil, fmt.Errorf(
+			"%w: poll must have from %d to %d options",
+			customerrors.ErrValidationFailed,
+			common.PollOptionsMinCount,
+			common.PollOptionsMaxCount,
+		)
+	}
+
+	options := make([]string, len(pollData.Options))
+	for i, option := range pollData.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || utf8.RuneCountInString(option) > common.PollOptionMaxLength {
+			return nil, fmt.Errorf("%w: invalid poll option", customerrors.ErrValidationFailed)
+		}
+
+		if slices.Contains(options[:i], option) {
+			return nil, fmt.Errorf("%w: poll options must be unique", customerrors.ErrValidationFailed)
+		}
+
+		options[i] = option
+	}
+
+	poll := &domains.CreatePollDTO{
+		Question:       question,
+		Options:        options,
+		MultipleChoice: pollData.MultipleChoice,
+		Anonymous:      pollData.Anonymous,
+	}
+
+	if pollData.ClosesAt != nil {
+		if !pollData.ClosesAt.After(time.Now()) {
+			return nil, fmt.Errorf("%w: poll closing time must be in the future", customerrors.ErrValidationFailed)
+		}
+
+		closesAt := pollData.ClosesAt.UTC()
+		poll.ClosesAt = &closesAt
+	}
+
+	return poll, nil
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	customerrors "github.com/DKhorkov/kfc/internal/errors"
+	mockrealtime "github.com/DKhorkov/kfc/mocks/realtime"
+	mockservices "github.com/DKhorkov/kfc/mocks/services"
+	"github.com/DKhorkov/libs/security"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMessagesUseCases_SendTypingIndicator(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chatID uint64 = 1
+		userID uint64 = 10
+	)
+
+	securityConfig := security.Config{
+		JWT: security.JWTConfig{
+			SecretKey: "secret",
+			Algorithm: "HS256",
+		},
+	}
+
+	accessToken, err := security.GenerateJWT(userID, securityConfig.JWT.SecretKey, time.Minute, "HS256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		chatType      domains.ChatType
+		role          domains.ChatMemberRole
+		expectedError error
+	}{
+		{
+			name:     "member of group chat",
+			chatType: domains.ChatTypeGroup,
+			role:     domains.ChatMemberRoleMember,
+		},
+		{
+			name:     "admin of channel",
+			chatType: domains.ChatTypeChannel,
+			role:     domains.ChatMemberRoleAdmin,
+		},
+		{
+			name:          "subscriber of channel",
+			chatType:      domains.ChatTypeChannel,
+			role:          domains.ChatMemberRoleMember,
+			expectedError: customerrors.ErrChatPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			chatsService := mockservices.NewMockChatsService(ctrl)
+			eventsPublisher := mockrealtime.NewMockEventsPublisher(ctrl)
+
+			chatsService.EXPECT().
+				GetChatByID(gomock.Any(), chatID).
+				Return(&domains.Chat{ID: chatID, Type: tc.chatType}, nil)
+			chatsService.EXPECT().
+				GetChatMember(gomock.Any(), chatID, userID).
+				Return(&domains.ChatMember{ChatID: chatID, UserID: userID, Role: tc.role}, nil)
+
+			if tc.expectedError == nil {
+				chatsService.EXPECT().
+					GetChatMembersIDs(gomock.Any(), chatID).
+					Return([]uint64{userID, 11}, nil)
+				eventsPublisher.EXPECT().
+					Publish(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, event domains.Event) {
+						require.Equal(t, domains.EventTypeTyping, event.Type)
+						require.Equal(t, []uint64{11}, event.Recipients)
+					})
+			}
+
+			useCases := NewMessagesUseCases(
+				nil,
+				chatsService,
+				nil,
+				nil,
+				eventsPublisher,
+				nil,
+				securityConfig,
+				config.ValidationConfig{},
+				config.RealtimeConfig{TypingIndicatorTTL: time.Second},
+				config.AttachmentsConfig{},
+				config.ChatsConfig{},
+			)
+
+			err := useCases.SendTypingIndicator(context.Background(), accessToken, chatID)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
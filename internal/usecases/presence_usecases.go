@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/DKhorkov/kfc/internal/config"
+	"github.com/DKhorkov/kfc/internal/domains"
+	"github.com/DKhorkov/kfc/internal/interfaces"
+)
+
+func NewPresenceUseCases(
+	presenceService interfaces.PresenceService,
+	chatsService interfaces.ChatsService,
+	eventsPublisher interfaces.EventsPublisher,
+	realtimeConfig config.RealtimeConfig,
+) *PresenceUseCases {
+	return &PresenceUseCases{
+		presenceService: presenceService,
+		chatsService:    chatsService,
+		eventsPublisher: eventsPublisher,
+		realtimeConfig:  realtimeConfig,
+	}
+}
+
+type PresenceUseCases struct {
+	presenceService interfaces.PresenceService
+	chatsService    interfaces.ChatsService
+	eventsPublisher interfaces.EventsPublisher
+	realtimeConfig  config.RealtimeConfig
+}
+
+func (u *PresenceUseCases) ConnectUser(ctx context.Context, userID uint64) error {
+	becameOnline, err := u.presenceService.AddConnection(ctx, userID, u.realtimeConfig.PresenceTTL)
+	if err != nil {
+		return err
+	}
+
+	if becameOnline {
+		u.publishPresence(ctx, userID)
+	}
+
+	return nil
+}
+
+// KeepUserOnline prolongs presence of User, which should be called periodically during real-time
+// connection, because presence expires after PresenceTTL.
+func (u *PresenceUseCases) KeepUserOnline(ctx context.Context, userID uint64) error {
+	return u.presenceService.ProlongConnections(ctx, userID, u.realtimeConfig.PresenceTTL)
+}
+
+func (u *PresenceUseCases) DisconnectUser(ctx context.Context, userID uint64) error {
+	becameOffline, err := u.presenceService.RemoveConnection(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if becameOffline {
+		u.publishPresence(ctx, userID)
+	}
+
+	return nil
+}
+
+// publishPresence notifies Users, who share chats with provided User, about his current presence. Channels are
+// provided without subscribers, so presence is not shared with other subscribers of channel.
+// Presence is already changed, so failure of notification only leads to absence of real-time event.
+func (u *PresenceUseCases) publishPresence(ctx context.Context, userID uint64) {
+	presence, err := u.presenceService.GetPresence(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	chats, err := u.chatsService.GetUserChats(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	recipients := make(map[uint64]struct{})
+	for _, chat := range chats {
+		for _, member := range chat.Members {
+			if member.UserID != userID {
+				recipients[member.UserID] = struct{}{}
+			}
+		}
+	}
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	event := domains.Event{
+		Type:       domains.EventTypePresenceChanged,
+		Payload:    presence,
+		Recipients: make([]uint64, 0, len(recipients)),
+		Ephemeral:  true, // Actual presence is provided with Users, so there is no need to replay it
+	}
+
+	for recipient := range recipients {
+		event.Recipients = append(event.Recipients, recipient)
+	}
+
+	u.eventsPublisher.Publish(ctx, event)
+}
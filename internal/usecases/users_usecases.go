@@ -14,11 +14,13 @@ import (
 
 func NewUsersUseCases(
 	usersService interfaces.UsersService,
+	presenceService interfaces.PresenceService,
 	securityConfig security.Config,
 	validationConfig config.ValidationConfig,
 ) *UsersUseCases {
 	return &UsersUseCases{
 		usersService:     usersService,
+		presenceService:  presenceService,
 		securityConfig:   securityConfig,
 		validationConfig: validationConfig,
 	}
@@ -26,6 +28,7 @@ func NewUsersUseCases(
 
 type UsersUseCases struct {
 	usersService     interfaces.UsersService
+	presenceService  interfaces.PresenceService
 	securityConfig   security.Config
 	validationConfig config.ValidationConfig
 }
@@ -34,18 +37,38 @@ func (u *UsersUseCases) GetUsers(
 	ctx context.Context,
 	filters *domains.UsersFilters,
 	pagination *domains.Pagination,
-) ([]domains.User, error) {
-	return u.usersService.GetUsers(ctx, filters, pagination)
+) ([]domains.UserWithPresence, error) {
+	users, err := u.usersService.GetUsers(ctx, filters, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domains.UserWithPresence, len(users))
+	for i, user := range users {
+		userWithPresence, err := u.attachPresence(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = *userWithPresence
+	}
+
+	return result, nil
 }
 
-func (u *UsersUseCases) GetUserByID(ctx context.Context, id uint64) (*domains.User, error) {
-	return u.usersService.GetUserByID(ctx, id)
+func (u *UsersUseCases) GetUserByID(ctx context.Context, id uint64) (*domains.UserWithPresence, error) {
+	user, err := u.usersService.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachPresence(ctx, *user)
 }
 
 func (u *UsersUseCases) UpdateUser(
 	ctx context.Context,
 	userData domains.RawUpdateUserDTO,
-) (*domains.User, error) {
+) (*domains.UserWithPresence, error) {
 	if !validation.ValidateValueByRules(userData.Username, u.validationConfig.UsernameRegExps) {
 		return nil, fmt.Errorf("%w: invalid username", customerrors.ErrValidationFailed)
 	}
@@ -70,16 +93,21 @@ func (u *UsersUseCases) UpdateUser(
 		return nil, err
 	}
 
-	return u.usersService.UpdateUser(
+	user, err = u.usersService.UpdateUser(
 		ctx,
 		domains.UpdateUserDTO{
 			ID:       user.ID,
 			Username: userData.Username,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.attachPresence(ctx, *user)
 }
 
-func (u *UsersUseCases) GetMe(ctx context.Context, accessToken string) (*domains.User, error) {
+func (u *UsersUseCases) GetMe(ctx context.Context, accessToken string) (*domains.UserWithPresence, error) {
 	accessTokenPayload, err := security.ParseJWT(accessToken, u.securityConfig.JWT.SecretKey)
 	if err != nil {
 		return nil, customerrors.ErrInvalidJWT
@@ -92,5 +120,17 @@ func (u *UsersUseCases) GetMe(ctx context.Context, accessToken string) (*domains
 
 	userID := uint64(floatUserID)
 
-	return u.usersService.GetUserByID(ctx, userID)
+	return u.GetUserByID(ctx, userID)
+}
+
+func (u *UsersUseCases) attachPresence(
+	ctx context.Context,
+	user domains.User,
+) (*domains.UserWithPresence, error) {
+	presence, err := u.presenceService.GetPresence(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domains.UserWithPresence{User: user, Presence: *presence}, nil
 }
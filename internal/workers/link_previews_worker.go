@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/logging"
+)
+
+func NewLinkPreviewsWorker(
+	messagesUseCases interfaces.MessagesUseCases,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) *LinkPreviewsWorker {
+	return &LinkPreviewsWorker{
+		messagesUseCases: messagesUseCases,
+		pollInterval:     pollInterval,
+		logger:           logger,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+// LinkPreviewsWorker fetches metadata of links, which messages contain, in background, so sending of message
+// does not wait for third-party servers. Links are polled from database, so several instances of application
+// can process them concurrently.
+type LinkPreviewsWorker struct {
+	messagesUseCases interfaces.MessagesUseCases
+	pollInterval     time.Duration
+	logger           logging.Logger
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+// Run processes links until worker is stopped. Next batch is taken immediately while there are pending
+// links, otherwise worker waits for poll interval.
+func (w *LinkPreviewsWorker) Run() {
+	defer close(w.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		processed, err := w.messagesUseCases.ProcessLinkPreviews(ctx)
+		if err != nil && ctx.Err() == nil {
+			logging.LogErrorContext(ctx, w.logger, "Failed to process link previews", err)
+		}
+
+		if processed > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Stop interrupts fetching of current batch and waits for worker to finish. Interrupted links are taken
+// again after claim timeout.
+func (w *LinkPreviewsWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
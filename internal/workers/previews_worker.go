@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/logging"
+)
+
+func NewPreviewsWorker(
+	attachmentsUseCases interfaces.AttachmentsUseCases,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) *PreviewsWorker {
+	return &PreviewsWorker{
+		attachmentsUseCases: attachmentsUseCases,
+		pollInterval:        pollInterval,
+		logger:              logger,
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+// PreviewsWorker generates previews of uploaded images in background. Attachments are polled from database,
+// so several instances of application can process them concurrently.
+type PreviewsWorker struct {
+	attachmentsUseCases interfaces.AttachmentsUseCases
+	pollInterval        time.Duration
+	logger              logging.Logger
+	stop                chan struct{}
+	done                chan struct{}
+}
+
+// Run processes attachments until worker is stopped. Next batch is taken immediately while there are
+// pending attachments, otherwise worker waits for poll interval.
+func (w *PreviewsWorker) Run() {
+	defer close(w.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		processed, err := w.attachmentsUseCases.ProcessAttachmentsPreviews(ctx)
+		if err != nil && ctx.Err() == nil {
+			logging.LogErrorContext(ctx, w.logger, "Failed to process attachments previews", err)
+		}
+
+		if processed > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Stop interrupts processing of current batch and waits for worker to finish. Interrupted attachments are
+// taken again after claim timeout.
+func (w *PreviewsWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
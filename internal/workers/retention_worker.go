@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/logging"
+)
+
+func NewRetentionWorker(
+	messagesUseCases interfaces.MessagesUseCases,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) *RetentionWorker {
+	return &RetentionWorker{
+		messagesUseCases: messagesUseCases,
+		pollInterval:     pollInterval,
+		logger:           logger,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+// RetentionWorker deletes messages of chats with auto-delete timer, which lifetime has expired. Messages are
+// locked while being deleted, so several instances of application can delete messages concurrently.
+type RetentionWorker struct {
+	messagesUseCases interfaces.MessagesUseCases
+	pollInterval     time.Duration
+	logger           logging.Logger
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+// Run deletes expired messages until worker is stopped. Next batch is taken immediately while there are
+// expired messages, otherwise worker waits for poll interval.
+func (w *RetentionWorker) Run() {
+	defer close(w.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		processed, err := w.messagesUseCases.PurgeExpiredMessages(ctx)
+		if err != nil && ctx.Err() == nil {
+			logging.LogErrorContext(ctx, w.logger, "Failed to purge expired messages", err)
+		}
+
+		if processed > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Stop interrupts deletion of current batch and waits for worker to finish. Transaction of interrupted batch
+// is rolled back, so its messages are deleted on next run.
+func (w *RetentionWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
@@ -0,0 +1,75 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/kfc/internal/interfaces"
+	"github.com/DKhorkov/libs/logging"
+)
+
+func NewScheduledMessagesWorker(
+	messagesUseCases interfaces.MessagesUseCases,
+	pollInterval time.Duration,
+	logger logging.Logger,
+) *ScheduledMessagesWorker {
+	return &ScheduledMessagesWorker{
+		messagesUseCases: messagesUseCases,
+		pollInterval:     pollInterval,
+		logger:           logger,
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+}
+
+// ScheduledMessagesWorker dispatches scheduled messages, which sending time has come. Each message is locked
+// while being sent, so several instances of application can dispatch messages concurrently without sending
+// any of them twice.
+type ScheduledMessagesWorker struct {
+	messagesUseCases interfaces.MessagesUseCases
+	pollInterval     time.Duration
+	logger           logging.Logger
+	stop             chan struct{}
+	done             chan struct{}
+}
+
+// Run dispatches scheduled messages until worker is stopped. Next batch is taken immediately while there are
+// due messages, otherwise worker waits for poll interval.
+func (w *ScheduledMessagesWorker) Run() {
+	defer close(w.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		processed, err := w.messagesUseCases.SendScheduledMessages(ctx)
+		if err != nil && ctx.Err() == nil {
+			logging.LogErrorContext(ctx, w.logger, "Failed to send scheduled messages", err)
+		}
+
+		if processed > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Stop interrupts dispatching of current batch and waits for worker to finish. Transaction of interrupted
+// message is rolled back, so message is sent on next run.
+func (w *ScheduledMessagesWorker) Stop() {
+	close(w.stop)
+	<-w.done
+}
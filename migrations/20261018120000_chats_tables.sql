@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS chats
+(
+    id         SERIAL PRIMARY KEY,
+    type       VARCHAR(20) NOT NULL,
+    direct_key VARCHAR(50) UNIQUE,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS chat_members
+(
+    id         SERIAL PRIMARY KEY,
+    chat_id    INTEGER   NOT NULL,
+    user_id    INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (chat_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS chat_members_user_id_idx ON chat_members (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chat_members;
+DROP TABLE IF EXISTS chats;
+-- +goose StatementEnd
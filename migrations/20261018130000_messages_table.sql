@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS messages
+(
+    id         SERIAL PRIMARY KEY,
+    chat_id    INTEGER   NOT NULL,
+    author_id  INTEGER   NOT NULL,
+    text       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Индекс для keyset-пагинации истории сообщений чата:
+CREATE INDEX IF NOT EXISTS messages_chat_id_id_idx ON messages (chat_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS messages;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chats
+    ADD COLUMN IF NOT EXISTS title VARCHAR(255);
+
+ALTER TABLE chat_members
+    ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_members
+    DROP COLUMN IF EXISTS role;
+
+ALTER TABLE chats
+    DROP COLUMN IF EXISTS title;
+-- +goose StatementEnd
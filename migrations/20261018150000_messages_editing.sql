@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS edited_at  TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Предыдущие версии текста отредактированных сообщений:
+CREATE TABLE IF NOT EXISTS message_revisions
+(
+    id         SERIAL PRIMARY KEY,
+    message_id INTEGER   NOT NULL,
+    text       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS message_revisions_message_id_idx ON message_revisions (message_id);
+
+-- Сообщения, удаленные пользователями только для себя:
+CREATE TABLE IF NOT EXISTS message_deletions
+(
+    id         SERIAL PRIMARY KEY,
+    message_id INTEGER   NOT NULL,
+    user_id    INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (message_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS message_deletions;
+DROP TABLE IF EXISTS message_revisions;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_members
+    ADD COLUMN IF NOT EXISTS last_read_message_id INTEGER,
+    ADD COLUMN IF NOT EXISTS unread_count         INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_members
+    DROP COLUMN IF EXISTS unread_count,
+    DROP COLUMN IF EXISTS last_read_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Реакции пользователей на сообщения. Пользователь может поставить несколько разных реакций на сообщение:
+CREATE TABLE IF NOT EXISTS message_reactions
+(
+    id         SERIAL PRIMARY KEY,
+    message_id INTEGER     NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    emoji      VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (message_id, user_id, emoji)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS message_reactions;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- reply_to_message_id - цитируемое сообщение, thread_root_id - корневое сообщение треда, к которому относится ответ.
+-- Счетчик и время последнего ответа хранятся в корневом сообщении, чтобы не считать их при чтении истории:
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS reply_to_message_id  INTEGER,
+    ADD COLUMN IF NOT EXISTS thread_root_id       INTEGER,
+    ADD COLUMN IF NOT EXISTS thread_reply_count   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS thread_last_reply_at TIMESTAMP,
+    ADD FOREIGN KEY (reply_to_message_id) REFERENCES messages (id) ON DELETE SET NULL,
+    ADD FOREIGN KEY (thread_root_id) REFERENCES messages (id) ON DELETE CASCADE;
+
+-- Индекс для keyset-пагинации ответов треда:
+CREATE INDEX IF NOT EXISTS messages_thread_root_id_id_idx ON messages (thread_root_id, id)
+    WHERE thread_root_id IS NOT NULL;
+
+-- Участники тредов - автор корневого сообщения и все, кто ответил в тред. Хранят непрочитанное в треде:
+CREATE TABLE IF NOT EXISTS thread_participants
+(
+    id                   SERIAL PRIMARY KEY,
+    thread_root_id       INTEGER   NOT NULL,
+    chat_id              INTEGER   NOT NULL,
+    user_id              INTEGER   NOT NULL,
+    last_read_message_id INTEGER,
+    unread_count         INTEGER   NOT NULL DEFAULT 0,
+    created_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (thread_root_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (thread_root_id, user_id)
+);
+
+-- Индекс для подсчета тредов с непрочитанными ответами в списке чатов:
+CREATE INDEX IF NOT EXISTS thread_participants_user_id_chat_id_idx ON thread_participants (user_id, chat_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS thread_participants;
+
+DROP INDEX IF EXISTS messages_thread_root_id_id_idx;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS thread_last_reply_at,
+    DROP COLUMN IF EXISTS thread_reply_count,
+    DROP COLUMN IF EXISTS thread_root_id,
+    DROP COLUMN IF EXISTS reply_to_message_id;
+-- +goose StatementEnd
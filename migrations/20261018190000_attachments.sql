@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Файлы, загруженные в чаты. Содержимое хранится в blob-хранилище по storage_key.
+-- message_id заполняется при отправке сообщения, до этого вложение видно только загрузившему его пользователю:
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id          SERIAL PRIMARY KEY,
+    chat_id     INTEGER      NOT NULL,
+    uploader_id INTEGER      NOT NULL,
+    message_id  INTEGER,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    filename    VARCHAR(255) NOT NULL,
+    mime_type   VARCHAR(255) NOT NULL,
+    size        BIGINT       NOT NULL,
+    checksum    VARCHAR(64)  NOT NULL,
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (uploader_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS attachments_message_id_idx ON attachments (message_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attachments;
+-- +goose StatementEnd
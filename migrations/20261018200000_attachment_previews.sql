@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Превью генерируются фоновым обработчиком только для изображений. preview_claimed_at - время, когда обработчик
+-- взял вложение в работу, чтобы вернуть его в очередь, если обработчик был остановлен аварийно:
+ALTER TABLE attachments
+    ADD COLUMN IF NOT EXISTS preview_status     VARCHAR(16) NOT NULL DEFAULT 'none',
+    ADD COLUMN IF NOT EXISTS preview_claimed_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS width              INTEGER,
+    ADD COLUMN IF NOT EXISTS height             INTEGER,
+    ADD COLUMN IF NOT EXISTS blurhash           VARCHAR(128);
+
+-- Индекс для поиска вложений, ожидающих генерации превью:
+CREATE INDEX IF NOT EXISTS attachments_preview_status_idx ON attachments (id)
+    WHERE preview_status IN ('pending', 'processing');
+
+-- Уменьшенные копии изображений. Содержимое хранится в blob-хранилище по storage_key:
+CREATE TABLE IF NOT EXISTS attachment_thumbnails
+(
+    id            SERIAL PRIMARY KEY,
+    attachment_id INTEGER      NOT NULL,
+    name          VARCHAR(16)  NOT NULL,
+    storage_key   VARCHAR(255) NOT NULL UNIQUE,
+    mime_type     VARCHAR(255) NOT NULL,
+    size          BIGINT       NOT NULL,
+    width         INTEGER      NOT NULL,
+    height        INTEGER      NOT NULL,
+    created_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (attachment_id) REFERENCES attachments (id) ON DELETE CASCADE,
+    UNIQUE (attachment_id, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attachment_thumbnails;
+
+DROP INDEX IF EXISTS attachments_preview_status_idx;
+
+ALTER TABLE attachments
+    DROP COLUMN IF EXISTS blurhash,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS preview_claimed_at,
+    DROP COLUMN IF EXISTS preview_status;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Поисковый вектор сообщения строится сразу по русской и английской конфигурациям, так как в чатах
+-- пишут на обоих языках. Колонка генерируемая, поэтому обновляется вместе с текстом сообщения:
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+        GENERATED ALWAYS AS (to_tsvector('russian', text) || to_tsvector('english', text)) STORED;
+
+CREATE INDEX IF NOT EXISTS messages_search_vector_idx ON messages USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS messages_search_vector_idx;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS search_vector;
+-- +goose StatementEnd
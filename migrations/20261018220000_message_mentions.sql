@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Упоминания пользователей в сообщениях. Пользователь упоминается в сообщении не более одного раза:
+CREATE TABLE IF NOT EXISTS message_mentions
+(
+    id         SERIAL PRIMARY KEY,
+    message_id INTEGER     NOT NULL,
+    chat_id    INTEGER     NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    type       VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (message_id, user_id)
+);
+
+-- Индекс для keyset-пагинации ленты упоминаний пользователя:
+CREATE INDEX IF NOT EXISTS message_mentions_user_id_id_idx ON message_mentions (user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS message_mentions;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Системные сообщения публикуются в ленту чата при служебных изменениях, например, при закреплении
+-- сообщений. Описание изменения хранится в system_event:
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS type         VARCHAR(20) NOT NULL DEFAULT 'regular',
+    ADD COLUMN IF NOT EXISTS system_event JSONB;
+
+CREATE TABLE IF NOT EXISTS message_pins
+(
+    id         SERIAL PRIMARY KEY,
+    chat_id    INTEGER   NOT NULL,
+    message_id INTEGER   NOT NULL UNIQUE,
+    pinned_by  INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (pinned_by) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS message_pins_chat_id_idx ON message_pins (chat_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS message_pins;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS system_event,
+    DROP COLUMN IF EXISTS type;
+-- +goose StatementEnd
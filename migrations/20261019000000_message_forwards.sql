@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пересланные сообщения хранят автора, чат и исходное сообщение оригинала. При пересылке пересланного
+-- сообщения сохраняется ссылка на оригинал, а не на промежуточную копию:
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS forwarded_from_message_id INTEGER,
+    ADD COLUMN IF NOT EXISTS forwarded_from_chat_id    INTEGER,
+    ADD COLUMN IF NOT EXISTS forwarded_from_author_id  INTEGER,
+    ADD FOREIGN KEY (forwarded_from_message_id) REFERENCES messages (id) ON DELETE SET NULL,
+    ADD FOREIGN KEY (forwarded_from_chat_id) REFERENCES chats (id) ON DELETE SET NULL,
+    ADD FOREIGN KEY (forwarded_from_author_id) REFERENCES users (id) ON DELETE SET NULL;
+
+-- Вложения пересланных сообщений копируются без повторной загрузки, поэтому несколько записей могут
+-- ссылаться на одно и то же содержимое в blob-хранилище:
+ALTER TABLE attachments
+    DROP CONSTRAINT IF EXISTS attachments_storage_key_key;
+
+ALTER TABLE attachment_thumbnails
+    DROP CONSTRAINT IF EXISTS attachment_thumbnails_storage_key_key;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE
+FROM attachments
+WHERE id NOT IN (SELECT MIN(id) FROM attachments GROUP BY storage_key);
+
+DELETE
+FROM attachment_thumbnails
+WHERE id NOT IN (SELECT MIN(id) FROM attachment_thumbnails GROUP BY storage_key);
+
+ALTER TABLE attachment_thumbnails
+    ADD CONSTRAINT attachment_thumbnails_storage_key_key UNIQUE (storage_key);
+
+ALTER TABLE attachments
+    ADD CONSTRAINT attachments_storage_key_key UNIQUE (storage_key);
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS forwarded_from_author_id,
+    DROP COLUMN IF EXISTS forwarded_from_chat_id,
+    DROP COLUMN IF EXISTS forwarded_from_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ссылки-приглашения в групповые чаты. Токен генерируется криптографически стойким генератором, поэтому
+-- не может быть подобран. Ссылка перестает действовать после expires_at, после max_uses использований
+-- или после отзыва:
+CREATE TABLE IF NOT EXISTS chat_invites
+(
+    id         SERIAL PRIMARY KEY,
+    chat_id    INTEGER     NOT NULL,
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    created_by INTEGER     NOT NULL,
+    expires_at TIMESTAMP,
+    max_uses   INTEGER,
+    uses_count INTEGER     NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS chat_invites_chat_id_idx ON chat_invites (chat_id);
+
+-- Каждое вступление в чат по ссылке фиксируется. Пользователь может использовать одну и ту же ссылку
+-- повторно, если покинул чат, поэтому пара (invite_id, user_id) не уникальна:
+CREATE TABLE IF NOT EXISTS chat_invite_uses
+(
+    id         SERIAL PRIMARY KEY,
+    invite_id  INTEGER   NOT NULL,
+    user_id    INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (invite_id) REFERENCES chat_invites (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS chat_invite_uses_invite_id_idx ON chat_invite_uses (invite_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chat_invite_uses;
+DROP TABLE IF EXISTS chat_invites;
+-- +goose StatementEnd
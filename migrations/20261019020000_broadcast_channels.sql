@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Каналы - чаты с типом channel, в которые публикуют только владелец и администраторы. Публичные каналы
+-- имеют уникальный handle, по которому их можно найти. Счетчики непрочитанных сообщений подписчиков
+-- каналов не обновляются при публикации, а вычисляются при чтении, поэтому публикация не затрагивает
+-- строки подписчиков:
+ALTER TABLE chats
+    ADD COLUMN IF NOT EXISTS handle VARCHAR(32) UNIQUE;
+
+CREATE INDEX IF NOT EXISTS chat_members_chat_id_idx ON chat_members (chat_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM chats WHERE type = 'channel';
+
+DROP INDEX IF EXISTS chat_members_chat_id_idx;
+
+ALTER TABLE chats
+    DROP COLUMN IF EXISTS handle;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Отложенные сообщения отправляются в чат от имени автора в момент send_at. Отправленное сообщение
+-- удаляется из расписания, а сообщение, которое не удалось отправить (например, автор покинул чат),
+-- остается в расписании с отметкой failed_at, пока автор не изменит или не отменит его:
+CREATE TABLE IF NOT EXISTS scheduled_messages
+(
+    id                  SERIAL PRIMARY KEY,
+    chat_id             INTEGER   NOT NULL,
+    author_id           INTEGER   NOT NULL,
+    text                TEXT      NOT NULL,
+    reply_to_message_id INTEGER,
+    send_at             TIMESTAMP NOT NULL,
+    failed_at           TIMESTAMP,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (reply_to_message_id) REFERENCES messages (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_messages_chat_id_author_id_idx ON scheduled_messages (chat_id, author_id);
+
+-- Диспетчер выбирает только ожидающие отправки сообщения:
+CREATE INDEX IF NOT EXISTS scheduled_messages_send_at_idx ON scheduled_messages (send_at) WHERE failed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scheduled_messages;
+-- +goose StatementEnd
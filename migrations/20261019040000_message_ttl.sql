@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Таймер автоудаления сообщений чата в секундах (0 - сообщения не удаляются). Сообщения, отправленные после
+-- включения таймера, получают expires_at и удаляются фоновым процессом вместе с вложениями после истечения
+-- срока. Ранее отправленные сообщения не затрагиваются:
+ALTER TABLE chats
+    ADD COLUMN IF NOT EXISTS message_ttl INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+-- Фоновый процесс выбирает только сообщения с ограниченным сроком жизни:
+CREATE INDEX IF NOT EXISTS messages_expires_at_idx ON messages (expires_at) WHERE expires_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS messages_expires_at_idx;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE chats
+    DROP COLUMN IF EXISTS message_ttl;
+-- +goose StatementEnd
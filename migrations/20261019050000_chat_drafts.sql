@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Черновики сообщений хранятся на сервере, чтобы пользователь мог продолжить набор сообщения на другом
+-- устройстве. У пользователя может быть только один черновик в каждом чате. Черновик удаляется, когда
+-- пользователь покидает чат или исключается из него:
+CREATE TABLE IF NOT EXISTS chat_drafts
+(
+    id         SERIAL PRIMARY KEY,
+    chat_id    INTEGER   NOT NULL,
+    user_id    INTEGER   NOT NULL,
+    text       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chat_id, user_id),
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Черновики пользователя загружаются вместе со списком его чатов:
+CREATE INDEX IF NOT EXISTS chat_drafts_user_id_idx ON chat_drafts (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chat_drafts;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Опросы в групповых чатах. Опрос публикуется сообщением типа poll, текстом которого является вопрос опроса.
+-- При удалении сообщения для всех опрос удаляется вместе с голосами:
+CREATE TABLE IF NOT EXISTS polls
+(
+    id              SERIAL PRIMARY KEY,
+    message_id      INTEGER   NOT NULL,
+    question        TEXT      NOT NULL,
+    multiple_choice BOOLEAN   NOT NULL DEFAULT FALSE,
+    anonymous       BOOLEAN   NOT NULL DEFAULT TRUE,
+    closes_at       TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    UNIQUE (message_id)
+);
+
+-- Варианты ответа упорядочены в порядке их создания:
+CREATE TABLE IF NOT EXISTS poll_options
+(
+    id      SERIAL PRIMARY KEY,
+    poll_id INTEGER NOT NULL,
+    text    TEXT    NOT NULL,
+    FOREIGN KEY (poll_id) REFERENCES polls (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS poll_options_poll_id_idx ON poll_options (poll_id);
+
+-- Пользователь голосует за вариант ответа не более одного раза. В опросе с одним вариантом ответа
+-- пользователь выбирает только один вариант, что проверяется при голосовании:
+CREATE TABLE IF NOT EXISTS poll_votes
+(
+    id         SERIAL PRIMARY KEY,
+    poll_id    INTEGER   NOT NULL,
+    option_id  INTEGER   NOT NULL,
+    user_id    INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (poll_id) REFERENCES polls (id) ON DELETE CASCADE,
+    FOREIGN KEY (option_id) REFERENCES poll_options (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    UNIQUE (option_id, user_id)
+);
+
+-- Индекс для подсчета проголосовавших и отзыва голосов пользователя:
+CREATE INDEX IF NOT EXISTS poll_votes_poll_id_user_id_idx ON poll_votes (poll_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+-- +goose StatementEnd
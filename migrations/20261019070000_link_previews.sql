@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Кэш превью ссылок. Метаданные страницы загружаются фоновым обработчиком один раз для каждого URL и
+-- используются всеми сообщениями с этой ссылкой. claimed_at - время, когда обработчик взял ссылку в работу,
+-- чтобы вернуть ее в очередь, если обработчик был остановлен аварийно:
+CREATE TABLE IF NOT EXISTS link_previews
+(
+    id          SERIAL PRIMARY KEY,
+    url         VARCHAR(2048) NOT NULL UNIQUE,
+    status      VARCHAR(16)   NOT NULL DEFAULT 'pending',
+    claimed_at  TIMESTAMP,
+    title       VARCHAR(300),
+    description VARCHAR(1000),
+    site_name   VARCHAR(100),
+    image_url   VARCHAR(2048),
+    created_at  TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Индекс для поиска ссылок, ожидающих загрузки метаданных:
+CREATE INDEX IF NOT EXISTS link_previews_status_idx ON link_previews (id)
+    WHERE status IN ('pending', 'processing');
+
+-- Превью первой ссылки из текста сообщения:
+CREATE TABLE IF NOT EXISTS message_link_previews
+(
+    message_id      INTEGER PRIMARY KEY,
+    link_preview_id INTEGER NOT NULL,
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (link_preview_id) REFERENCES link_previews (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS message_link_previews_link_preview_id_idx ON message_link_previews (link_preview_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS message_link_previews;
+
+DROP TABLE IF EXISTS link_previews;
+-- +goose StatementEnd
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=content_builders.go -destination=../../mocks/contentbuilders/forget_password_content_builder.go -package=mockcontentbuilders -exclude_interfaces=VerifyEmailContentBuilder,TicketUpdatedContentBuilder,TicketDeletedContentBuilder
+//	mockgen -source=content_builders.go -destination=../../mocks/contentbuilders/forget_password_content_builder.go -package=mockcontentbuilders -exclude_interfaces=VerifyEmailContentBuilder
 //
 
 // Package mockcontentbuilders is a generated GoMock package.
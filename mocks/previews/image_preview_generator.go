@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: previews.go
+//
+// Generated by this command:
+//
+//	mockgen -source=previews.go -destination=../../mocks/previews/image_preview_generator.go -package=mockpreviews -exclude_interfaces=LinkPreviewFetcher
+//
+
+// Package mockpreviews is a generated GoMock package.
+package mockpreviews
+
+import (
+	io "io"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImagePreviewGenerator is a mock of ImagePreviewGenerator interface.
+type MockImagePreviewGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagePreviewGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockImagePreviewGeneratorMockRecorder is the mock recorder for MockImagePreviewGenerator.
+type MockImagePreviewGeneratorMockRecorder struct {
+	mock *MockImagePreviewGenerator
+}
+
+// NewMockImagePreviewGenerator creates a new mock instance.
+func NewMockImagePreviewGenerator(ctrl *gomock.Controller) *MockImagePreviewGenerator {
+	mock := &MockImagePreviewGenerator{ctrl: ctrl}
+	mock.recorder = &MockImagePreviewGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagePreviewGenerator) EXPECT() *MockImagePreviewGeneratorMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockImagePreviewGenerator) Generate(content io.Reader) (*domains.ImagePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", content)
+	ret0, _ := ret[0].(*domains.ImagePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockImagePreviewGeneratorMockRecorder) Generate(content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockImagePreviewGenerator)(nil).Generate), content)
+}
+
+// Supports mocks base method.
+func (m *MockImagePreviewGenerator) Supports(mimeType string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Supports", mimeType)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Supports indicates an expected call of Supports.
+func (mr *MockImagePreviewGeneratorMockRecorder) Supports(mimeType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Supports", reflect.TypeOf((*MockImagePreviewGenerator)(nil).Supports), mimeType)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: realtime.go
+//
+// Generated by this command:
+//
+//	mockgen -source=realtime.go -destination=../../mocks/realtime/events_publisher.go -package=mockrealtime -exclude_interfaces=
+//
+
+// Package mockrealtime is a generated GoMock package.
+package mockrealtime
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventsPublisher is a mock of EventsPublisher interface.
+type MockEventsPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventsPublisherMockRecorder is the mock recorder for MockEventsPublisher.
+type MockEventsPublisherMockRecorder struct {
+	mock *MockEventsPublisher
+}
+
+// NewMockEventsPublisher creates a new mock instance.
+func NewMockEventsPublisher(ctrl *gomock.Controller) *MockEventsPublisher {
+	mock := &MockEventsPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventsPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsPublisher) EXPECT() *MockEventsPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventsPublisher) Publish(ctx context.Context, event domains.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventsPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventsPublisher)(nil).Publish), ctx, event)
+}
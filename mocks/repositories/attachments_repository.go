@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/attachments_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,MessagesRepository,PresenceRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentsRepository is a mock of AttachmentsRepository interface.
+type MockAttachmentsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentsRepositoryMockRecorder is the mock recorder for MockAttachmentsRepository.
+type MockAttachmentsRepositoryMockRecorder struct {
+	mock *MockAttachmentsRepository
+}
+
+// NewMockAttachmentsRepository creates a new mock instance.
+func NewMockAttachmentsRepository(ctrl *gomock.Controller) *MockAttachmentsRepository {
+	mock := &MockAttachmentsRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentsRepository) EXPECT() *MockAttachmentsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimAttachmentsForPreview mocks base method.
+func (m *MockAttachmentsRepository) ClaimAttachmentsForPreview(ctx context.Context, limit uint64, claimedBefore time.Time) ([]domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAttachmentsForPreview", ctx, limit, claimedBefore)
+	ret0, _ := ret[0].([]domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAttachmentsForPreview indicates an expected call of ClaimAttachmentsForPreview.
+func (mr *MockAttachmentsRepositoryMockRecorder) ClaimAttachmentsForPreview(ctx, limit, claimedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAttachmentsForPreview", reflect.TypeOf((*MockAttachmentsRepository)(nil).ClaimAttachmentsForPreview), ctx, limit, claimedBefore)
+}
+
+// CopyAttachment mocks base method.
+func (m *MockAttachmentsRepository) CopyAttachment(ctx context.Context, attachmentID, chatID, messageID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyAttachment", ctx, attachmentID, chatID, messageID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyAttachment indicates an expected call of CopyAttachment.
+func (mr *MockAttachmentsRepositoryMockRecorder) CopyAttachment(ctx, attachmentID, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyAttachment", reflect.TypeOf((*MockAttachmentsRepository)(nil).CopyAttachment), ctx, attachmentID, chatID, messageID)
+}
+
+// CopyAttachmentThumbnails mocks base method.
+func (m *MockAttachmentsRepository) CopyAttachmentThumbnails(ctx context.Context, attachmentID, copyID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyAttachmentThumbnails", ctx, attachmentID, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyAttachmentThumbnails indicates an expected call of CopyAttachmentThumbnails.
+func (mr *MockAttachmentsRepositoryMockRecorder) CopyAttachmentThumbnails(ctx, attachmentID, copyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyAttachmentThumbnails", reflect.TypeOf((*MockAttachmentsRepository)(nil).CopyAttachmentThumbnails), ctx, attachmentID, copyID)
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentsRepository) CreateAttachment(ctx context.Context, attachmentData domains.CreateAttachmentDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachmentData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentsRepositoryMockRecorder) CreateAttachment(ctx, attachmentData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentsRepository)(nil).CreateAttachment), ctx, attachmentData)
+}
+
+// CreateAttachmentThumbnails mocks base method.
+func (m *MockAttachmentsRepository) CreateAttachmentThumbnails(ctx context.Context, thumbnails ...domains.CreateAttachmentThumbnailDTO) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range thumbnails {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAttachmentThumbnails", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachmentThumbnails indicates an expected call of CreateAttachmentThumbnails.
+func (mr *MockAttachmentsRepositoryMockRecorder) CreateAttachmentThumbnails(ctx any, thumbnails ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, thumbnails...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachmentThumbnails", reflect.TypeOf((*MockAttachmentsRepository)(nil).CreateAttachmentThumbnails), varargs...)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachmentsRepository) GetAttachmentByID(ctx context.Context, id uint64) (*domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentsRepositoryMockRecorder) GetAttachmentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachmentsRepository)(nil).GetAttachmentByID), ctx, id)
+}
+
+// GetAttachmentsByIDsForUpdate mocks base method.
+func (m *MockAttachmentsRepository) GetAttachmentsByIDsForUpdate(ctx context.Context, ids []uint64) ([]domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByIDsForUpdate", ctx, ids)
+	ret0, _ := ret[0].([]domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByIDsForUpdate indicates an expected call of GetAttachmentsByIDsForUpdate.
+func (mr *MockAttachmentsRepositoryMockRecorder) GetAttachmentsByIDsForUpdate(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByIDsForUpdate", reflect.TypeOf((*MockAttachmentsRepository)(nil).GetAttachmentsByIDsForUpdate), ctx, ids)
+}
+
+// GetAttachmentsThumbnails mocks base method.
+func (m *MockAttachmentsRepository) GetAttachmentsThumbnails(ctx context.Context, attachmentIDs []uint64) ([]domains.AttachmentThumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsThumbnails", ctx, attachmentIDs)
+	ret0, _ := ret[0].([]domains.AttachmentThumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsThumbnails indicates an expected call of GetAttachmentsThumbnails.
+func (mr *MockAttachmentsRepositoryMockRecorder) GetAttachmentsThumbnails(ctx, attachmentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsThumbnails", reflect.TypeOf((*MockAttachmentsRepository)(nil).GetAttachmentsThumbnails), ctx, attachmentIDs)
+}
+
+// GetMessagesAttachments mocks base method.
+func (m *MockAttachmentsRepository) GetMessagesAttachments(ctx context.Context, messageIDs []uint64) ([]domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesAttachments", ctx, messageIDs)
+	ret0, _ := ret[0].([]domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesAttachments indicates an expected call of GetMessagesAttachments.
+func (mr *MockAttachmentsRepositoryMockRecorder) GetMessagesAttachments(ctx, messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesAttachments", reflect.TypeOf((*MockAttachmentsRepository)(nil).GetMessagesAttachments), ctx, messageIDs)
+}
+
+// GetUsedStorageKeys mocks base method.
+func (m *MockAttachmentsRepository) GetUsedStorageKeys(ctx context.Context, storageKeys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsedStorageKeys", ctx, storageKeys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsedStorageKeys indicates an expected call of GetUsedStorageKeys.
+func (mr *MockAttachmentsRepositoryMockRecorder) GetUsedStorageKeys(ctx, storageKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedStorageKeys", reflect.TypeOf((*MockAttachmentsRepository)(nil).GetUsedStorageKeys), ctx, storageKeys)
+}
+
+// SetAttachmentsMessage mocks base method.
+func (m *MockAttachmentsRepository) SetAttachmentsMessage(ctx context.Context, messageID uint64, attachmentIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttachmentsMessage", ctx, messageID, attachmentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAttachmentsMessage indicates an expected call of SetAttachmentsMessage.
+func (mr *MockAttachmentsRepositoryMockRecorder) SetAttachmentsMessage(ctx, messageID, attachmentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttachmentsMessage", reflect.TypeOf((*MockAttachmentsRepository)(nil).SetAttachmentsMessage), ctx, messageID, attachmentIDs)
+}
+
+// UpdateAttachmentPreview mocks base method.
+func (m *MockAttachmentsRepository) UpdateAttachmentPreview(ctx context.Context, previewData domains.UpdateAttachmentPreviewDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttachmentPreview", ctx, previewData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAttachmentPreview indicates an expected call of UpdateAttachmentPreview.
+func (mr *MockAttachmentsRepositoryMockRecorder) UpdateAttachmentPreview(ctx, previewData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttachmentPreview", reflect.TypeOf((*MockAttachmentsRepository)(nil).UpdateAttachmentPreview), ctx, previewData)
+}
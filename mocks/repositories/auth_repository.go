@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=repositories.go -destination=../../mocks/repositories/auth_repository.go -package=mockrepositories -exclude_interfaces=UsersRepository,EmailsRepository
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/auth_repository.go -package=mockrepositories -exclude_interfaces=UsersRepository,EmailsRepository,ChatsRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
 //
 
 // Package mockrepositories is a generated GoMock package.
@@ -57,18 +57,18 @@ func (mr *MockAuthRepositoryMockRecorder) ChangePassword(ctx, userID, newPasswor
 }
 
 // CreateRefreshToken mocks base method.
-func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, userID uint64, refreshToken string, ttl time.Duration) (uint64, error) {
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, userID uint64, value string, ttl time.Duration) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, refreshToken, ttl)
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, value, ttl)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRefreshToken indicates an expected call of CreateRefreshToken.
-func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, userID, refreshToken, ttl any) *gomock.Call {
+func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, userID, value, ttl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, userID, refreshToken, ttl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, userID, value, ttl)
 }
 
 // ExpireRefreshToken mocks base method.
@@ -0,0 +1,574 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/chats_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,MessagesRepository,PresenceRepository,AttachmentsRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatsRepository is a mock of ChatsRepository interface.
+type MockChatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatsRepositoryMockRecorder is the mock recorder for MockChatsRepository.
+type MockChatsRepositoryMockRecorder struct {
+	mock *MockChatsRepository
+}
+
+// NewMockChatsRepository creates a new mock instance.
+func NewMockChatsRepository(ctrl *gomock.Controller) *MockChatsRepository {
+	mock := &MockChatsRepository{ctrl: ctrl}
+	mock.recorder = &MockChatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatsRepository) EXPECT() *MockChatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddChatMembers mocks base method.
+func (m *MockChatsRepository) AddChatMembers(ctx context.Context, chatID uint64, role domains.ChatMemberRole, userIDs ...uint64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, chatID, role}
+	for _, a := range userIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddChatMembers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChatMembers indicates an expected call of AddChatMembers.
+func (mr *MockChatsRepositoryMockRecorder) AddChatMembers(ctx, chatID, role any, userIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, chatID, role}, userIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChatMembers", reflect.TypeOf((*MockChatsRepository)(nil).AddChatMembers), varargs...)
+}
+
+// CreateChannel mocks base method.
+func (m *MockChatsRepository) CreateChannel(ctx context.Context, title string, handle *string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", ctx, title, handle)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockChatsRepositoryMockRecorder) CreateChannel(ctx, title, handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChatsRepository)(nil).CreateChannel), ctx, title, handle)
+}
+
+// CreateChatInvite mocks base method.
+func (m *MockChatsRepository) CreateChatInvite(ctx context.Context, inviteData domains.CreateChatInviteDTO, token string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatInvite", ctx, inviteData, token)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChatInvite indicates an expected call of CreateChatInvite.
+func (mr *MockChatsRepositoryMockRecorder) CreateChatInvite(ctx, inviteData, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatInvite", reflect.TypeOf((*MockChatsRepository)(nil).CreateChatInvite), ctx, inviteData, token)
+}
+
+// CreateChatInviteUse mocks base method.
+func (m *MockChatsRepository) CreateChatInviteUse(ctx context.Context, inviteID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatInviteUse", ctx, inviteID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChatInviteUse indicates an expected call of CreateChatInviteUse.
+func (mr *MockChatsRepositoryMockRecorder) CreateChatInviteUse(ctx, inviteID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatInviteUse", reflect.TypeOf((*MockChatsRepository)(nil).CreateChatInviteUse), ctx, inviteID, userID)
+}
+
+// CreateDirectChat mocks base method.
+func (m *MockChatsRepository) CreateDirectChat(ctx context.Context, firstUserID, secondUserID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDirectChat", ctx, firstUserID, secondUserID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirectChat indicates an expected call of CreateDirectChat.
+func (mr *MockChatsRepositoryMockRecorder) CreateDirectChat(ctx, firstUserID, secondUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectChat", reflect.TypeOf((*MockChatsRepository)(nil).CreateDirectChat), ctx, firstUserID, secondUserID)
+}
+
+// CreateGroupChat mocks base method.
+func (m *MockChatsRepository) CreateGroupChat(ctx context.Context, title string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, title)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupChat indicates an expected call of CreateGroupChat.
+func (mr *MockChatsRepositoryMockRecorder) CreateGroupChat(ctx, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockChatsRepository)(nil).CreateGroupChat), ctx, title)
+}
+
+// DeleteChat mocks base method.
+func (m *MockChatsRepository) DeleteChat(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChat", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChat indicates an expected call of DeleteChat.
+func (mr *MockChatsRepositoryMockRecorder) DeleteChat(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChat", reflect.TypeOf((*MockChatsRepository)(nil).DeleteChat), ctx, id)
+}
+
+// DeleteChatDraft mocks base method.
+func (m *MockChatsRepository) DeleteChatDraft(ctx context.Context, chatID, userID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatDraft", ctx, chatID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChatDraft indicates an expected call of DeleteChatDraft.
+func (mr *MockChatsRepositoryMockRecorder) DeleteChatDraft(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatDraft", reflect.TypeOf((*MockChatsRepository)(nil).DeleteChatDraft), ctx, chatID, userID)
+}
+
+// DeleteChatMember mocks base method.
+func (m *MockChatsRepository) DeleteChatMember(ctx context.Context, chatID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatMember", ctx, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChatMember indicates an expected call of DeleteChatMember.
+func (mr *MockChatsRepositoryMockRecorder) DeleteChatMember(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatMember", reflect.TypeOf((*MockChatsRepository)(nil).DeleteChatMember), ctx, chatID, userID)
+}
+
+// DeleteThreadParticipants mocks base method.
+func (m *MockChatsRepository) DeleteThreadParticipants(ctx context.Context, chatID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteThreadParticipants", ctx, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteThreadParticipants indicates an expected call of DeleteThreadParticipants.
+func (mr *MockChatsRepositoryMockRecorder) DeleteThreadParticipants(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThreadParticipants", reflect.TypeOf((*MockChatsRepository)(nil).DeleteThreadParticipants), ctx, chatID, userID)
+}
+
+// GetChannels mocks base method.
+func (m *MockChatsRepository) GetChannels(ctx context.Context, filters *domains.ChannelsFilters, pagination *domains.Pagination) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ctx, filters, pagination)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockChatsRepositoryMockRecorder) GetChannels(ctx, filters, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockChatsRepository)(nil).GetChannels), ctx, filters, pagination)
+}
+
+// GetChannelsUnreadCounts mocks base method.
+func (m *MockChatsRepository) GetChannelsUnreadCounts(ctx context.Context, userID uint64, chatIDs []uint64) (map[uint64]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelsUnreadCounts", ctx, userID, chatIDs)
+	ret0, _ := ret[0].(map[uint64]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelsUnreadCounts indicates an expected call of GetChannelsUnreadCounts.
+func (mr *MockChatsRepositoryMockRecorder) GetChannelsUnreadCounts(ctx, userID, chatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelsUnreadCounts", reflect.TypeOf((*MockChatsRepository)(nil).GetChannelsUnreadCounts), ctx, userID, chatIDs)
+}
+
+// GetChatByID mocks base method.
+func (m *MockChatsRepository) GetChatByID(ctx context.Context, id uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatByID indicates an expected call of GetChatByID.
+func (mr *MockChatsRepositoryMockRecorder) GetChatByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatByID", reflect.TypeOf((*MockChatsRepository)(nil).GetChatByID), ctx, id)
+}
+
+// GetChatByIDForUpdate mocks base method.
+func (m *MockChatsRepository) GetChatByIDForUpdate(ctx context.Context, id uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatByIDForUpdate indicates an expected call of GetChatByIDForUpdate.
+func (mr *MockChatsRepositoryMockRecorder) GetChatByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatByIDForUpdate", reflect.TypeOf((*MockChatsRepository)(nil).GetChatByIDForUpdate), ctx, id)
+}
+
+// GetChatDraft mocks base method.
+func (m *MockChatsRepository) GetChatDraft(ctx context.Context, chatID, userID uint64) (*domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatDraft", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatDraft indicates an expected call of GetChatDraft.
+func (mr *MockChatsRepositoryMockRecorder) GetChatDraft(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatDraft", reflect.TypeOf((*MockChatsRepository)(nil).GetChatDraft), ctx, chatID, userID)
+}
+
+// GetChatInviteByID mocks base method.
+func (m *MockChatsRepository) GetChatInviteByID(ctx context.Context, id uint64) (*domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatInviteByID", ctx, id)
+	ret0, _ := ret[0].(*domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatInviteByID indicates an expected call of GetChatInviteByID.
+func (mr *MockChatsRepositoryMockRecorder) GetChatInviteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatInviteByID", reflect.TypeOf((*MockChatsRepository)(nil).GetChatInviteByID), ctx, id)
+}
+
+// GetChatInviteByToken mocks base method.
+func (m *MockChatsRepository) GetChatInviteByToken(ctx context.Context, token string) (*domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatInviteByToken", ctx, token)
+	ret0, _ := ret[0].(*domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatInviteByToken indicates an expected call of GetChatInviteByToken.
+func (mr *MockChatsRepositoryMockRecorder) GetChatInviteByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatInviteByToken", reflect.TypeOf((*MockChatsRepository)(nil).GetChatInviteByToken), ctx, token)
+}
+
+// GetChatInvites mocks base method.
+func (m *MockChatsRepository) GetChatInvites(ctx context.Context, chatID uint64) ([]domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatInvites", ctx, chatID)
+	ret0, _ := ret[0].([]domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatInvites indicates an expected call of GetChatInvites.
+func (mr *MockChatsRepositoryMockRecorder) GetChatInvites(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatInvites", reflect.TypeOf((*MockChatsRepository)(nil).GetChatInvites), ctx, chatID)
+}
+
+// GetChatMember mocks base method.
+func (m *MockChatsRepository) GetChatMember(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMember", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMember indicates an expected call of GetChatMember.
+func (mr *MockChatsRepositoryMockRecorder) GetChatMember(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMember", reflect.TypeOf((*MockChatsRepository)(nil).GetChatMember), ctx, chatID, userID)
+}
+
+// GetChatMemberForUpdate mocks base method.
+func (m *MockChatsRepository) GetChatMemberForUpdate(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMemberForUpdate", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMemberForUpdate indicates an expected call of GetChatMemberForUpdate.
+func (mr *MockChatsRepositoryMockRecorder) GetChatMemberForUpdate(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMemberForUpdate", reflect.TypeOf((*MockChatsRepository)(nil).GetChatMemberForUpdate), ctx, chatID, userID)
+}
+
+// GetChatMembersIDs mocks base method.
+func (m *MockChatsRepository) GetChatMembersIDs(ctx context.Context, chatID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMembersIDs", ctx, chatID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMembersIDs indicates an expected call of GetChatMembersIDs.
+func (mr *MockChatsRepositoryMockRecorder) GetChatMembersIDs(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMembersIDs", reflect.TypeOf((*MockChatsRepository)(nil).GetChatMembersIDs), ctx, chatID)
+}
+
+// GetChatsMembers mocks base method.
+func (m *MockChatsRepository) GetChatsMembers(ctx context.Context, chatIDs []uint64) ([]domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatsMembers", ctx, chatIDs)
+	ret0, _ := ret[0].([]domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatsMembers indicates an expected call of GetChatsMembers.
+func (mr *MockChatsRepositoryMockRecorder) GetChatsMembers(ctx, chatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatsMembers", reflect.TypeOf((*MockChatsRepository)(nil).GetChatsMembers), ctx, chatIDs)
+}
+
+// GetChatsMembersCounts mocks base method.
+func (m *MockChatsRepository) GetChatsMembersCounts(ctx context.Context, chatIDs []uint64) (map[uint64]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatsMembersCounts", ctx, chatIDs)
+	ret0, _ := ret[0].(map[uint64]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatsMembersCounts indicates an expected call of GetChatsMembersCounts.
+func (mr *MockChatsRepositoryMockRecorder) GetChatsMembersCounts(ctx, chatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatsMembersCounts", reflect.TypeOf((*MockChatsRepository)(nil).GetChatsMembersCounts), ctx, chatIDs)
+}
+
+// GetDirectChat mocks base method.
+func (m *MockChatsRepository) GetDirectChat(ctx context.Context, firstUserID, secondUserID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectChat", ctx, firstUserID, secondUserID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectChat indicates an expected call of GetDirectChat.
+func (mr *MockChatsRepositoryMockRecorder) GetDirectChat(ctx, firstUserID, secondUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectChat", reflect.TypeOf((*MockChatsRepository)(nil).GetDirectChat), ctx, firstUserID, secondUserID)
+}
+
+// GetUnreadThreadsCounts mocks base method.
+func (m *MockChatsRepository) GetUnreadThreadsCounts(ctx context.Context, userID uint64, chatIDs []uint64) (map[uint64]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadThreadsCounts", ctx, userID, chatIDs)
+	ret0, _ := ret[0].(map[uint64]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadThreadsCounts indicates an expected call of GetUnreadThreadsCounts.
+func (mr *MockChatsRepositoryMockRecorder) GetUnreadThreadsCounts(ctx, userID, chatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadThreadsCounts", reflect.TypeOf((*MockChatsRepository)(nil).GetUnreadThreadsCounts), ctx, userID, chatIDs)
+}
+
+// GetUserChatDrafts mocks base method.
+func (m *MockChatsRepository) GetUserChatDrafts(ctx context.Context, userID uint64, chatIDs []uint64) ([]domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChatDrafts", ctx, userID, chatIDs)
+	ret0, _ := ret[0].([]domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChatDrafts indicates an expected call of GetUserChatDrafts.
+func (mr *MockChatsRepositoryMockRecorder) GetUserChatDrafts(ctx, userID, chatIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChatDrafts", reflect.TypeOf((*MockChatsRepository)(nil).GetUserChatDrafts), ctx, userID, chatIDs)
+}
+
+// GetUserChats mocks base method.
+func (m *MockChatsRepository) GetUserChats(ctx context.Context, userID uint64) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChats", ctx, userID)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChats indicates an expected call of GetUserChats.
+func (mr *MockChatsRepositoryMockRecorder) GetUserChats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChats", reflect.TypeOf((*MockChatsRepository)(nil).GetUserChats), ctx, userID)
+}
+
+// IncrementChatInviteUses mocks base method.
+func (m *MockChatsRepository) IncrementChatInviteUses(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementChatInviteUses", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementChatInviteUses indicates an expected call of IncrementChatInviteUses.
+func (mr *MockChatsRepositoryMockRecorder) IncrementChatInviteUses(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementChatInviteUses", reflect.TypeOf((*MockChatsRepository)(nil).IncrementChatInviteUses), ctx, id)
+}
+
+// IncrementUnreadCounters mocks base method.
+func (m *MockChatsRepository) IncrementUnreadCounters(ctx context.Context, chatID, exceptUserID, delta uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementUnreadCounters", ctx, chatID, exceptUserID, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementUnreadCounters indicates an expected call of IncrementUnreadCounters.
+func (mr *MockChatsRepositoryMockRecorder) IncrementUnreadCounters(ctx, chatID, exceptUserID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUnreadCounters", reflect.TypeOf((*MockChatsRepository)(nil).IncrementUnreadCounters), ctx, chatID, exceptUserID, delta)
+}
+
+// RecountUnreadCounters mocks base method.
+func (m *MockChatsRepository) RecountUnreadCounters(ctx context.Context, chatID, fromMessageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountUnreadCounters", ctx, chatID, fromMessageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountUnreadCounters indicates an expected call of RecountUnreadCounters.
+func (mr *MockChatsRepositoryMockRecorder) RecountUnreadCounters(ctx, chatID, fromMessageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountUnreadCounters", reflect.TypeOf((*MockChatsRepository)(nil).RecountUnreadCounters), ctx, chatID, fromMessageID)
+}
+
+// RevokeChatInvite mocks base method.
+func (m *MockChatsRepository) RevokeChatInvite(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeChatInvite", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeChatInvite indicates an expected call of RevokeChatInvite.
+func (mr *MockChatsRepositoryMockRecorder) RevokeChatInvite(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeChatInvite", reflect.TypeOf((*MockChatsRepository)(nil).RevokeChatInvite), ctx, id)
+}
+
+// SaveChatDraft mocks base method.
+func (m *MockChatsRepository) SaveChatDraft(ctx context.Context, draftData domains.SaveChatDraftDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatDraft", ctx, draftData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveChatDraft indicates an expected call of SaveChatDraft.
+func (mr *MockChatsRepositoryMockRecorder) SaveChatDraft(ctx, draftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatDraft", reflect.TypeOf((*MockChatsRepository)(nil).SaveChatDraft), ctx, draftData)
+}
+
+// TouchChat mocks base method.
+func (m *MockChatsRepository) TouchChat(ctx context.Context, chatID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchChat", ctx, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchChat indicates an expected call of TouchChat.
+func (mr *MockChatsRepositoryMockRecorder) TouchChat(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchChat", reflect.TypeOf((*MockChatsRepository)(nil).TouchChat), ctx, chatID)
+}
+
+// UpdateChatMemberRole mocks base method.
+func (m *MockChatsRepository) UpdateChatMemberRole(ctx context.Context, chatID, userID uint64, role domains.ChatMemberRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChatMemberRole", ctx, chatID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChatMemberRole indicates an expected call of UpdateChatMemberRole.
+func (mr *MockChatsRepositoryMockRecorder) UpdateChatMemberRole(ctx, chatID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChatMemberRole", reflect.TypeOf((*MockChatsRepository)(nil).UpdateChatMemberRole), ctx, chatID, userID, role)
+}
+
+// UpdateChatMessageTTL mocks base method.
+func (m *MockChatsRepository) UpdateChatMessageTTL(ctx context.Context, chatID uint64, messageTTL domains.MessageTTL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChatMessageTTL", ctx, chatID, messageTTL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChatMessageTTL indicates an expected call of UpdateChatMessageTTL.
+func (mr *MockChatsRepositoryMockRecorder) UpdateChatMessageTTL(ctx, chatID, messageTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChatMessageTTL", reflect.TypeOf((*MockChatsRepository)(nil).UpdateChatMessageTTL), ctx, chatID, messageTTL)
+}
+
+// UpdateLastReadMessage mocks base method.
+func (m *MockChatsRepository) UpdateLastReadMessage(ctx context.Context, chatID, userID, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastReadMessage", ctx, chatID, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastReadMessage indicates an expected call of UpdateLastReadMessage.
+func (mr *MockChatsRepositoryMockRecorder) UpdateLastReadMessage(ctx, chatID, userID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastReadMessage", reflect.TypeOf((*MockChatsRepository)(nil).UpdateLastReadMessage), ctx, chatID, userID, messageID)
+}
@@ -0,0 +1,901 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/messages_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,PresenceRepository,AttachmentsRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessagesRepository is a mock of MessagesRepository interface.
+type MockMessagesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessagesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMessagesRepositoryMockRecorder is the mock recorder for MockMessagesRepository.
+type MockMessagesRepositoryMockRecorder struct {
+	mock *MockMessagesRepository
+}
+
+// NewMockMessagesRepository creates a new mock instance.
+func NewMockMessagesRepository(ctrl *gomock.Controller) *MockMessagesRepository {
+	mock := &MockMessagesRepository{ctrl: ctrl}
+	mock.recorder = &MockMessagesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessagesRepository) EXPECT() *MockMessagesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMessageReaction mocks base method.
+func (m *MockMessagesRepository) AddMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMessageReaction indicates an expected call of AddMessageReaction.
+func (mr *MockMessagesRepositoryMockRecorder) AddMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessageReaction", reflect.TypeOf((*MockMessagesRepository)(nil).AddMessageReaction), ctx, reactionData)
+}
+
+// AddThreadParticipants mocks base method.
+func (m *MockMessagesRepository) AddThreadParticipants(ctx context.Context, chatID, threadRootID uint64, userIDs ...uint64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, chatID, threadRootID}
+	for _, a := range userIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddThreadParticipants", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddThreadParticipants indicates an expected call of AddThreadParticipants.
+func (mr *MockMessagesRepositoryMockRecorder) AddThreadParticipants(ctx, chatID, threadRootID any, userIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, chatID, threadRootID}, userIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddThreadParticipants", reflect.TypeOf((*MockMessagesRepository)(nil).AddThreadParticipants), varargs...)
+}
+
+// AddThreadReply mocks base method.
+func (m *MockMessagesRepository) AddThreadReply(ctx context.Context, threadRootID uint64, repliedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddThreadReply", ctx, threadRootID, repliedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddThreadReply indicates an expected call of AddThreadReply.
+func (mr *MockMessagesRepositoryMockRecorder) AddThreadReply(ctx, threadRootID, repliedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddThreadReply", reflect.TypeOf((*MockMessagesRepository)(nil).AddThreadReply), ctx, threadRootID, repliedAt)
+}
+
+// ClaimLinkPreviews mocks base method.
+func (m *MockMessagesRepository) ClaimLinkPreviews(ctx context.Context, limit uint64, claimedBefore time.Time) ([]domains.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimLinkPreviews", ctx, limit, claimedBefore)
+	ret0, _ := ret[0].([]domains.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimLinkPreviews indicates an expected call of ClaimLinkPreviews.
+func (mr *MockMessagesRepositoryMockRecorder) ClaimLinkPreviews(ctx, limit, claimedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimLinkPreviews", reflect.TypeOf((*MockMessagesRepository)(nil).ClaimLinkPreviews), ctx, limit, claimedBefore)
+}
+
+// CopyMessageLinkPreview mocks base method.
+func (m *MockMessagesRepository) CopyMessageLinkPreview(ctx context.Context, messageID, copyID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyMessageLinkPreview", ctx, messageID, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyMessageLinkPreview indicates an expected call of CopyMessageLinkPreview.
+func (mr *MockMessagesRepositoryMockRecorder) CopyMessageLinkPreview(ctx, messageID, copyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyMessageLinkPreview", reflect.TypeOf((*MockMessagesRepository)(nil).CopyMessageLinkPreview), ctx, messageID, copyID)
+}
+
+// CountChatPins mocks base method.
+func (m *MockMessagesRepository) CountChatPins(ctx context.Context, chatID uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChatPins", ctx, chatID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChatPins indicates an expected call of CountChatPins.
+func (mr *MockMessagesRepositoryMockRecorder) CountChatPins(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChatPins", reflect.TypeOf((*MockMessagesRepository)(nil).CountChatPins), ctx, chatID)
+}
+
+// CountUserScheduledMessages mocks base method.
+func (m *MockMessagesRepository) CountUserScheduledMessages(ctx context.Context, chatID, authorID uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserScheduledMessages", ctx, chatID, authorID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserScheduledMessages indicates an expected call of CountUserScheduledMessages.
+func (mr *MockMessagesRepositoryMockRecorder) CountUserScheduledMessages(ctx, chatID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserScheduledMessages", reflect.TypeOf((*MockMessagesRepository)(nil).CountUserScheduledMessages), ctx, chatID, authorID)
+}
+
+// CreateMentions mocks base method.
+func (m *MockMessagesRepository) CreateMentions(ctx context.Context, mentions ...domains.CreateMentionDTO) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range mentions {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateMentions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMentions indicates an expected call of CreateMentions.
+func (mr *MockMessagesRepositoryMockRecorder) CreateMentions(ctx any, mentions ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, mentions...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMentions", reflect.TypeOf((*MockMessagesRepository)(nil).CreateMentions), varargs...)
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessagesRepository) CreateMessage(ctx context.Context, messageData domains.CreateMessageDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, messageData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessagesRepositoryMockRecorder) CreateMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessagesRepository)(nil).CreateMessage), ctx, messageData)
+}
+
+// CreateMessageRevision mocks base method.
+func (m *MockMessagesRepository) CreateMessageRevision(ctx context.Context, messageID uint64, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessageRevision", ctx, messageID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessageRevision indicates an expected call of CreateMessageRevision.
+func (mr *MockMessagesRepositoryMockRecorder) CreateMessageRevision(ctx, messageID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageRevision", reflect.TypeOf((*MockMessagesRepository)(nil).CreateMessageRevision), ctx, messageID, text)
+}
+
+// CreatePin mocks base method.
+func (m *MockMessagesRepository) CreatePin(ctx context.Context, chatID, messageID, pinnedBy uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePin", ctx, chatID, messageID, pinnedBy)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePin indicates an expected call of CreatePin.
+func (mr *MockMessagesRepositoryMockRecorder) CreatePin(ctx, chatID, messageID, pinnedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePin", reflect.TypeOf((*MockMessagesRepository)(nil).CreatePin), ctx, chatID, messageID, pinnedBy)
+}
+
+// CreatePoll mocks base method.
+func (m *MockMessagesRepository) CreatePoll(ctx context.Context, messageID uint64, pollData domains.CreatePollDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, messageID, pollData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePoll indicates an expected call of CreatePoll.
+func (mr *MockMessagesRepositoryMockRecorder) CreatePoll(ctx, messageID, pollData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockMessagesRepository)(nil).CreatePoll), ctx, messageID, pollData)
+}
+
+// CreatePollVotes mocks base method.
+func (m *MockMessagesRepository) CreatePollVotes(ctx context.Context, pollID, userID uint64, optionIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePollVotes", ctx, pollID, userID, optionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePollVotes indicates an expected call of CreatePollVotes.
+func (mr *MockMessagesRepositoryMockRecorder) CreatePollVotes(ctx, pollID, userID, optionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePollVotes", reflect.TypeOf((*MockMessagesRepository)(nil).CreatePollVotes), ctx, pollID, userID, optionIDs)
+}
+
+// CreateScheduledMessage mocks base method.
+func (m *MockMessagesRepository) CreateScheduledMessage(ctx context.Context, messageData domains.ScheduleMessageDTO) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledMessage", ctx, messageData)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledMessage indicates an expected call of CreateScheduledMessage.
+func (mr *MockMessagesRepositoryMockRecorder) CreateScheduledMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledMessage", reflect.TypeOf((*MockMessagesRepository)(nil).CreateScheduledMessage), ctx, messageData)
+}
+
+// DeleteMessage mocks base method.
+func (m *MockMessagesRepository) DeleteMessage(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessage), ctx, id)
+}
+
+// DeleteMessageLinkPreview mocks base method.
+func (m *MockMessagesRepository) DeleteMessageLinkPreview(ctx context.Context, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageLinkPreview", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessageLinkPreview indicates an expected call of DeleteMessageLinkPreview.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessageLinkPreview(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageLinkPreview", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessageLinkPreview), ctx, messageID)
+}
+
+// DeleteMessagePoll mocks base method.
+func (m *MockMessagesRepository) DeleteMessagePoll(ctx context.Context, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessagePoll", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessagePoll indicates an expected call of DeleteMessagePoll.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessagePoll(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessagePoll", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessagePoll), ctx, messageID)
+}
+
+// DeleteMessageReaction mocks base method.
+func (m *MockMessagesRepository) DeleteMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageReaction indicates an expected call of DeleteMessageReaction.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageReaction", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessageReaction), ctx, reactionData)
+}
+
+// DeleteMessageReactions mocks base method.
+func (m *MockMessagesRepository) DeleteMessageReactions(ctx context.Context, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageReactions", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessageReactions indicates an expected call of DeleteMessageReactions.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessageReactions(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageReactions", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessageReactions), ctx, messageID)
+}
+
+// DeleteMessageRevisions mocks base method.
+func (m *MockMessagesRepository) DeleteMessageRevisions(ctx context.Context, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageRevisions", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessageRevisions indicates an expected call of DeleteMessageRevisions.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessageRevisions(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageRevisions", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessageRevisions), ctx, messageID)
+}
+
+// DeleteMessages mocks base method.
+func (m *MockMessagesRepository) DeleteMessages(ctx context.Context, ids []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessages", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessages indicates an expected call of DeleteMessages.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteMessages(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessages", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteMessages), ctx, ids)
+}
+
+// DeletePin mocks base method.
+func (m *MockMessagesRepository) DeletePin(ctx context.Context, messageID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePin", ctx, messageID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePin indicates an expected call of DeletePin.
+func (mr *MockMessagesRepositoryMockRecorder) DeletePin(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePin", reflect.TypeOf((*MockMessagesRepository)(nil).DeletePin), ctx, messageID)
+}
+
+// DeletePollVotes mocks base method.
+func (m *MockMessagesRepository) DeletePollVotes(ctx context.Context, pollID, userID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePollVotes", ctx, pollID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePollVotes indicates an expected call of DeletePollVotes.
+func (mr *MockMessagesRepositoryMockRecorder) DeletePollVotes(ctx, pollID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePollVotes", reflect.TypeOf((*MockMessagesRepository)(nil).DeletePollVotes), ctx, pollID, userID)
+}
+
+// DeleteScheduledMessage mocks base method.
+func (m *MockMessagesRepository) DeleteScheduledMessage(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledMessage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledMessage indicates an expected call of DeleteScheduledMessage.
+func (mr *MockMessagesRepositoryMockRecorder) DeleteScheduledMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledMessage", reflect.TypeOf((*MockMessagesRepository)(nil).DeleteScheduledMessage), ctx, id)
+}
+
+// FailScheduledMessage mocks base method.
+func (m *MockMessagesRepository) FailScheduledMessage(ctx context.Context, id uint64, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailScheduledMessage", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailScheduledMessage indicates an expected call of FailScheduledMessage.
+func (mr *MockMessagesRepositoryMockRecorder) FailScheduledMessage(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailScheduledMessage", reflect.TypeOf((*MockMessagesRepository)(nil).FailScheduledMessage), ctx, id, updatedAt)
+}
+
+// GetChatMessages mocks base method.
+func (m *MockMessagesRepository) GetChatMessages(ctx context.Context, chatID, userID uint64, cursor domains.MessagesCursor) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessages", ctx, chatID, userID, cursor)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessages indicates an expected call of GetChatMessages.
+func (mr *MockMessagesRepositoryMockRecorder) GetChatMessages(ctx, chatID, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessages", reflect.TypeOf((*MockMessagesRepository)(nil).GetChatMessages), ctx, chatID, userID, cursor)
+}
+
+// GetChatMessagesByIDs mocks base method.
+func (m *MockMessagesRepository) GetChatMessagesByIDs(ctx context.Context, chatID, userID uint64, ids []uint64) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessagesByIDs", ctx, chatID, userID, ids)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessagesByIDs indicates an expected call of GetChatMessagesByIDs.
+func (mr *MockMessagesRepositoryMockRecorder) GetChatMessagesByIDs(ctx, chatID, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessagesByIDs", reflect.TypeOf((*MockMessagesRepository)(nil).GetChatMessagesByIDs), ctx, chatID, userID, ids)
+}
+
+// GetChatPins mocks base method.
+func (m *MockMessagesRepository) GetChatPins(ctx context.Context, chatID, userID uint64) ([]domains.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatPins", ctx, chatID, userID)
+	ret0, _ := ret[0].([]domains.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatPins indicates an expected call of GetChatPins.
+func (mr *MockMessagesRepositoryMockRecorder) GetChatPins(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatPins", reflect.TypeOf((*MockMessagesRepository)(nil).GetChatPins), ctx, chatID, userID)
+}
+
+// GetDueScheduledMessages mocks base method.
+func (m *MockMessagesRepository) GetDueScheduledMessages(ctx context.Context, now time.Time, limit uint64) ([]domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduledMessages", ctx, now, limit)
+	ret0, _ := ret[0].([]domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduledMessages indicates an expected call of GetDueScheduledMessages.
+func (mr *MockMessagesRepositoryMockRecorder) GetDueScheduledMessages(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduledMessages", reflect.TypeOf((*MockMessagesRepository)(nil).GetDueScheduledMessages), ctx, now, limit)
+}
+
+// GetLinkPreviewMessages mocks base method.
+func (m *MockMessagesRepository) GetLinkPreviewMessages(ctx context.Context, linkPreviewID uint64) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkPreviewMessages", ctx, linkPreviewID)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkPreviewMessages indicates an expected call of GetLinkPreviewMessages.
+func (mr *MockMessagesRepositoryMockRecorder) GetLinkPreviewMessages(ctx, linkPreviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkPreviewMessages", reflect.TypeOf((*MockMessagesRepository)(nil).GetLinkPreviewMessages), ctx, linkPreviewID)
+}
+
+// GetMessageByID mocks base method.
+func (m *MockMessagesRepository) GetMessageByID(ctx context.Context, id uint64) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessageByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessageByID), ctx, id)
+}
+
+// GetMessageByIDForUpdate mocks base method.
+func (m *MockMessagesRepository) GetMessageByIDForUpdate(ctx context.Context, id uint64) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByIDForUpdate indicates an expected call of GetMessageByIDForUpdate.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessageByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByIDForUpdate", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessageByIDForUpdate), ctx, id)
+}
+
+// GetMessageMentions mocks base method.
+func (m *MockMessagesRepository) GetMessageMentions(ctx context.Context, messageID uint64) ([]domains.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageMentions", ctx, messageID)
+	ret0, _ := ret[0].([]domains.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageMentions indicates an expected call of GetMessageMentions.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessageMentions(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageMentions", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessageMentions), ctx, messageID)
+}
+
+// GetMessagePin mocks base method.
+func (m *MockMessagesRepository) GetMessagePin(ctx context.Context, messageID uint64) (*domains.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagePin", ctx, messageID)
+	ret0, _ := ret[0].(*domains.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagePin indicates an expected call of GetMessagePin.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessagePin(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagePin", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessagePin), ctx, messageID)
+}
+
+// GetMessagesLinkPreviews mocks base method.
+func (m *MockMessagesRepository) GetMessagesLinkPreviews(ctx context.Context, messageIDs []uint64) ([]domains.MessageLinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesLinkPreviews", ctx, messageIDs)
+	ret0, _ := ret[0].([]domains.MessageLinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesLinkPreviews indicates an expected call of GetMessagesLinkPreviews.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessagesLinkPreviews(ctx, messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesLinkPreviews", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessagesLinkPreviews), ctx, messageIDs)
+}
+
+// GetMessagesPolls mocks base method.
+func (m *MockMessagesRepository) GetMessagesPolls(ctx context.Context, messageIDs []uint64) ([]domains.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesPolls", ctx, messageIDs)
+	ret0, _ := ret[0].([]domains.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesPolls indicates an expected call of GetMessagesPolls.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessagesPolls(ctx, messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesPolls", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessagesPolls), ctx, messageIDs)
+}
+
+// GetMessagesReactions mocks base method.
+func (m *MockMessagesRepository) GetMessagesReactions(ctx context.Context, messageIDs []uint64, userID uint64) ([]domains.MessageReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesReactions", ctx, messageIDs, userID)
+	ret0, _ := ret[0].([]domains.MessageReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesReactions indicates an expected call of GetMessagesReactions.
+func (mr *MockMessagesRepositoryMockRecorder) GetMessagesReactions(ctx, messageIDs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesReactions", reflect.TypeOf((*MockMessagesRepository)(nil).GetMessagesReactions), ctx, messageIDs, userID)
+}
+
+// GetPollsOptions mocks base method.
+func (m *MockMessagesRepository) GetPollsOptions(ctx context.Context, pollIDs []uint64, userID uint64) ([]domains.PollOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsOptions", ctx, pollIDs, userID)
+	ret0, _ := ret[0].([]domains.PollOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsOptions indicates an expected call of GetPollsOptions.
+func (mr *MockMessagesRepositoryMockRecorder) GetPollsOptions(ctx, pollIDs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsOptions", reflect.TypeOf((*MockMessagesRepository)(nil).GetPollsOptions), ctx, pollIDs, userID)
+}
+
+// GetPollsVotes mocks base method.
+func (m *MockMessagesRepository) GetPollsVotes(ctx context.Context, pollIDs []uint64) ([]domains.PollVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsVotes", ctx, pollIDs)
+	ret0, _ := ret[0].([]domains.PollVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsVotes indicates an expected call of GetPollsVotes.
+func (mr *MockMessagesRepositoryMockRecorder) GetPollsVotes(ctx, pollIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsVotes", reflect.TypeOf((*MockMessagesRepository)(nil).GetPollsVotes), ctx, pollIDs)
+}
+
+// GetScheduledMessageByID mocks base method.
+func (m *MockMessagesRepository) GetScheduledMessageByID(ctx context.Context, id uint64) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledMessageByID", ctx, id)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledMessageByID indicates an expected call of GetScheduledMessageByID.
+func (mr *MockMessagesRepositoryMockRecorder) GetScheduledMessageByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledMessageByID", reflect.TypeOf((*MockMessagesRepository)(nil).GetScheduledMessageByID), ctx, id)
+}
+
+// GetThreadMessages mocks base method.
+func (m *MockMessagesRepository) GetThreadMessages(ctx context.Context, threadRootID, userID uint64, cursor domains.MessagesCursor) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadMessages", ctx, threadRootID, userID, cursor)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadMessages indicates an expected call of GetThreadMessages.
+func (mr *MockMessagesRepositoryMockRecorder) GetThreadMessages(ctx, threadRootID, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadMessages", reflect.TypeOf((*MockMessagesRepository)(nil).GetThreadMessages), ctx, threadRootID, userID, cursor)
+}
+
+// GetThreadParticipant mocks base method.
+func (m *MockMessagesRepository) GetThreadParticipant(ctx context.Context, threadRootID, userID uint64) (*domains.ThreadParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadParticipant", ctx, threadRootID, userID)
+	ret0, _ := ret[0].(*domains.ThreadParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadParticipant indicates an expected call of GetThreadParticipant.
+func (mr *MockMessagesRepositoryMockRecorder) GetThreadParticipant(ctx, threadRootID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadParticipant", reflect.TypeOf((*MockMessagesRepository)(nil).GetThreadParticipant), ctx, threadRootID, userID)
+}
+
+// GetThreadParticipantForUpdate mocks base method.
+func (m *MockMessagesRepository) GetThreadParticipantForUpdate(ctx context.Context, threadRootID, userID uint64) (*domains.ThreadParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadParticipantForUpdate", ctx, threadRootID, userID)
+	ret0, _ := ret[0].(*domains.ThreadParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadParticipantForUpdate indicates an expected call of GetThreadParticipantForUpdate.
+func (mr *MockMessagesRepositoryMockRecorder) GetThreadParticipantForUpdate(ctx, threadRootID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadParticipantForUpdate", reflect.TypeOf((*MockMessagesRepository)(nil).GetThreadParticipantForUpdate), ctx, threadRootID, userID)
+}
+
+// GetUserMentions mocks base method.
+func (m *MockMessagesRepository) GetUserMentions(ctx context.Context, userID uint64, cursor domains.MentionsCursor) ([]domains.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMentions", ctx, userID, cursor)
+	ret0, _ := ret[0].([]domains.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMentions indicates an expected call of GetUserMentions.
+func (mr *MockMessagesRepositoryMockRecorder) GetUserMentions(ctx, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockMessagesRepository)(nil).GetUserMentions), ctx, userID, cursor)
+}
+
+// GetUserScheduledMessages mocks base method.
+func (m *MockMessagesRepository) GetUserScheduledMessages(ctx context.Context, chatID, authorID uint64) ([]domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserScheduledMessages", ctx, chatID, authorID)
+	ret0, _ := ret[0].([]domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserScheduledMessages indicates an expected call of GetUserScheduledMessages.
+func (mr *MockMessagesRepositoryMockRecorder) GetUserScheduledMessages(ctx, chatID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserScheduledMessages", reflect.TypeOf((*MockMessagesRepository)(nil).GetUserScheduledMessages), ctx, chatID, authorID)
+}
+
+// GetUserThreadParticipants mocks base method.
+func (m *MockMessagesRepository) GetUserThreadParticipants(ctx context.Context, threadRootIDs []uint64, userID uint64) ([]domains.ThreadParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserThreadParticipants", ctx, threadRootIDs, userID)
+	ret0, _ := ret[0].([]domains.ThreadParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserThreadParticipants indicates an expected call of GetUserThreadParticipants.
+func (mr *MockMessagesRepositoryMockRecorder) GetUserThreadParticipants(ctx, threadRootIDs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserThreadParticipants", reflect.TypeOf((*MockMessagesRepository)(nil).GetUserThreadParticipants), ctx, threadRootIDs, userID)
+}
+
+// HideMessage mocks base method.
+func (m *MockMessagesRepository) HideMessage(ctx context.Context, messageID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideMessage", ctx, messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideMessage indicates an expected call of HideMessage.
+func (mr *MockMessagesRepositoryMockRecorder) HideMessage(ctx, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideMessage", reflect.TypeOf((*MockMessagesRepository)(nil).HideMessage), ctx, messageID, userID)
+}
+
+// IncrementThreadUnreadCounters mocks base method.
+func (m *MockMessagesRepository) IncrementThreadUnreadCounters(ctx context.Context, threadRootID, exceptUserID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementThreadUnreadCounters", ctx, threadRootID, exceptUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementThreadUnreadCounters indicates an expected call of IncrementThreadUnreadCounters.
+func (mr *MockMessagesRepositoryMockRecorder) IncrementThreadUnreadCounters(ctx, threadRootID, exceptUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementThreadUnreadCounters", reflect.TypeOf((*MockMessagesRepository)(nil).IncrementThreadUnreadCounters), ctx, threadRootID, exceptUserID)
+}
+
+// LockExpiredMessages mocks base method.
+func (m *MockMessagesRepository) LockExpiredMessages(ctx context.Context, expiredBefore time.Time, limit uint64) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockExpiredMessages", ctx, expiredBefore, limit)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockExpiredMessages indicates an expected call of LockExpiredMessages.
+func (mr *MockMessagesRepositoryMockRecorder) LockExpiredMessages(ctx, expiredBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockExpiredMessages", reflect.TypeOf((*MockMessagesRepository)(nil).LockExpiredMessages), ctx, expiredBefore, limit)
+}
+
+// LockPendingScheduledMessage mocks base method.
+func (m *MockMessagesRepository) LockPendingScheduledMessage(ctx context.Context, id uint64) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPendingScheduledMessage", ctx, id)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPendingScheduledMessage indicates an expected call of LockPendingScheduledMessage.
+func (mr *MockMessagesRepositoryMockRecorder) LockPendingScheduledMessage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPendingScheduledMessage", reflect.TypeOf((*MockMessagesRepository)(nil).LockPendingScheduledMessage), ctx, id)
+}
+
+// RecountThreadReplies mocks base method.
+func (m *MockMessagesRepository) RecountThreadReplies(ctx context.Context, threadRootIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountThreadReplies", ctx, threadRootIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountThreadReplies indicates an expected call of RecountThreadReplies.
+func (mr *MockMessagesRepositoryMockRecorder) RecountThreadReplies(ctx, threadRootIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountThreadReplies", reflect.TypeOf((*MockMessagesRepository)(nil).RecountThreadReplies), ctx, threadRootIDs)
+}
+
+// RecountThreadUnreadCounters mocks base method.
+func (m *MockMessagesRepository) RecountThreadUnreadCounters(ctx context.Context, threadRootIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountThreadUnreadCounters", ctx, threadRootIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountThreadUnreadCounters indicates an expected call of RecountThreadUnreadCounters.
+func (mr *MockMessagesRepositoryMockRecorder) RecountThreadUnreadCounters(ctx, threadRootIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountThreadUnreadCounters", reflect.TypeOf((*MockMessagesRepository)(nil).RecountThreadUnreadCounters), ctx, threadRootIDs)
+}
+
+// SearchMessages mocks base method.
+func (m *MockMessagesRepository) SearchMessages(ctx context.Context, searchData domains.SearchMessagesDTO) ([]domains.MessageSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessages", ctx, searchData)
+	ret0, _ := ret[0].([]domains.MessageSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessages indicates an expected call of SearchMessages.
+func (mr *MockMessagesRepositoryMockRecorder) SearchMessages(ctx, searchData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessages", reflect.TypeOf((*MockMessagesRepository)(nil).SearchMessages), ctx, searchData)
+}
+
+// SetMessageLinkPreview mocks base method.
+func (m *MockMessagesRepository) SetMessageLinkPreview(ctx context.Context, messageID uint64, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMessageLinkPreview", ctx, messageID, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMessageLinkPreview indicates an expected call of SetMessageLinkPreview.
+func (mr *MockMessagesRepositoryMockRecorder) SetMessageLinkPreview(ctx, messageID, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMessageLinkPreview", reflect.TypeOf((*MockMessagesRepository)(nil).SetMessageLinkPreview), ctx, messageID, url)
+}
+
+// UpdateLinkPreview mocks base method.
+func (m *MockMessagesRepository) UpdateLinkPreview(ctx context.Context, previewData domains.UpdateLinkPreviewDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLinkPreview", ctx, previewData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLinkPreview indicates an expected call of UpdateLinkPreview.
+func (mr *MockMessagesRepositoryMockRecorder) UpdateLinkPreview(ctx, previewData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkPreview", reflect.TypeOf((*MockMessagesRepository)(nil).UpdateLinkPreview), ctx, previewData)
+}
+
+// UpdateMessageText mocks base method.
+func (m *MockMessagesRepository) UpdateMessageText(ctx context.Context, id uint64, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessageText", ctx, id, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessageText indicates an expected call of UpdateMessageText.
+func (mr *MockMessagesRepositoryMockRecorder) UpdateMessageText(ctx, id, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageText", reflect.TypeOf((*MockMessagesRepository)(nil).UpdateMessageText), ctx, id, text)
+}
+
+// UpdateScheduledMessage mocks base method.
+func (m *MockMessagesRepository) UpdateScheduledMessage(ctx context.Context, id uint64, text string, sendAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledMessage", ctx, id, text, sendAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledMessage indicates an expected call of UpdateScheduledMessage.
+func (mr *MockMessagesRepositoryMockRecorder) UpdateScheduledMessage(ctx, id, text, sendAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledMessage", reflect.TypeOf((*MockMessagesRepository)(nil).UpdateScheduledMessage), ctx, id, text, sendAt)
+}
+
+// UpdateThreadLastReadMessage mocks base method.
+func (m *MockMessagesRepository) UpdateThreadLastReadMessage(ctx context.Context, threadRootID, userID, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateThreadLastReadMessage", ctx, threadRootID, userID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateThreadLastReadMessage indicates an expected call of UpdateThreadLastReadMessage.
+func (mr *MockMessagesRepositoryMockRecorder) UpdateThreadLastReadMessage(ctx, threadRootID, userID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateThreadLastReadMessage", reflect.TypeOf((*MockMessagesRepository)(nil).UpdateThreadLastReadMessage), ctx, threadRootID, userID, messageID)
+}
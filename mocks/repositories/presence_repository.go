@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repositories.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repositories.go -destination=../../mocks/repositories/presence_repository.go -package=mockrepositories -exclude_interfaces=EmailsRepository,UsersRepository,AuthRepository,ChatsRepository,MessagesRepository,AttachmentsRepository
+//
+
+// Package mockrepositories is a generated GoMock package.
+package mockrepositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresenceRepository is a mock of PresenceRepository interface.
+type MockPresenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceRepositoryMockRecorder is the mock recorder for MockPresenceRepository.
+type MockPresenceRepositoryMockRecorder struct {
+	mock *MockPresenceRepository
+}
+
+// NewMockPresenceRepository creates a new mock instance.
+func NewMockPresenceRepository(ctrl *gomock.Controller) *MockPresenceRepository {
+	mock := &MockPresenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPresenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceRepository) EXPECT() *MockPresenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddConnection mocks base method.
+func (m *MockPresenceRepository) AddConnection(ctx context.Context, userID uint64, ttl time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConnection", ctx, userID, ttl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConnection indicates an expected call of AddConnection.
+func (mr *MockPresenceRepositoryMockRecorder) AddConnection(ctx, userID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConnection", reflect.TypeOf((*MockPresenceRepository)(nil).AddConnection), ctx, userID, ttl)
+}
+
+// GetPresence mocks base method.
+func (m *MockPresenceRepository) GetPresence(ctx context.Context, userID uint64) (*domains.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresence", ctx, userID)
+	ret0, _ := ret[0].(*domains.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresence indicates an expected call of GetPresence.
+func (mr *MockPresenceRepositoryMockRecorder) GetPresence(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresence", reflect.TypeOf((*MockPresenceRepository)(nil).GetPresence), ctx, userID)
+}
+
+// GetPresences mocks base method.
+func (m *MockPresenceRepository) GetPresences(ctx context.Context, userIDs []uint64) ([]domains.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresences", ctx, userIDs)
+	ret0, _ := ret[0].([]domains.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresences indicates an expected call of GetPresences.
+func (mr *MockPresenceRepositoryMockRecorder) GetPresences(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresences", reflect.TypeOf((*MockPresenceRepository)(nil).GetPresences), ctx, userIDs)
+}
+
+// ProlongConnections mocks base method.
+func (m *MockPresenceRepository) ProlongConnections(ctx context.Context, userID uint64, ttl time.Duration, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProlongConnections", ctx, userID, ttl, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProlongConnections indicates an expected call of ProlongConnections.
+func (mr *MockPresenceRepositoryMockRecorder) ProlongConnections(ctx, userID, ttl, lastSeenAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProlongConnections", reflect.TypeOf((*MockPresenceRepository)(nil).ProlongConnections), ctx, userID, ttl, lastSeenAt)
+}
+
+// RemoveConnection mocks base method.
+func (m *MockPresenceRepository) RemoveConnection(ctx context.Context, userID uint64, lastSeenAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveConnection", ctx, userID, lastSeenAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveConnection indicates an expected call of RemoveConnection.
+func (mr *MockPresenceRepositoryMockRecorder) RemoveConnection(ctx, userID, lastSeenAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveConnection", reflect.TypeOf((*MockPresenceRepository)(nil).RemoveConnection), ctx, userID, lastSeenAt)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/attachments_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,MessagesService,PresenceService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentsService is a mock of AttachmentsService interface.
+type MockAttachmentsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentsServiceMockRecorder is the mock recorder for MockAttachmentsService.
+type MockAttachmentsServiceMockRecorder struct {
+	mock *MockAttachmentsService
+}
+
+// NewMockAttachmentsService creates a new mock instance.
+func NewMockAttachmentsService(ctrl *gomock.Controller) *MockAttachmentsService {
+	mock := &MockAttachmentsService{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentsService) EXPECT() *MockAttachmentsServiceMockRecorder {
+	return m.recorder
+}
+
+// ClaimAttachmentsForPreview mocks base method.
+func (m *MockAttachmentsService) ClaimAttachmentsForPreview(ctx context.Context, limit uint64, claimTimeout time.Duration) ([]domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAttachmentsForPreview", ctx, limit, claimTimeout)
+	ret0, _ := ret[0].([]domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAttachmentsForPreview indicates an expected call of ClaimAttachmentsForPreview.
+func (mr *MockAttachmentsServiceMockRecorder) ClaimAttachmentsForPreview(ctx, limit, claimTimeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAttachmentsForPreview", reflect.TypeOf((*MockAttachmentsService)(nil).ClaimAttachmentsForPreview), ctx, limit, claimTimeout)
+}
+
+// DeleteAttachmentsContent mocks base method.
+func (m *MockAttachmentsService) DeleteAttachmentsContent(ctx context.Context, storageKeys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachmentsContent", ctx, storageKeys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachmentsContent indicates an expected call of DeleteAttachmentsContent.
+func (mr *MockAttachmentsServiceMockRecorder) DeleteAttachmentsContent(ctx, storageKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachmentsContent", reflect.TypeOf((*MockAttachmentsService)(nil).DeleteAttachmentsContent), ctx, storageKeys)
+}
+
+// DownloadAttachment mocks base method.
+func (m *MockAttachmentsService) DownloadAttachment(ctx context.Context, attachment domains.Attachment, thumbnailName domains.ThumbnailName) (*domains.AttachmentDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadAttachment", ctx, attachment, thumbnailName)
+	ret0, _ := ret[0].(*domains.AttachmentDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAttachment indicates an expected call of DownloadAttachment.
+func (mr *MockAttachmentsServiceMockRecorder) DownloadAttachment(ctx, attachment, thumbnailName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAttachment", reflect.TypeOf((*MockAttachmentsService)(nil).DownloadAttachment), ctx, attachment, thumbnailName)
+}
+
+// GenerateAttachmentPreview mocks base method.
+func (m *MockAttachmentsService) GenerateAttachmentPreview(ctx context.Context, attachment domains.Attachment) (*domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateAttachmentPreview", ctx, attachment)
+	ret0, _ := ret[0].(*domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateAttachmentPreview indicates an expected call of GenerateAttachmentPreview.
+func (mr *MockAttachmentsServiceMockRecorder) GenerateAttachmentPreview(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAttachmentPreview", reflect.TypeOf((*MockAttachmentsService)(nil).GenerateAttachmentPreview), ctx, attachment)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachmentsService) GetAttachmentByID(ctx context.Context, id uint64) (*domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentsServiceMockRecorder) GetAttachmentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachmentsService)(nil).GetAttachmentByID), ctx, id)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockAttachmentsService) UploadAttachment(ctx context.Context, attachmentData domains.UploadAttachmentDTO) (*domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, attachmentData)
+	ret0, _ := ret[0].(*domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockAttachmentsServiceMockRecorder) UploadAttachment(ctx, attachmentData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAttachmentsService)(nil).UploadAttachment), ctx, attachmentData)
+}
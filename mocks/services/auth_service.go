@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=services.go -destination=../../mocks/services/auth_service.go -package=mockservices -exclude_interfaces=UsersService
+//	mockgen -source=services.go -destination=../../mocks/services/auth_service.go -package=mockservices -exclude_interfaces=UsersService,ChatsService,MessagesService,PresenceService,AttachmentsService
 //
 
 // Package mockservices is a generated GoMock package.
@@ -57,18 +57,18 @@ func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, newPassword a
 }
 
 // CreateRefreshToken mocks base method.
-func (m *MockAuthService) CreateRefreshToken(ctx context.Context, userID uint64, refreshToken string, ttl time.Duration) (*domains.RefreshToken, error) {
+func (m *MockAuthService) CreateRefreshToken(ctx context.Context, userID uint64, value string, ttl time.Duration) (*domains.RefreshToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, refreshToken, ttl)
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, userID, value, ttl)
 	ret0, _ := ret[0].(*domains.RefreshToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRefreshToken indicates an expected call of CreateRefreshToken.
-func (mr *MockAuthServiceMockRecorder) CreateRefreshToken(ctx, userID, refreshToken, ttl any) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) CreateRefreshToken(ctx, userID, value, ttl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthService)(nil).CreateRefreshToken), ctx, userID, refreshToken, ttl)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthService)(nil).CreateRefreshToken), ctx, userID, value, ttl)
 }
 
 // ExpireRefreshToken mocks base method.
@@ -0,0 +1,369 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/chats_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,MessagesService,PresenceService,AttachmentsService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatsService is a mock of ChatsService interface.
+type MockChatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatsServiceMockRecorder is the mock recorder for MockChatsService.
+type MockChatsServiceMockRecorder struct {
+	mock *MockChatsService
+}
+
+// NewMockChatsService creates a new mock instance.
+func NewMockChatsService(ctrl *gomock.Controller) *MockChatsService {
+	mock := &MockChatsService{ctrl: ctrl}
+	mock.recorder = &MockChatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatsService) EXPECT() *MockChatsServiceMockRecorder {
+	return m.recorder
+}
+
+// AddGroupChatMembers mocks base method.
+func (m *MockChatsService) AddGroupChatMembers(ctx context.Context, membersData domains.AddChatMembersDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupChatMembers", ctx, membersData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGroupChatMembers indicates an expected call of AddGroupChatMembers.
+func (mr *MockChatsServiceMockRecorder) AddGroupChatMembers(ctx, membersData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupChatMembers", reflect.TypeOf((*MockChatsService)(nil).AddGroupChatMembers), ctx, membersData)
+}
+
+// ChangeGroupChatMemberRole mocks base method.
+func (m *MockChatsService) ChangeGroupChatMemberRole(ctx context.Context, roleData domains.ChangeChatMemberRoleDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeGroupChatMemberRole", ctx, roleData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeGroupChatMemberRole indicates an expected call of ChangeGroupChatMemberRole.
+func (mr *MockChatsServiceMockRecorder) ChangeGroupChatMemberRole(ctx, roleData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeGroupChatMemberRole", reflect.TypeOf((*MockChatsService)(nil).ChangeGroupChatMemberRole), ctx, roleData)
+}
+
+// CreateChannel mocks base method.
+func (m *MockChatsService) CreateChannel(ctx context.Context, channelData domains.CreateChannelDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", ctx, channelData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockChatsServiceMockRecorder) CreateChannel(ctx, channelData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChatsService)(nil).CreateChannel), ctx, channelData)
+}
+
+// CreateChatInvite mocks base method.
+func (m *MockChatsService) CreateChatInvite(ctx context.Context, inviteData domains.CreateChatInviteDTO) (*domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatInvite", ctx, inviteData)
+	ret0, _ := ret[0].(*domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChatInvite indicates an expected call of CreateChatInvite.
+func (mr *MockChatsServiceMockRecorder) CreateChatInvite(ctx, inviteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatInvite", reflect.TypeOf((*MockChatsService)(nil).CreateChatInvite), ctx, inviteData)
+}
+
+// CreateGroupChat mocks base method.
+func (m *MockChatsService) CreateGroupChat(ctx context.Context, chatData domains.CreateGroupChatDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, chatData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupChat indicates an expected call of CreateGroupChat.
+func (mr *MockChatsServiceMockRecorder) CreateGroupChat(ctx, chatData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockChatsService)(nil).CreateGroupChat), ctx, chatData)
+}
+
+// DeleteChatDraft mocks base method.
+func (m *MockChatsService) DeleteChatDraft(ctx context.Context, chatID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatDraft", ctx, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChatDraft indicates an expected call of DeleteChatDraft.
+func (mr *MockChatsServiceMockRecorder) DeleteChatDraft(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatDraft", reflect.TypeOf((*MockChatsService)(nil).DeleteChatDraft), ctx, chatID, userID)
+}
+
+// GetChannelUnreadCount mocks base method.
+func (m *MockChatsService) GetChannelUnreadCount(ctx context.Context, chatID, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelUnreadCount", ctx, chatID, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelUnreadCount indicates an expected call of GetChannelUnreadCount.
+func (mr *MockChatsServiceMockRecorder) GetChannelUnreadCount(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelUnreadCount", reflect.TypeOf((*MockChatsService)(nil).GetChannelUnreadCount), ctx, chatID, userID)
+}
+
+// GetChannels mocks base method.
+func (m *MockChatsService) GetChannels(ctx context.Context, filters *domains.ChannelsFilters, pagination *domains.Pagination) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ctx, filters, pagination)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockChatsServiceMockRecorder) GetChannels(ctx, filters, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockChatsService)(nil).GetChannels), ctx, filters, pagination)
+}
+
+// GetChatByID mocks base method.
+func (m *MockChatsService) GetChatByID(ctx context.Context, id uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatByID indicates an expected call of GetChatByID.
+func (mr *MockChatsServiceMockRecorder) GetChatByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatByID", reflect.TypeOf((*MockChatsService)(nil).GetChatByID), ctx, id)
+}
+
+// GetChatDraft mocks base method.
+func (m *MockChatsService) GetChatDraft(ctx context.Context, chatID, userID uint64) (*domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatDraft", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatDraft indicates an expected call of GetChatDraft.
+func (mr *MockChatsServiceMockRecorder) GetChatDraft(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatDraft", reflect.TypeOf((*MockChatsService)(nil).GetChatDraft), ctx, chatID, userID)
+}
+
+// GetChatInvites mocks base method.
+func (m *MockChatsService) GetChatInvites(ctx context.Context, chatID, actorID uint64) ([]domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatInvites", ctx, chatID, actorID)
+	ret0, _ := ret[0].([]domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatInvites indicates an expected call of GetChatInvites.
+func (mr *MockChatsServiceMockRecorder) GetChatInvites(ctx, chatID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatInvites", reflect.TypeOf((*MockChatsService)(nil).GetChatInvites), ctx, chatID, actorID)
+}
+
+// GetChatMember mocks base method.
+func (m *MockChatsService) GetChatMember(ctx context.Context, chatID, userID uint64) (*domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMember", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMember indicates an expected call of GetChatMember.
+func (mr *MockChatsServiceMockRecorder) GetChatMember(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMember", reflect.TypeOf((*MockChatsService)(nil).GetChatMember), ctx, chatID, userID)
+}
+
+// GetChatMembersIDs mocks base method.
+func (m *MockChatsService) GetChatMembersIDs(ctx context.Context, chatID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMembersIDs", ctx, chatID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMembersIDs indicates an expected call of GetChatMembersIDs.
+func (mr *MockChatsServiceMockRecorder) GetChatMembersIDs(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMembersIDs", reflect.TypeOf((*MockChatsService)(nil).GetChatMembersIDs), ctx, chatID)
+}
+
+// GetOrCreateDirectChat mocks base method.
+func (m *MockChatsService) GetOrCreateDirectChat(ctx context.Context, userID, interlocutorID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateDirectChat", ctx, userID, interlocutorID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateDirectChat indicates an expected call of GetOrCreateDirectChat.
+func (mr *MockChatsServiceMockRecorder) GetOrCreateDirectChat(ctx, userID, interlocutorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateDirectChat", reflect.TypeOf((*MockChatsService)(nil).GetOrCreateDirectChat), ctx, userID, interlocutorID)
+}
+
+// GetUnreadThreadsCount mocks base method.
+func (m *MockChatsService) GetUnreadThreadsCount(ctx context.Context, chatID, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadThreadsCount", ctx, chatID, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadThreadsCount indicates an expected call of GetUnreadThreadsCount.
+func (mr *MockChatsServiceMockRecorder) GetUnreadThreadsCount(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadThreadsCount", reflect.TypeOf((*MockChatsService)(nil).GetUnreadThreadsCount), ctx, chatID, userID)
+}
+
+// GetUserChats mocks base method.
+func (m *MockChatsService) GetUserChats(ctx context.Context, userID uint64) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChats", ctx, userID)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChats indicates an expected call of GetUserChats.
+func (mr *MockChatsServiceMockRecorder) GetUserChats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChats", reflect.TypeOf((*MockChatsService)(nil).GetUserChats), ctx, userID)
+}
+
+// JoinChatByInvite mocks base method.
+func (m *MockChatsService) JoinChatByInvite(ctx context.Context, joinData domains.JoinChatByInviteDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinChatByInvite", ctx, joinData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinChatByInvite indicates an expected call of JoinChatByInvite.
+func (mr *MockChatsServiceMockRecorder) JoinChatByInvite(ctx, joinData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinChatByInvite", reflect.TypeOf((*MockChatsService)(nil).JoinChatByInvite), ctx, joinData)
+}
+
+// LeaveGroupChat mocks base method.
+func (m *MockChatsService) LeaveGroupChat(ctx context.Context, chatID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveGroupChat", ctx, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveGroupChat indicates an expected call of LeaveGroupChat.
+func (mr *MockChatsServiceMockRecorder) LeaveGroupChat(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroupChat", reflect.TypeOf((*MockChatsService)(nil).LeaveGroupChat), ctx, chatID, userID)
+}
+
+// RemoveGroupChatMember mocks base method.
+func (m *MockChatsService) RemoveGroupChatMember(ctx context.Context, memberData domains.ChangeChatMemberDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroupChatMember", ctx, memberData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveGroupChatMember indicates an expected call of RemoveGroupChatMember.
+func (mr *MockChatsServiceMockRecorder) RemoveGroupChatMember(ctx, memberData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroupChatMember", reflect.TypeOf((*MockChatsService)(nil).RemoveGroupChatMember), ctx, memberData)
+}
+
+// RevokeChatInvite mocks base method.
+func (m *MockChatsService) RevokeChatInvite(ctx context.Context, inviteData domains.RevokeChatInviteDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeChatInvite", ctx, inviteData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeChatInvite indicates an expected call of RevokeChatInvite.
+func (mr *MockChatsServiceMockRecorder) RevokeChatInvite(ctx, inviteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeChatInvite", reflect.TypeOf((*MockChatsService)(nil).RevokeChatInvite), ctx, inviteData)
+}
+
+// SaveChatDraft mocks base method.
+func (m *MockChatsService) SaveChatDraft(ctx context.Context, draftData domains.SaveChatDraftDTO) (*domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatDraft", ctx, draftData)
+	ret0, _ := ret[0].(*domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveChatDraft indicates an expected call of SaveChatDraft.
+func (mr *MockChatsServiceMockRecorder) SaveChatDraft(ctx, draftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatDraft", reflect.TypeOf((*MockChatsService)(nil).SaveChatDraft), ctx, draftData)
+}
+
+// SubscribeToChannel mocks base method.
+func (m *MockChatsService) SubscribeToChannel(ctx context.Context, chatID, userID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToChannel", ctx, chatID, userID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeToChannel indicates an expected call of SubscribeToChannel.
+func (mr *MockChatsServiceMockRecorder) SubscribeToChannel(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToChannel", reflect.TypeOf((*MockChatsService)(nil).SubscribeToChannel), ctx, chatID, userID)
+}
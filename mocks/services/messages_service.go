@@ -0,0 +1,508 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/messages_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,PresenceService,AttachmentsService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessagesService is a mock of MessagesService interface.
+type MockMessagesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessagesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMessagesServiceMockRecorder is the mock recorder for MockMessagesService.
+type MockMessagesServiceMockRecorder struct {
+	mock *MockMessagesService
+}
+
+// NewMockMessagesService creates a new mock instance.
+func NewMockMessagesService(ctrl *gomock.Controller) *MockMessagesService {
+	mock := &MockMessagesService{ctrl: ctrl}
+	mock.recorder = &MockMessagesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessagesService) EXPECT() *MockMessagesServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMessageReaction mocks base method.
+func (m *MockMessagesService) AddMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMessageReaction indicates an expected call of AddMessageReaction.
+func (mr *MockMessagesServiceMockRecorder) AddMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessageReaction", reflect.TypeOf((*MockMessagesService)(nil).AddMessageReaction), ctx, reactionData)
+}
+
+// ClaimLinkPreviews mocks base method.
+func (m *MockMessagesService) ClaimLinkPreviews(ctx context.Context, limit uint64, claimTimeout time.Duration) ([]domains.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimLinkPreviews", ctx, limit, claimTimeout)
+	ret0, _ := ret[0].([]domains.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimLinkPreviews indicates an expected call of ClaimLinkPreviews.
+func (mr *MockMessagesServiceMockRecorder) ClaimLinkPreviews(ctx, limit, claimTimeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimLinkPreviews", reflect.TypeOf((*MockMessagesService)(nil).ClaimLinkPreviews), ctx, limit, claimTimeout)
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessagesService) CreateMessage(ctx context.Context, messageData domains.CreateMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessagesServiceMockRecorder) CreateMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessagesService)(nil).CreateMessage), ctx, messageData)
+}
+
+// DeleteMessage mocks base method.
+func (m *MockMessagesService) DeleteMessage(ctx context.Context, messageData domains.DeleteMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessagesServiceMockRecorder) DeleteMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessagesService)(nil).DeleteMessage), ctx, messageData)
+}
+
+// DeleteScheduledMessage mocks base method.
+func (m *MockMessagesService) DeleteScheduledMessage(ctx context.Context, chatID, id, actorID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledMessage", ctx, chatID, id, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledMessage indicates an expected call of DeleteScheduledMessage.
+func (mr *MockMessagesServiceMockRecorder) DeleteScheduledMessage(ctx, chatID, id, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledMessage", reflect.TypeOf((*MockMessagesService)(nil).DeleteScheduledMessage), ctx, chatID, id, actorID)
+}
+
+// FailScheduledMessage mocks base method.
+func (m *MockMessagesService) FailScheduledMessage(ctx context.Context, scheduledMessage domains.ScheduledMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailScheduledMessage", ctx, scheduledMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailScheduledMessage indicates an expected call of FailScheduledMessage.
+func (mr *MockMessagesServiceMockRecorder) FailScheduledMessage(ctx, scheduledMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailScheduledMessage", reflect.TypeOf((*MockMessagesService)(nil).FailScheduledMessage), ctx, scheduledMessage)
+}
+
+// ForwardMessages mocks base method.
+func (m *MockMessagesService) ForwardMessages(ctx context.Context, forwardData domains.ForwardMessagesDTO) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardMessages", ctx, forwardData)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForwardMessages indicates an expected call of ForwardMessages.
+func (mr *MockMessagesServiceMockRecorder) ForwardMessages(ctx, forwardData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardMessages", reflect.TypeOf((*MockMessagesService)(nil).ForwardMessages), ctx, forwardData)
+}
+
+// GetChatMessageByID mocks base method.
+func (m *MockMessagesService) GetChatMessageByID(ctx context.Context, chatID, userID, id uint64) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessageByID", ctx, chatID, userID, id)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessageByID indicates an expected call of GetChatMessageByID.
+func (mr *MockMessagesServiceMockRecorder) GetChatMessageByID(ctx, chatID, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessageByID", reflect.TypeOf((*MockMessagesService)(nil).GetChatMessageByID), ctx, chatID, userID, id)
+}
+
+// GetChatMessages mocks base method.
+func (m *MockMessagesService) GetChatMessages(ctx context.Context, chatID, userID uint64, cursor domains.MessagesCursor) (*domains.MessagesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessages", ctx, chatID, userID, cursor)
+	ret0, _ := ret[0].(*domains.MessagesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessages indicates an expected call of GetChatMessages.
+func (mr *MockMessagesServiceMockRecorder) GetChatMessages(ctx, chatID, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessages", reflect.TypeOf((*MockMessagesService)(nil).GetChatMessages), ctx, chatID, userID, cursor)
+}
+
+// GetChatPins mocks base method.
+func (m *MockMessagesService) GetChatPins(ctx context.Context, chatID, userID uint64) ([]domains.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatPins", ctx, chatID, userID)
+	ret0, _ := ret[0].([]domains.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatPins indicates an expected call of GetChatPins.
+func (mr *MockMessagesServiceMockRecorder) GetChatPins(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatPins", reflect.TypeOf((*MockMessagesService)(nil).GetChatPins), ctx, chatID, userID)
+}
+
+// GetDueScheduledMessages mocks base method.
+func (m *MockMessagesService) GetDueScheduledMessages(ctx context.Context, now time.Time, limit uint64) ([]domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduledMessages", ctx, now, limit)
+	ret0, _ := ret[0].([]domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduledMessages indicates an expected call of GetDueScheduledMessages.
+func (mr *MockMessagesServiceMockRecorder) GetDueScheduledMessages(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduledMessages", reflect.TypeOf((*MockMessagesService)(nil).GetDueScheduledMessages), ctx, now, limit)
+}
+
+// GetMessageByID mocks base method.
+func (m *MockMessagesService) GetMessageByID(ctx context.Context, id uint64) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockMessagesServiceMockRecorder) GetMessageByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockMessagesService)(nil).GetMessageByID), ctx, id)
+}
+
+// GetMessageMentions mocks base method.
+func (m *MockMessagesService) GetMessageMentions(ctx context.Context, messageID uint64) ([]domains.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageMentions", ctx, messageID)
+	ret0, _ := ret[0].([]domains.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageMentions indicates an expected call of GetMessageMentions.
+func (mr *MockMessagesServiceMockRecorder) GetMessageMentions(ctx, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageMentions", reflect.TypeOf((*MockMessagesService)(nil).GetMessageMentions), ctx, messageID)
+}
+
+// GetThreadMessages mocks base method.
+func (m *MockMessagesService) GetThreadMessages(ctx context.Context, chatID, threadRootID, userID uint64, cursor domains.MessagesCursor) (*domains.ThreadPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadMessages", ctx, chatID, threadRootID, userID, cursor)
+	ret0, _ := ret[0].(*domains.ThreadPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadMessages indicates an expected call of GetThreadMessages.
+func (mr *MockMessagesServiceMockRecorder) GetThreadMessages(ctx, chatID, threadRootID, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadMessages", reflect.TypeOf((*MockMessagesService)(nil).GetThreadMessages), ctx, chatID, threadRootID, userID, cursor)
+}
+
+// GetUserMentions mocks base method.
+func (m *MockMessagesService) GetUserMentions(ctx context.Context, userID uint64, cursor domains.MentionsCursor) (*domains.MentionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMentions", ctx, userID, cursor)
+	ret0, _ := ret[0].(*domains.MentionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMentions indicates an expected call of GetUserMentions.
+func (mr *MockMessagesServiceMockRecorder) GetUserMentions(ctx, userID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockMessagesService)(nil).GetUserMentions), ctx, userID, cursor)
+}
+
+// GetUserScheduledMessages mocks base method.
+func (m *MockMessagesService) GetUserScheduledMessages(ctx context.Context, chatID, userID uint64) ([]domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserScheduledMessages", ctx, chatID, userID)
+	ret0, _ := ret[0].([]domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserScheduledMessages indicates an expected call of GetUserScheduledMessages.
+func (mr *MockMessagesServiceMockRecorder) GetUserScheduledMessages(ctx, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserScheduledMessages", reflect.TypeOf((*MockMessagesService)(nil).GetUserScheduledMessages), ctx, chatID, userID)
+}
+
+// PinMessage mocks base method.
+func (m *MockMessagesService) PinMessage(ctx context.Context, pinData domains.PinMessageDTO) (*domains.Pin, *domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinMessage", ctx, pinData)
+	ret0, _ := ret[0].(*domains.Pin)
+	ret1, _ := ret[1].(*domains.Message)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PinMessage indicates an expected call of PinMessage.
+func (mr *MockMessagesServiceMockRecorder) PinMessage(ctx, pinData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinMessage", reflect.TypeOf((*MockMessagesService)(nil).PinMessage), ctx, pinData)
+}
+
+// PurgeExpiredMessages mocks base method.
+func (m *MockMessagesService) PurgeExpiredMessages(ctx context.Context, expiredBefore time.Time, limit uint64) (*domains.MessagesPurge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredMessages", ctx, expiredBefore, limit)
+	ret0, _ := ret[0].(*domains.MessagesPurge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredMessages indicates an expected call of PurgeExpiredMessages.
+func (mr *MockMessagesServiceMockRecorder) PurgeExpiredMessages(ctx, expiredBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredMessages", reflect.TypeOf((*MockMessagesService)(nil).PurgeExpiredMessages), ctx, expiredBefore, limit)
+}
+
+// ReadChatMessages mocks base method.
+func (m *MockMessagesService) ReadChatMessages(ctx context.Context, chatID, userID, messageID uint64) (*domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadChatMessages", ctx, chatID, userID, messageID)
+	ret0, _ := ret[0].(*domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadChatMessages indicates an expected call of ReadChatMessages.
+func (mr *MockMessagesServiceMockRecorder) ReadChatMessages(ctx, chatID, userID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadChatMessages", reflect.TypeOf((*MockMessagesService)(nil).ReadChatMessages), ctx, chatID, userID, messageID)
+}
+
+// ReadThreadMessages mocks base method.
+func (m *MockMessagesService) ReadThreadMessages(ctx context.Context, chatID, threadRootID, userID, messageID uint64) (*domains.ThreadParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadThreadMessages", ctx, chatID, threadRootID, userID, messageID)
+	ret0, _ := ret[0].(*domains.ThreadParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadThreadMessages indicates an expected call of ReadThreadMessages.
+func (mr *MockMessagesServiceMockRecorder) ReadThreadMessages(ctx, chatID, threadRootID, userID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadThreadMessages", reflect.TypeOf((*MockMessagesService)(nil).ReadThreadMessages), ctx, chatID, threadRootID, userID, messageID)
+}
+
+// RemoveMessageReaction mocks base method.
+func (m *MockMessagesService) RemoveMessageReaction(ctx context.Context, reactionData domains.MessageReactionDTO) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMessageReaction indicates an expected call of RemoveMessageReaction.
+func (mr *MockMessagesServiceMockRecorder) RemoveMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMessageReaction", reflect.TypeOf((*MockMessagesService)(nil).RemoveMessageReaction), ctx, reactionData)
+}
+
+// RetractPollVote mocks base method.
+func (m *MockMessagesService) RetractPollVote(ctx context.Context, voteData domains.PollVoteDTO) (*domains.Poll, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractPollVote", ctx, voteData)
+	ret0, _ := ret[0].(*domains.Poll)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetractPollVote indicates an expected call of RetractPollVote.
+func (mr *MockMessagesServiceMockRecorder) RetractPollVote(ctx, voteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractPollVote", reflect.TypeOf((*MockMessagesService)(nil).RetractPollVote), ctx, voteData)
+}
+
+// SaveLinkPreview mocks base method.
+func (m *MockMessagesService) SaveLinkPreview(ctx context.Context, previewData domains.UpdateLinkPreviewDTO) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLinkPreview", ctx, previewData)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveLinkPreview indicates an expected call of SaveLinkPreview.
+func (mr *MockMessagesServiceMockRecorder) SaveLinkPreview(ctx, previewData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLinkPreview", reflect.TypeOf((*MockMessagesService)(nil).SaveLinkPreview), ctx, previewData)
+}
+
+// ScheduleMessage mocks base method.
+func (m *MockMessagesService) ScheduleMessage(ctx context.Context, messageData domains.ScheduleMessageDTO) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleMessage indicates an expected call of ScheduleMessage.
+func (mr *MockMessagesServiceMockRecorder) ScheduleMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleMessage", reflect.TypeOf((*MockMessagesService)(nil).ScheduleMessage), ctx, messageData)
+}
+
+// SearchMessages mocks base method.
+func (m *MockMessagesService) SearchMessages(ctx context.Context, searchData domains.SearchMessagesDTO) (*domains.MessagesSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessages", ctx, searchData)
+	ret0, _ := ret[0].(*domains.MessagesSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessages indicates an expected call of SearchMessages.
+func (mr *MockMessagesServiceMockRecorder) SearchMessages(ctx, searchData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessages", reflect.TypeOf((*MockMessagesService)(nil).SearchMessages), ctx, searchData)
+}
+
+// SendScheduledMessage mocks base method.
+func (m *MockMessagesService) SendScheduledMessage(ctx context.Context, scheduledMessage domains.ScheduledMessage, messageData domains.CreateMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendScheduledMessage", ctx, scheduledMessage, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendScheduledMessage indicates an expected call of SendScheduledMessage.
+func (mr *MockMessagesServiceMockRecorder) SendScheduledMessage(ctx, scheduledMessage, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendScheduledMessage", reflect.TypeOf((*MockMessagesService)(nil).SendScheduledMessage), ctx, scheduledMessage, messageData)
+}
+
+// SetChatMessageTTL mocks base method.
+func (m *MockMessagesService) SetChatMessageTTL(ctx context.Context, ttlData domains.SetChatMessageTTLDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatMessageTTL", ctx, ttlData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChatMessageTTL indicates an expected call of SetChatMessageTTL.
+func (mr *MockMessagesServiceMockRecorder) SetChatMessageTTL(ctx, ttlData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatMessageTTL", reflect.TypeOf((*MockMessagesService)(nil).SetChatMessageTTL), ctx, ttlData)
+}
+
+// UnpinMessage mocks base method.
+func (m *MockMessagesService) UnpinMessage(ctx context.Context, pinData domains.UnpinMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinMessage", ctx, pinData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinMessage indicates an expected call of UnpinMessage.
+func (mr *MockMessagesServiceMockRecorder) UnpinMessage(ctx, pinData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinMessage", reflect.TypeOf((*MockMessagesService)(nil).UnpinMessage), ctx, pinData)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockMessagesService) UpdateMessage(ctx context.Context, messageData domains.UpdateMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockMessagesServiceMockRecorder) UpdateMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockMessagesService)(nil).UpdateMessage), ctx, messageData)
+}
+
+// UpdateScheduledMessage mocks base method.
+func (m *MockMessagesService) UpdateScheduledMessage(ctx context.Context, messageData domains.UpdateScheduledMessageDTO) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledMessage indicates an expected call of UpdateScheduledMessage.
+func (mr *MockMessagesServiceMockRecorder) UpdateScheduledMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledMessage", reflect.TypeOf((*MockMessagesService)(nil).UpdateScheduledMessage), ctx, messageData)
+}
+
+// VotePoll mocks base method.
+func (m *MockMessagesService) VotePoll(ctx context.Context, voteData domains.PollVoteDTO) (*domains.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VotePoll", ctx, voteData)
+	ret0, _ := ret[0].(*domains.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VotePoll indicates an expected call of VotePoll.
+func (mr *MockMessagesServiceMockRecorder) VotePoll(ctx, voteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VotePoll", reflect.TypeOf((*MockMessagesService)(nil).VotePoll), ctx, voteData)
+}
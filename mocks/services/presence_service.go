@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=../../mocks/services/presence_service.go -package=mockservices -exclude_interfaces=UsersService,AuthService,ChatsService,MessagesService,AttachmentsService
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresenceService is a mock of PresenceService interface.
+type MockPresenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceServiceMockRecorder is the mock recorder for MockPresenceService.
+type MockPresenceServiceMockRecorder struct {
+	mock *MockPresenceService
+}
+
+// NewMockPresenceService creates a new mock instance.
+func NewMockPresenceService(ctrl *gomock.Controller) *MockPresenceService {
+	mock := &MockPresenceService{ctrl: ctrl}
+	mock.recorder = &MockPresenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceService) EXPECT() *MockPresenceServiceMockRecorder {
+	return m.recorder
+}
+
+// AddConnection mocks base method.
+func (m *MockPresenceService) AddConnection(ctx context.Context, userID uint64, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConnection", ctx, userID, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConnection indicates an expected call of AddConnection.
+func (mr *MockPresenceServiceMockRecorder) AddConnection(ctx, userID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConnection", reflect.TypeOf((*MockPresenceService)(nil).AddConnection), ctx, userID, ttl)
+}
+
+// GetPresence mocks base method.
+func (m *MockPresenceService) GetPresence(ctx context.Context, userID uint64) (*domains.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresence", ctx, userID)
+	ret0, _ := ret[0].(*domains.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresence indicates an expected call of GetPresence.
+func (mr *MockPresenceServiceMockRecorder) GetPresence(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresence", reflect.TypeOf((*MockPresenceService)(nil).GetPresence), ctx, userID)
+}
+
+// GetPresences mocks base method.
+func (m *MockPresenceService) GetPresences(ctx context.Context, userIDs []uint64) ([]domains.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresences", ctx, userIDs)
+	ret0, _ := ret[0].([]domains.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresences indicates an expected call of GetPresences.
+func (mr *MockPresenceServiceMockRecorder) GetPresences(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresences", reflect.TypeOf((*MockPresenceService)(nil).GetPresences), ctx, userIDs)
+}
+
+// ProlongConnections mocks base method.
+func (m *MockPresenceService) ProlongConnections(ctx context.Context, userID uint64, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProlongConnections", ctx, userID, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProlongConnections indicates an expected call of ProlongConnections.
+func (mr *MockPresenceServiceMockRecorder) ProlongConnections(ctx, userID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProlongConnections", reflect.TypeOf((*MockPresenceService)(nil).ProlongConnections), ctx, userID, ttl)
+}
+
+// RemoveConnection mocks base method.
+func (m *MockPresenceService) RemoveConnection(ctx context.Context, userID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveConnection", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveConnection indicates an expected call of RemoveConnection.
+func (mr *MockPresenceServiceMockRecorder) RemoveConnection(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveConnection", reflect.TypeOf((*MockPresenceService)(nil).RemoveConnection), ctx, userID)
+}
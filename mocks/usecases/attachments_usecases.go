@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/attachments_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases
+//
+
+// Package mockusecases is a generated GoMock package.
+package mockusecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentsUseCases is a mock of AttachmentsUseCases interface.
+type MockAttachmentsUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsUseCasesMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentsUseCasesMockRecorder is the mock recorder for MockAttachmentsUseCases.
+type MockAttachmentsUseCasesMockRecorder struct {
+	mock *MockAttachmentsUseCases
+}
+
+// NewMockAttachmentsUseCases creates a new mock instance.
+func NewMockAttachmentsUseCases(ctrl *gomock.Controller) *MockAttachmentsUseCases {
+	mock := &MockAttachmentsUseCases{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentsUseCases) EXPECT() *MockAttachmentsUseCasesMockRecorder {
+	return m.recorder
+}
+
+// DownloadAttachment mocks base method.
+func (m *MockAttachmentsUseCases) DownloadAttachment(ctx context.Context, accessToken string, chatID, attachmentID uint64) (*domains.AttachmentDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadAttachment", ctx, accessToken, chatID, attachmentID)
+	ret0, _ := ret[0].(*domains.AttachmentDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAttachment indicates an expected call of DownloadAttachment.
+func (mr *MockAttachmentsUseCasesMockRecorder) DownloadAttachment(ctx, accessToken, chatID, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAttachment", reflect.TypeOf((*MockAttachmentsUseCases)(nil).DownloadAttachment), ctx, accessToken, chatID, attachmentID)
+}
+
+// DownloadAttachmentThumbnail mocks base method.
+func (m *MockAttachmentsUseCases) DownloadAttachmentThumbnail(ctx context.Context, accessToken string, chatID, attachmentID uint64, thumbnailName domains.ThumbnailName) (*domains.AttachmentDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadAttachmentThumbnail", ctx, accessToken, chatID, attachmentID, thumbnailName)
+	ret0, _ := ret[0].(*domains.AttachmentDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAttachmentThumbnail indicates an expected call of DownloadAttachmentThumbnail.
+func (mr *MockAttachmentsUseCasesMockRecorder) DownloadAttachmentThumbnail(ctx, accessToken, chatID, attachmentID, thumbnailName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAttachmentThumbnail", reflect.TypeOf((*MockAttachmentsUseCases)(nil).DownloadAttachmentThumbnail), ctx, accessToken, chatID, attachmentID, thumbnailName)
+}
+
+// ProcessAttachmentsPreviews mocks base method.
+func (m *MockAttachmentsUseCases) ProcessAttachmentsPreviews(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAttachmentsPreviews", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessAttachmentsPreviews indicates an expected call of ProcessAttachmentsPreviews.
+func (mr *MockAttachmentsUseCasesMockRecorder) ProcessAttachmentsPreviews(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAttachmentsPreviews", reflect.TypeOf((*MockAttachmentsUseCases)(nil).ProcessAttachmentsPreviews), ctx)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockAttachmentsUseCases) UploadAttachment(ctx context.Context, attachmentData domains.RawUploadAttachmentDTO) (*domains.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, attachmentData)
+	ret0, _ := ret[0].(*domains.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockAttachmentsUseCasesMockRecorder) UploadAttachment(ctx, attachmentData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAttachmentsUseCases)(nil).UploadAttachment), ctx, attachmentData)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=usecases.go -destination=../../mocks/usecases/auth_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/auth_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
 //
 
 // Package mockusecases is a generated GoMock package.
@@ -114,10 +114,10 @@ func (mr *MockAuthUseCasesMockRecorder) RefreshTokens(ctx, refreshToken any) *go
 }
 
 // RegisterUser mocks base method.
-func (m *MockAuthUseCases) RegisterUser(ctx context.Context, userData domains.RegisterDTO) (uint64, error) {
+func (m *MockAuthUseCases) RegisterUser(ctx context.Context, userData domains.RegisterDTO) (*domains.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterUser", ctx, userData)
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(*domains.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -156,16 +156,16 @@ func (mr *MockAuthUseCasesMockRecorder) SendVerifyEmailMessage(ctx, email any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerifyEmailMessage", reflect.TypeOf((*MockAuthUseCases)(nil).SendVerifyEmailMessage), ctx, email)
 }
 
-// VerifyUserEmail mocks base method.
-func (m *MockAuthUseCases) VerifyUserEmail(ctx context.Context, verifyEmailToken string) error {
+// VerifyEmail mocks base method.
+func (m *MockAuthUseCases) VerifyEmail(ctx context.Context, verifyEmailToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyUserEmail", ctx, verifyEmailToken)
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, verifyEmailToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// VerifyUserEmail indicates an expected call of VerifyUserEmail.
-func (mr *MockAuthUseCasesMockRecorder) VerifyUserEmail(ctx, verifyEmailToken any) *gomock.Call {
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthUseCasesMockRecorder) VerifyEmail(ctx, verifyEmailToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockAuthUseCases)(nil).VerifyUserEmail), ctx, verifyEmailToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthUseCases)(nil).VerifyEmail), ctx, verifyEmailToken)
 }
@@ -0,0 +1,324 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/chats_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
+//
+
+// Package mockusecases is a generated GoMock package.
+package mockusecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatsUseCases is a mock of ChatsUseCases interface.
+type MockChatsUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatsUseCasesMockRecorder
+	isgomock struct{}
+}
+
+// MockChatsUseCasesMockRecorder is the mock recorder for MockChatsUseCases.
+type MockChatsUseCasesMockRecorder struct {
+	mock *MockChatsUseCases
+}
+
+// NewMockChatsUseCases creates a new mock instance.
+func NewMockChatsUseCases(ctrl *gomock.Controller) *MockChatsUseCases {
+	mock := &MockChatsUseCases{ctrl: ctrl}
+	mock.recorder = &MockChatsUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatsUseCases) EXPECT() *MockChatsUseCasesMockRecorder {
+	return m.recorder
+}
+
+// AddChatMembers mocks base method.
+func (m *MockChatsUseCases) AddChatMembers(ctx context.Context, accessToken string, chatID uint64, userIDs []uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChatMembers", ctx, accessToken, chatID, userIDs)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChatMembers indicates an expected call of AddChatMembers.
+func (mr *MockChatsUseCasesMockRecorder) AddChatMembers(ctx, accessToken, chatID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChatMembers", reflect.TypeOf((*MockChatsUseCases)(nil).AddChatMembers), ctx, accessToken, chatID, userIDs)
+}
+
+// CreateChannel mocks base method.
+func (m *MockChatsUseCases) CreateChannel(ctx context.Context, channelData domains.RawCreateChannelDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", ctx, channelData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockChatsUseCasesMockRecorder) CreateChannel(ctx, channelData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChatsUseCases)(nil).CreateChannel), ctx, channelData)
+}
+
+// CreateChatInvite mocks base method.
+func (m *MockChatsUseCases) CreateChatInvite(ctx context.Context, inviteData domains.RawCreateChatInviteDTO) (*domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatInvite", ctx, inviteData)
+	ret0, _ := ret[0].(*domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChatInvite indicates an expected call of CreateChatInvite.
+func (mr *MockChatsUseCasesMockRecorder) CreateChatInvite(ctx, inviteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatInvite", reflect.TypeOf((*MockChatsUseCases)(nil).CreateChatInvite), ctx, inviteData)
+}
+
+// CreateDirectChat mocks base method.
+func (m *MockChatsUseCases) CreateDirectChat(ctx context.Context, accessToken string, interlocutorID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDirectChat", ctx, accessToken, interlocutorID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDirectChat indicates an expected call of CreateDirectChat.
+func (mr *MockChatsUseCasesMockRecorder) CreateDirectChat(ctx, accessToken, interlocutorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectChat", reflect.TypeOf((*MockChatsUseCases)(nil).CreateDirectChat), ctx, accessToken, interlocutorID)
+}
+
+// CreateGroupChat mocks base method.
+func (m *MockChatsUseCases) CreateGroupChat(ctx context.Context, chatData domains.RawCreateGroupChatDTO) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupChat", ctx, chatData)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupChat indicates an expected call of CreateGroupChat.
+func (mr *MockChatsUseCasesMockRecorder) CreateGroupChat(ctx, chatData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupChat", reflect.TypeOf((*MockChatsUseCases)(nil).CreateGroupChat), ctx, chatData)
+}
+
+// DeleteChatDraft mocks base method.
+func (m *MockChatsUseCases) DeleteChatDraft(ctx context.Context, accessToken string, chatID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatDraft", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChatDraft indicates an expected call of DeleteChatDraft.
+func (mr *MockChatsUseCasesMockRecorder) DeleteChatDraft(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatDraft", reflect.TypeOf((*MockChatsUseCases)(nil).DeleteChatDraft), ctx, accessToken, chatID)
+}
+
+// DemoteChatMember mocks base method.
+func (m *MockChatsUseCases) DemoteChatMember(ctx context.Context, accessToken string, chatID, memberID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DemoteChatMember", ctx, accessToken, chatID, memberID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DemoteChatMember indicates an expected call of DemoteChatMember.
+func (mr *MockChatsUseCasesMockRecorder) DemoteChatMember(ctx, accessToken, chatID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoteChatMember", reflect.TypeOf((*MockChatsUseCases)(nil).DemoteChatMember), ctx, accessToken, chatID, memberID)
+}
+
+// GetChannels mocks base method.
+func (m *MockChatsUseCases) GetChannels(ctx context.Context, filters *domains.ChannelsFilters, pagination *domains.Pagination) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ctx, filters, pagination)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockChatsUseCasesMockRecorder) GetChannels(ctx, filters, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockChatsUseCases)(nil).GetChannels), ctx, filters, pagination)
+}
+
+// GetChatByID mocks base method.
+func (m *MockChatsUseCases) GetChatByID(ctx context.Context, accessToken string, chatID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatByID", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatByID indicates an expected call of GetChatByID.
+func (mr *MockChatsUseCasesMockRecorder) GetChatByID(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatByID", reflect.TypeOf((*MockChatsUseCases)(nil).GetChatByID), ctx, accessToken, chatID)
+}
+
+// GetChatDraft mocks base method.
+func (m *MockChatsUseCases) GetChatDraft(ctx context.Context, accessToken string, chatID uint64) (*domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatDraft", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(*domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatDraft indicates an expected call of GetChatDraft.
+func (mr *MockChatsUseCasesMockRecorder) GetChatDraft(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatDraft", reflect.TypeOf((*MockChatsUseCases)(nil).GetChatDraft), ctx, accessToken, chatID)
+}
+
+// GetChatInvites mocks base method.
+func (m *MockChatsUseCases) GetChatInvites(ctx context.Context, accessToken string, chatID uint64) ([]domains.ChatInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatInvites", ctx, accessToken, chatID)
+	ret0, _ := ret[0].([]domains.ChatInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatInvites indicates an expected call of GetChatInvites.
+func (mr *MockChatsUseCasesMockRecorder) GetChatInvites(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatInvites", reflect.TypeOf((*MockChatsUseCases)(nil).GetChatInvites), ctx, accessToken, chatID)
+}
+
+// GetChats mocks base method.
+func (m *MockChatsUseCases) GetChats(ctx context.Context, accessToken string) ([]domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChats", ctx, accessToken)
+	ret0, _ := ret[0].([]domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChats indicates an expected call of GetChats.
+func (mr *MockChatsUseCasesMockRecorder) GetChats(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChats", reflect.TypeOf((*MockChatsUseCases)(nil).GetChats), ctx, accessToken)
+}
+
+// JoinChatByInvite mocks base method.
+func (m *MockChatsUseCases) JoinChatByInvite(ctx context.Context, accessToken, token string) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinChatByInvite", ctx, accessToken, token)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinChatByInvite indicates an expected call of JoinChatByInvite.
+func (mr *MockChatsUseCasesMockRecorder) JoinChatByInvite(ctx, accessToken, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinChatByInvite", reflect.TypeOf((*MockChatsUseCases)(nil).JoinChatByInvite), ctx, accessToken, token)
+}
+
+// LeaveChat mocks base method.
+func (m *MockChatsUseCases) LeaveChat(ctx context.Context, accessToken string, chatID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveChat", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveChat indicates an expected call of LeaveChat.
+func (mr *MockChatsUseCasesMockRecorder) LeaveChat(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveChat", reflect.TypeOf((*MockChatsUseCases)(nil).LeaveChat), ctx, accessToken, chatID)
+}
+
+// PromoteChatMember mocks base method.
+func (m *MockChatsUseCases) PromoteChatMember(ctx context.Context, accessToken string, chatID, memberID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteChatMember", ctx, accessToken, chatID, memberID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteChatMember indicates an expected call of PromoteChatMember.
+func (mr *MockChatsUseCasesMockRecorder) PromoteChatMember(ctx, accessToken, chatID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteChatMember", reflect.TypeOf((*MockChatsUseCases)(nil).PromoteChatMember), ctx, accessToken, chatID, memberID)
+}
+
+// RemoveChatMember mocks base method.
+func (m *MockChatsUseCases) RemoveChatMember(ctx context.Context, accessToken string, chatID, memberID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveChatMember", ctx, accessToken, chatID, memberID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveChatMember indicates an expected call of RemoveChatMember.
+func (mr *MockChatsUseCasesMockRecorder) RemoveChatMember(ctx, accessToken, chatID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChatMember", reflect.TypeOf((*MockChatsUseCases)(nil).RemoveChatMember), ctx, accessToken, chatID, memberID)
+}
+
+// RevokeChatInvite mocks base method.
+func (m *MockChatsUseCases) RevokeChatInvite(ctx context.Context, accessToken string, chatID, inviteID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeChatInvite", ctx, accessToken, chatID, inviteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeChatInvite indicates an expected call of RevokeChatInvite.
+func (mr *MockChatsUseCasesMockRecorder) RevokeChatInvite(ctx, accessToken, chatID, inviteID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeChatInvite", reflect.TypeOf((*MockChatsUseCases)(nil).RevokeChatInvite), ctx, accessToken, chatID, inviteID)
+}
+
+// SaveChatDraft mocks base method.
+func (m *MockChatsUseCases) SaveChatDraft(ctx context.Context, draftData domains.RawSaveChatDraftDTO) (*domains.ChatDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatDraft", ctx, draftData)
+	ret0, _ := ret[0].(*domains.ChatDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveChatDraft indicates an expected call of SaveChatDraft.
+func (mr *MockChatsUseCasesMockRecorder) SaveChatDraft(ctx, draftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatDraft", reflect.TypeOf((*MockChatsUseCases)(nil).SaveChatDraft), ctx, draftData)
+}
+
+// SubscribeToChannel mocks base method.
+func (m *MockChatsUseCases) SubscribeToChannel(ctx context.Context, accessToken string, chatID uint64) (*domains.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToChannel", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(*domains.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeToChannel indicates an expected call of SubscribeToChannel.
+func (mr *MockChatsUseCasesMockRecorder) SubscribeToChannel(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToChannel", reflect.TypeOf((*MockChatsUseCases)(nil).SubscribeToChannel), ctx, accessToken, chatID)
+}
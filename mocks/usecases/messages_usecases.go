@@ -0,0 +1,440 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/messages_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,PresenceUseCases,AttachmentsUseCases
+//
+
+// Package mockusecases is a generated GoMock package.
+package mockusecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/DKhorkov/kfc/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessagesUseCases is a mock of MessagesUseCases interface.
+type MockMessagesUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessagesUseCasesMockRecorder
+	isgomock struct{}
+}
+
+// MockMessagesUseCasesMockRecorder is the mock recorder for MockMessagesUseCases.
+type MockMessagesUseCasesMockRecorder struct {
+	mock *MockMessagesUseCases
+}
+
+// NewMockMessagesUseCases creates a new mock instance.
+func NewMockMessagesUseCases(ctrl *gomock.Controller) *MockMessagesUseCases {
+	mock := &MockMessagesUseCases{ctrl: ctrl}
+	mock.recorder = &MockMessagesUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessagesUseCases) EXPECT() *MockMessagesUseCasesMockRecorder {
+	return m.recorder
+}
+
+// AddMessageReaction mocks base method.
+func (m *MockMessagesUseCases) AddMessageReaction(ctx context.Context, reactionData domains.RawMessageReactionDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMessageReaction indicates an expected call of AddMessageReaction.
+func (mr *MockMessagesUseCasesMockRecorder) AddMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessageReaction", reflect.TypeOf((*MockMessagesUseCases)(nil).AddMessageReaction), ctx, reactionData)
+}
+
+// CancelScheduledMessage mocks base method.
+func (m *MockMessagesUseCases) CancelScheduledMessage(ctx context.Context, accessToken string, chatID, scheduledMessageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledMessage", ctx, accessToken, chatID, scheduledMessageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduledMessage indicates an expected call of CancelScheduledMessage.
+func (mr *MockMessagesUseCasesMockRecorder) CancelScheduledMessage(ctx, accessToken, chatID, scheduledMessageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).CancelScheduledMessage), ctx, accessToken, chatID, scheduledMessageID)
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessagesUseCases) CreateMessage(ctx context.Context, messageData domains.RawCreateMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessagesUseCasesMockRecorder) CreateMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).CreateMessage), ctx, messageData)
+}
+
+// CreatePoll mocks base method.
+func (m *MockMessagesUseCases) CreatePoll(ctx context.Context, pollData domains.RawCreatePollDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, pollData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePoll indicates an expected call of CreatePoll.
+func (mr *MockMessagesUseCasesMockRecorder) CreatePoll(ctx, pollData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockMessagesUseCases)(nil).CreatePoll), ctx, pollData)
+}
+
+// DeleteMessage mocks base method.
+func (m *MockMessagesUseCases) DeleteMessage(ctx context.Context, messageData domains.RawDeleteMessageDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", ctx, messageData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessagesUseCasesMockRecorder) DeleteMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).DeleteMessage), ctx, messageData)
+}
+
+// ForwardMessages mocks base method.
+func (m *MockMessagesUseCases) ForwardMessages(ctx context.Context, forwardData domains.RawForwardMessagesDTO) ([]domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardMessages", ctx, forwardData)
+	ret0, _ := ret[0].([]domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForwardMessages indicates an expected call of ForwardMessages.
+func (mr *MockMessagesUseCasesMockRecorder) ForwardMessages(ctx, forwardData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).ForwardMessages), ctx, forwardData)
+}
+
+// GetChatMessages mocks base method.
+func (m *MockMessagesUseCases) GetChatMessages(ctx context.Context, accessToken string, chatID uint64, cursor domains.MessagesCursor) (*domains.MessagesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessages", ctx, accessToken, chatID, cursor)
+	ret0, _ := ret[0].(*domains.MessagesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessages indicates an expected call of GetChatMessages.
+func (mr *MockMessagesUseCasesMockRecorder) GetChatMessages(ctx, accessToken, chatID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).GetChatMessages), ctx, accessToken, chatID, cursor)
+}
+
+// GetChatPins mocks base method.
+func (m *MockMessagesUseCases) GetChatPins(ctx context.Context, accessToken string, chatID uint64) ([]domains.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatPins", ctx, accessToken, chatID)
+	ret0, _ := ret[0].([]domains.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatPins indicates an expected call of GetChatPins.
+func (mr *MockMessagesUseCasesMockRecorder) GetChatPins(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatPins", reflect.TypeOf((*MockMessagesUseCases)(nil).GetChatPins), ctx, accessToken, chatID)
+}
+
+// GetMentions mocks base method.
+func (m *MockMessagesUseCases) GetMentions(ctx context.Context, accessToken string, cursor domains.MentionsCursor) (*domains.MentionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", ctx, accessToken, cursor)
+	ret0, _ := ret[0].(*domains.MentionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockMessagesUseCasesMockRecorder) GetMentions(ctx, accessToken, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockMessagesUseCases)(nil).GetMentions), ctx, accessToken, cursor)
+}
+
+// GetScheduledMessages mocks base method.
+func (m *MockMessagesUseCases) GetScheduledMessages(ctx context.Context, accessToken string, chatID uint64) ([]domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledMessages", ctx, accessToken, chatID)
+	ret0, _ := ret[0].([]domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledMessages indicates an expected call of GetScheduledMessages.
+func (mr *MockMessagesUseCasesMockRecorder) GetScheduledMessages(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).GetScheduledMessages), ctx, accessToken, chatID)
+}
+
+// GetThreadMessages mocks base method.
+func (m *MockMessagesUseCases) GetThreadMessages(ctx context.Context, accessToken string, chatID, threadRootID uint64, cursor domains.MessagesCursor) (*domains.ThreadPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadMessages", ctx, accessToken, chatID, threadRootID, cursor)
+	ret0, _ := ret[0].(*domains.ThreadPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadMessages indicates an expected call of GetThreadMessages.
+func (mr *MockMessagesUseCasesMockRecorder) GetThreadMessages(ctx, accessToken, chatID, threadRootID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).GetThreadMessages), ctx, accessToken, chatID, threadRootID, cursor)
+}
+
+// PinMessage mocks base method.
+func (m *MockMessagesUseCases) PinMessage(ctx context.Context, accessToken string, chatID, messageID uint64) (*domains.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinMessage", ctx, accessToken, chatID, messageID)
+	ret0, _ := ret[0].(*domains.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinMessage indicates an expected call of PinMessage.
+func (mr *MockMessagesUseCasesMockRecorder) PinMessage(ctx, accessToken, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).PinMessage), ctx, accessToken, chatID, messageID)
+}
+
+// ProcessLinkPreviews mocks base method.
+func (m *MockMessagesUseCases) ProcessLinkPreviews(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessLinkPreviews", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessLinkPreviews indicates an expected call of ProcessLinkPreviews.
+func (mr *MockMessagesUseCasesMockRecorder) ProcessLinkPreviews(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessLinkPreviews", reflect.TypeOf((*MockMessagesUseCases)(nil).ProcessLinkPreviews), ctx)
+}
+
+// PurgeExpiredMessages mocks base method.
+func (m *MockMessagesUseCases) PurgeExpiredMessages(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredMessages", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredMessages indicates an expected call of PurgeExpiredMessages.
+func (mr *MockMessagesUseCasesMockRecorder) PurgeExpiredMessages(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).PurgeExpiredMessages), ctx)
+}
+
+// ReadChatMessages mocks base method.
+func (m *MockMessagesUseCases) ReadChatMessages(ctx context.Context, accessToken string, chatID, messageID uint64) (*domains.ChatMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadChatMessages", ctx, accessToken, chatID, messageID)
+	ret0, _ := ret[0].(*domains.ChatMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadChatMessages indicates an expected call of ReadChatMessages.
+func (mr *MockMessagesUseCasesMockRecorder) ReadChatMessages(ctx, accessToken, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadChatMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).ReadChatMessages), ctx, accessToken, chatID, messageID)
+}
+
+// ReadThreadMessages mocks base method.
+func (m *MockMessagesUseCases) ReadThreadMessages(ctx context.Context, accessToken string, chatID, threadRootID, messageID uint64) (*domains.ThreadParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadThreadMessages", ctx, accessToken, chatID, threadRootID, messageID)
+	ret0, _ := ret[0].(*domains.ThreadParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadThreadMessages indicates an expected call of ReadThreadMessages.
+func (mr *MockMessagesUseCasesMockRecorder) ReadThreadMessages(ctx, accessToken, chatID, threadRootID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadThreadMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).ReadThreadMessages), ctx, accessToken, chatID, threadRootID, messageID)
+}
+
+// RemoveMessageReaction mocks base method.
+func (m *MockMessagesUseCases) RemoveMessageReaction(ctx context.Context, reactionData domains.RawMessageReactionDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMessageReaction", ctx, reactionData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMessageReaction indicates an expected call of RemoveMessageReaction.
+func (mr *MockMessagesUseCasesMockRecorder) RemoveMessageReaction(ctx, reactionData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMessageReaction", reflect.TypeOf((*MockMessagesUseCases)(nil).RemoveMessageReaction), ctx, reactionData)
+}
+
+// RetractPollVote mocks base method.
+func (m *MockMessagesUseCases) RetractPollVote(ctx context.Context, accessToken string, chatID, messageID uint64) (*domains.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractPollVote", ctx, accessToken, chatID, messageID)
+	ret0, _ := ret[0].(*domains.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetractPollVote indicates an expected call of RetractPollVote.
+func (mr *MockMessagesUseCasesMockRecorder) RetractPollVote(ctx, accessToken, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractPollVote", reflect.TypeOf((*MockMessagesUseCases)(nil).RetractPollVote), ctx, accessToken, chatID, messageID)
+}
+
+// ScheduleMessage mocks base method.
+func (m *MockMessagesUseCases) ScheduleMessage(ctx context.Context, messageData domains.RawScheduleMessageDTO) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleMessage indicates an expected call of ScheduleMessage.
+func (mr *MockMessagesUseCasesMockRecorder) ScheduleMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).ScheduleMessage), ctx, messageData)
+}
+
+// SearchMessages mocks base method.
+func (m *MockMessagesUseCases) SearchMessages(ctx context.Context, searchData domains.RawSearchMessagesDTO) (*domains.MessagesSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessages", ctx, searchData)
+	ret0, _ := ret[0].(*domains.MessagesSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessages indicates an expected call of SearchMessages.
+func (mr *MockMessagesUseCasesMockRecorder) SearchMessages(ctx, searchData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).SearchMessages), ctx, searchData)
+}
+
+// SendScheduledMessages mocks base method.
+func (m *MockMessagesUseCases) SendScheduledMessages(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendScheduledMessages", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendScheduledMessages indicates an expected call of SendScheduledMessages.
+func (mr *MockMessagesUseCasesMockRecorder) SendScheduledMessages(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendScheduledMessages", reflect.TypeOf((*MockMessagesUseCases)(nil).SendScheduledMessages), ctx)
+}
+
+// SendTypingIndicator mocks base method.
+func (m *MockMessagesUseCases) SendTypingIndicator(ctx context.Context, accessToken string, chatID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTypingIndicator", ctx, accessToken, chatID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendTypingIndicator indicates an expected call of SendTypingIndicator.
+func (mr *MockMessagesUseCasesMockRecorder) SendTypingIndicator(ctx, accessToken, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTypingIndicator", reflect.TypeOf((*MockMessagesUseCases)(nil).SendTypingIndicator), ctx, accessToken, chatID)
+}
+
+// SetChatMessageTTL mocks base method.
+func (m *MockMessagesUseCases) SetChatMessageTTL(ctx context.Context, accessToken string, chatID uint64, messageTTL domains.MessageTTL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatMessageTTL", ctx, accessToken, chatID, messageTTL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatMessageTTL indicates an expected call of SetChatMessageTTL.
+func (mr *MockMessagesUseCasesMockRecorder) SetChatMessageTTL(ctx, accessToken, chatID, messageTTL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatMessageTTL", reflect.TypeOf((*MockMessagesUseCases)(nil).SetChatMessageTTL), ctx, accessToken, chatID, messageTTL)
+}
+
+// UnpinMessage mocks base method.
+func (m *MockMessagesUseCases) UnpinMessage(ctx context.Context, accessToken string, chatID, messageID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinMessage", ctx, accessToken, chatID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinMessage indicates an expected call of UnpinMessage.
+func (mr *MockMessagesUseCasesMockRecorder) UnpinMessage(ctx, accessToken, chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).UnpinMessage), ctx, accessToken, chatID, messageID)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockMessagesUseCases) UpdateMessage(ctx context.Context, messageData domains.RawUpdateMessageDTO) (*domains.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockMessagesUseCasesMockRecorder) UpdateMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).UpdateMessage), ctx, messageData)
+}
+
+// UpdateScheduledMessage mocks base method.
+func (m *MockMessagesUseCases) UpdateScheduledMessage(ctx context.Context, messageData domains.RawUpdateScheduledMessageDTO) (*domains.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledMessage", ctx, messageData)
+	ret0, _ := ret[0].(*domains.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledMessage indicates an expected call of UpdateScheduledMessage.
+func (mr *MockMessagesUseCasesMockRecorder) UpdateScheduledMessage(ctx, messageData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledMessage", reflect.TypeOf((*MockMessagesUseCases)(nil).UpdateScheduledMessage), ctx, messageData)
+}
+
+// VotePoll mocks base method.
+func (m *MockMessagesUseCases) VotePoll(ctx context.Context, voteData domains.RawPollVoteDTO) (*domains.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VotePoll", ctx, voteData)
+	ret0, _ := ret[0].(*domains.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VotePoll indicates an expected call of VotePoll.
+func (mr *MockMessagesUseCasesMockRecorder) VotePoll(ctx, voteData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VotePoll", reflect.TypeOf((*MockMessagesUseCases)(nil).VotePoll), ctx, voteData)
+}
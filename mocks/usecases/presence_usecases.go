@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/presence_usecases.go -package=mockusecases -exclude_interfaces=UsersUseCases,AuthUseCases,ChatsUseCases,MessagesUseCases,AttachmentsUseCases
+//
+
+// Package mockusecases is a generated GoMock package.
+package mockusecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresenceUseCases is a mock of PresenceUseCases interface.
+type MockPresenceUseCases struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceUseCasesMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceUseCasesMockRecorder is the mock recorder for MockPresenceUseCases.
+type MockPresenceUseCasesMockRecorder struct {
+	mock *MockPresenceUseCases
+}
+
+// NewMockPresenceUseCases creates a new mock instance.
+func NewMockPresenceUseCases(ctrl *gomock.Controller) *MockPresenceUseCases {
+	mock := &MockPresenceUseCases{ctrl: ctrl}
+	mock.recorder = &MockPresenceUseCasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceUseCases) EXPECT() *MockPresenceUseCasesMockRecorder {
+	return m.recorder
+}
+
+// ConnectUser mocks base method.
+func (m *MockPresenceUseCases) ConnectUser(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectUser indicates an expected call of ConnectUser.
+func (mr *MockPresenceUseCasesMockRecorder) ConnectUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectUser", reflect.TypeOf((*MockPresenceUseCases)(nil).ConnectUser), ctx, userID)
+}
+
+// DisconnectUser mocks base method.
+func (m *MockPresenceUseCases) DisconnectUser(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectUser indicates an expected call of DisconnectUser.
+func (mr *MockPresenceUseCasesMockRecorder) DisconnectUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectUser", reflect.TypeOf((*MockPresenceUseCases)(nil).DisconnectUser), ctx, userID)
+}
+
+// KeepUserOnline mocks base method.
+func (m *MockPresenceUseCases) KeepUserOnline(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeepUserOnline", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// KeepUserOnline indicates an expected call of KeepUserOnline.
+func (mr *MockPresenceUseCasesMockRecorder) KeepUserOnline(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeepUserOnline", reflect.TypeOf((*MockPresenceUseCases)(nil).KeepUserOnline), ctx, userID)
+}
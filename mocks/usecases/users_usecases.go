@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=usecases.go -destination=../../mocks/usecases/users_usecases.go -package=mockusecases -exclude_interfaces=AuthUseCases
+//	mockgen -source=usecases.go -destination=../../mocks/usecases/users_usecases.go -package=mockusecases -exclude_interfaces=AuthUseCases,ChatsUseCases,MessagesUseCases,PresenceUseCases,AttachmentsUseCases
 //
 
 // Package mockusecases is a generated GoMock package.
@@ -42,10 +42,10 @@ func (m *MockUsersUseCases) EXPECT() *MockUsersUseCasesMockRecorder {
 }
 
 // GetMe mocks base method.
-func (m *MockUsersUseCases) GetMe(ctx context.Context, accessToken string) (*domains.User, error) {
+func (m *MockUsersUseCases) GetMe(ctx context.Context, accessToken string) (*domains.UserWithPresence, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMe", ctx, accessToken)
-	ret0, _ := ret[0].(*domains.User)
+	ret0, _ := ret[0].(*domains.UserWithPresence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -56,26 +56,11 @@ func (mr *MockUsersUseCasesMockRecorder) GetMe(ctx, accessToken any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockUsersUseCases)(nil).GetMe), ctx, accessToken)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockUsersUseCases) GetUserByEmail(ctx context.Context, email string) (*domains.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
-	ret0, _ := ret[0].(*domains.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockUsersUseCasesMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUsersUseCases)(nil).GetUserByEmail), ctx, email)
-}
-
 // GetUserByID mocks base method.
-func (m *MockUsersUseCases) GetUserByID(ctx context.Context, id uint64) (*domains.User, error) {
+func (m *MockUsersUseCases) GetUserByID(ctx context.Context, id uint64) (*domains.UserWithPresence, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
-	ret0, _ := ret[0].(*domains.User)
+	ret0, _ := ret[0].(*domains.UserWithPresence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -87,10 +72,10 @@ func (mr *MockUsersUseCasesMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 }
 
 // GetUsers mocks base method.
-func (m *MockUsersUseCases) GetUsers(ctx context.Context, filters *domains.UsersFilters, pagination *domains.Pagination) ([]domains.User, error) {
+func (m *MockUsersUseCases) GetUsers(ctx context.Context, filters *domains.UsersFilters, pagination *domains.Pagination) ([]domains.UserWithPresence, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUsers", ctx, filters, pagination)
-	ret0, _ := ret[0].([]domains.User)
+	ret0, _ := ret[0].([]domains.UserWithPresence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -102,11 +87,12 @@ func (mr *MockUsersUseCasesMockRecorder) GetUsers(ctx, filters, pagination any)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUsersUseCases) UpdateUser(ctx context.Context, userData domains.UpdateUserDTO) error {
+func (m *MockUsersUseCases) UpdateUser(ctx context.Context, userData domains.RawUpdateUserDTO) (*domains.UserWithPresence, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateUser", ctx, userData)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*domains.UserWithPresence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.